@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/guarzo/wanderer-sde/internal/codegen"
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// codegenTables are the tables the codegen subcommands draw from.
+var codegenTables = []string{
+	config.TableTypes, config.TableGroups, config.TableRegions, config.TableWormholeClasses,
+}
+
+// codegenPackage and codegenTypeCategories are the codegen go command's own flags.
+var (
+	codegenPackage        string
+	codegenTypeCategories []string
+)
+
+var codegenCmd = &cobra.Command{
+	Use:   "codegen",
+	Short: "Generate source code declaring well-known SDE IDs",
+}
+
+var codegenGoCmd = &cobra.Command{
+	Use:   "go",
+	Short: "Generate a Go package of typed ID constants",
+	Long: `Generates a Go package declaring typed constants for every item
+category and group, the published types of the selected categories, every
+region and every wormhole class, written to <output>/<package>/<package>.go:
+
+  const (
+  	// CategoryShip is the category Ship (SDE build 3142455).
+  	CategoryShip CategoryID = 6
+  	...
+  )
+
+Constants are named after their English names with everything but ASCII
+letters and digits removed, e.g. TypeAugmentedHammerheadII. When names
+collide the lowest ID keeps the name and the others get their ID appended,
+e.g. TypeCapsule_33328. Each constant's doc comment quotes the SDE build,
+which is "unknown" for an SDE read from disk.`,
+	Example: `  # Generate output/eveids/eveids.go with ship type constants
+  sdeconvert codegen go --sde-path ./sde --output ./output
+
+  # Generate package sdeids with ship and drone type constants
+  sdeconvert codegen go --sde-path ./sde --output ./output --package sdeids --type-category Ship,Drone`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return loadConfig(cmd)
+	},
+	RunE: runCodegenGo,
+}
+
+func init() {
+	rootCmd.AddCommand(codegenCmd)
+	codegenCmd.AddCommand(codegenGoCmd)
+
+	codegenGoCmd.Flags().StringVar(&codegenPackage, "package", "eveids", "Name of the generated Go package and its directory")
+	codegenGoCmd.Flags().StringSliceVar(&codegenTypeCategories, "type-category", []string{"Ship"}, "Comma-separated category names or IDs whose published types get constants")
+}
+
+func runCodegenGo(cmd *cobra.Command, args []string) error {
+	if !token.IsIdentifier(codegenPackage) {
+		return fmt.Errorf("invalid --package %q: must be a Go identifier", codegenPackage)
+	}
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	ds, err := loadDataset(ctx, codegenTables)
+	if err != nil {
+		return err
+	}
+	data := ds.Data()
+
+	typeCategories, err := selectCategories(data.InvCategories, codegenTypeCategories)
+	if err != nil {
+		return err
+	}
+	categories := make([]codegen.Named, len(data.InvCategories))
+	for i, c := range data.InvCategories {
+		categories[i] = codegen.Named{ID: c.CategoryID, Name: c.CategoryName}
+	}
+	blocks := codegen.Blocks(data, categories, typeCategories)
+
+	build := ""
+	if ds.Version != nil {
+		build = ds.Version.BuildNumber
+	}
+
+	dir := filepath.Join(cfg.OutputDir, codegenPackage)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create package directory: %w", err)
+	}
+	name := codegenPackage + ".go"
+	if err := writeOutputFile(filepath.Join(dir, name), func(w io.Writer) error {
+		return codegen.WriteGo(w, codegenPackage, build, blocks)
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nCode generation complete! Output written to: %s\n", dir)
+	counts := make([]string, len(blocks))
+	for i, block := range blocks {
+		counts[i] = fmt.Sprintf("%d %s", len(block.Constants), block.Type)
+	}
+	fmt.Printf("  - %s (%s)\n", name, strings.Join(counts, ", "))
+
+	return nil
+}
+
+// selectCategories resolves category names, case-insensitively, or IDs.
+func selectCategories(categories []models.InvCategory, names []string) ([]int64, error) {
+	var ids []int64
+	for _, name := range names {
+		found := false
+		for _, c := range categories {
+			if strings.EqualFold(c.CategoryName, name) || strconv.FormatInt(c.CategoryID, 10) == name {
+				ids = append(ids, c.CategoryID)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown category %q", name)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/guarzo/wanderer-sde/internal/graph"
+)
+
+// graphFormats and graphLevel are the graph command's own flags.
+var (
+	graphFormats []string
+	graphLevel   string
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Export the stargate network as GraphML, DOT and GEXF",
+	Long: `Exports the stargate network as an undirected graph for graph tools
+such as Gephi, yEd, Graphviz and NetworkX.
+
+Each solar system is a node carrying its name, security, region,
+constellation and coordinates, and each stargate pair is one edge.
+With --collapse the systems of each constellation or region are merged
+into one node at their centroid, and the gates between two nodes into
+one edge weighted by the number of gates.`,
+	Example: `  # Write gateNetwork.graphml, .dot and .gexf
+  sdeconvert graph --sde-path ./sde --output ./output
+
+  # Region-level GraphML only
+  sdeconvert graph --sde-path ./sde --output ./output --graph-format graphml --collapse region`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return loadConfig(cmd)
+	},
+	RunE: runGraph,
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+
+	graphCmd.Flags().StringSliceVar(&graphFormats, "graph-format", []string{"graphml", "dot", "gexf"}, "Comma-separated graph formats: graphml,dot,gexf")
+	graphCmd.Flags().StringVar(&graphLevel, "collapse", string(graph.LevelSystem), "Node level: system, constellation or region")
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	level, err := graph.ParseLevel(graphLevel)
+	if err != nil {
+		return err
+	}
+	var formats []graph.Format
+	for _, name := range graphFormats {
+		format, err := graph.ParseFormat(name)
+		if err != nil {
+			return err
+		}
+		formats = append(formats, format)
+	}
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	ds, err := loadMapDataset(ctx)
+	if err != nil {
+		return err
+	}
+
+	g, err := graph.Build(ds.Data(), level)
+	if err != nil {
+		return fmt.Errorf("failed to build graph: %w", err)
+	}
+
+	if err := os.MkdirAll(cfg.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for _, format := range formats {
+		name := graph.FileName(level, format)
+		if err := writeOutputFile(filepath.Join(cfg.OutputDir, name), func(w io.Writer) error {
+			return graph.Encode(w, g, format)
+		}); err != nil {
+			return err
+		}
+		fmt.Printf("  - %s (%d nodes, %d edges)\n", name, len(g.Nodes), len(g.Edges))
+	}
+
+	fmt.Printf("\nGraph export complete! Output written to: %s\n", cfg.OutputDir)
+
+	return nil
+}
@@ -4,35 +4,28 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/guarzo/wanderer-sde/internal/config"
-	"github.com/guarzo/wanderer-sde/internal/downloader"
-	"github.com/guarzo/wanderer-sde/internal/parser"
-	"github.com/guarzo/wanderer-sde/internal/transformer"
+	"github.com/guarzo/wanderer-sde/internal/models"
 	"github.com/guarzo/wanderer-sde/internal/writer"
+	"github.com/guarzo/wanderer-sde/pkg/sde"
 )
 
 // Version is set at build time via ldflags.
 var Version = "dev"
 
-// SDEMetadata contains metadata about the SDE conversion.
-type SDEMetadata struct {
-	SDEVersion  string `json:"sde_version"`
-	ReleaseDate string `json:"release_date"`
-	GeneratedBy string `json:"generated_by"`
-	GeneratedAt string `json:"generated_at"`
-	Source      string `json:"source"`
-}
-
 // MetadataFileName is the name of the metadata output file.
 const MetadataFileName = "sde_metadata.json"
 
@@ -45,11 +38,14 @@ func main() {
 
 var cfg = config.NewConfig()
 
+// configFile is the path given with --config.
+var configFile string
+
 var rootCmd = &cobra.Command{
 	Use:   "sdeconvert",
 	Short: "Convert EVE SDE to Wanderer data format",
 	Long: `Converts EVE Online's Static Data Export (SDE) YAML files
-into CSV or JSON format compatible with Wanderer/Fuzzwork.
+into CSV, JSON, NDJSON, Parquet or Erlang term format compatible with Wanderer/Fuzzwork.
 
 This tool can download the latest SDE from CCP or use an existing
 SDE directory, then parse the YAML files and generate output files
@@ -60,6 +56,24 @@ compatible with Wanderer's data format.`,
   # Convert to JSON format instead
   sdeconvert --download --output ./output --format json
 
+  # Write one universe.json with tables keyed by ID, e.g. {"30000142": {...}}
+  sdeconvert --download --output ./output --format json --json-layout keyed --bundle
+
+  # Also write regions/<regionID>/systems.json, ... for lazy loading
+  sdeconvert --download --output ./output --format json --split-by region
+
+  # Stream gzipped newline-delimited JSON, one record per line
+  sdeconvert --download --output ./output --format ndjson --compress gzip
+
+  # Write zstd-compressed CSV files (invTypes.csv.zst, ...)
+  sdeconvert --download --output ./output --compress zstd
+
+  # Write Parquet files for an analytics warehouse
+  sdeconvert --download --output ./output --format parquet
+
+  # Write Erlang terms with binary keys for :erlang.binary_to_term(bin, [:safe])
+  sdeconvert --download --output ./output --format etf --etf-keys binary
+
   # Convert an existing SDE directory
   sdeconvert --sde-path ./sde --output ./output
 
@@ -67,7 +81,13 @@ compatible with Wanderer's data format.`,
   sdeconvert --sde-path ./sde --output ./output --passthrough ../wanderer/priv/repo/data
 
   # Verbose mode with custom worker count
-  sdeconvert --download --output ./output --verbose --workers 8`,
+  sdeconvert --download --output ./output --verbose --workers 8
+
+  # Only generate map data, skipping the slow types.yaml parse
+  sdeconvert --sde-path ./sde --output ./output --only systems,jumps,wormholeClasses
+
+  # Load settings from a config file, overriding the format from the environment
+  SDECONVERT_FORMAT=json sdeconvert --config sdeconvert.yaml`,
 	RunE: runConversion,
 }
 
@@ -83,41 +103,78 @@ var versionCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(versionCmd)
 
-	rootCmd.Flags().StringVarP(&cfg.SDEPath, "sde-path", "s", "", "Path to SDE directory or ZIP file")
-	rootCmd.Flags().StringVarP(&cfg.OutputDir, "output", "o", "./output", "Output directory for output files")
-	rootCmd.Flags().BoolVarP(&cfg.DownloadSDE, "download", "d", false, "Download latest SDE from CCP")
+	// Source and output flags are shared with subcommands such as graph
+	rootCmd.PersistentFlags().StringVarP(&cfg.SDEPath, "sde-path", "s", "", "Path to SDE directory or ZIP file")
+	rootCmd.PersistentFlags().StringVarP(&cfg.OutputDir, "output", "o", "./output", "Output directory for output files")
+	rootCmd.PersistentFlags().BoolVarP(&cfg.DownloadSDE, "download", "d", false, "Download latest SDE from CCP")
+	rootCmd.PersistentFlags().BoolVarP(&cfg.Verbose, "verbose", "v", false, "Enable verbose output")
+	rootCmd.PersistentFlags().IntVarP(&cfg.Workers, "workers", "w", 4, "Number of parallel workers")
+	rootCmd.PersistentFlags().StringVar(&cfg.SDEUrl, "sde-url", config.SDELatestURL, "URL to download SDE from")
+	rootCmd.PersistentFlags().StringVar(&cfg.VersionURL, "version-url", config.SDEVersionURL, "URL to check the latest SDE build number")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Path to a YAML or TOML config file (or set "+config.EnvConfigFile+")")
+
 	rootCmd.Flags().StringVarP(&cfg.PassthroughDir, "passthrough", "p", "", "Directory with Wanderer JSON files to copy")
-	rootCmd.Flags().BoolVarP(&cfg.Verbose, "verbose", "v", false, "Enable verbose output")
 	rootCmd.Flags().BoolVar(&cfg.PrettyPrint, "pretty", true, "Pretty-print JSON output (only applies to JSON format)")
-	rootCmd.Flags().IntVarP(&cfg.Workers, "workers", "w", 4, "Number of parallel workers")
-	rootCmd.Flags().StringVar(&cfg.SDEUrl, "sde-url", config.SDELatestURL, "URL to download SDE from")
-
-	// Output format flag with custom handling
+	rootCmd.Flags().String("only", "", "Comma-separated tables to generate: "+strings.Join(config.AllTables, ","))
+	rootCmd.Flags().String("exclude", "", "Comma-separated tables to skip")
+	rootCmd.Flags().String("languages", "", "Comma-separated extra languages for names: "+strings.Join(config.SupportedLanguages, ","))
+	rootCmd.Flags().BoolVar(&cfg.NestedNames, "json-nested-names", false, "Write translated JSON names as {lang: text} objects")
+	rootCmd.Flags().BoolVar(&cfg.MarketGroupTree, "market-group-tree", false, "Also write the market group hierarchy as a nested JSON tree")
+	rootCmd.Flags().BoolVar(&cfg.SecurityColumns, "security-columns", false, "Add display security, security band and wormhole space columns to solar systems")
+	rootCmd.Flags().BoolVar(&cfg.ProjectionColumns, "projection-columns", false, "Add projected 2D map coordinates (X/Z plane, light-years) to solar systems")
+	rootCmd.Flags().String("dogma-types", "", "Limit per-type dogma tables to types matching filters: published,ships")
+
+	// Output format is parsed by the config package so file and env values share validation
 	var formatStr string
-	rootCmd.Flags().StringVarP(&formatStr, "format", "f", "csv", "Output format: csv or json (default: csv)")
+	rootCmd.Flags().StringVarP(&formatStr, "format", "f", "csv", "Output format: csv, json, ndjson, parquet or etf (default: csv)")
+	rootCmd.Flags().String("json-layout", string(config.JSONLayoutArray), "Lay out JSON tables as an array or as objects keyed by ID: array or keyed")
+	rootCmd.Flags().BoolVar(&cfg.Bundle, "bundle", false, "Write all tables, the SDE metadata and the schema version to a single universe.json (requires JSON format)")
+	rootCmd.Flags().String("split-by", string(config.SplitByNone), "Also write systems, constellations, jumps and wormhole classes per region: none or region (CSV and JSON formats)")
+	rootCmd.Flags().String("etf-keys", string(config.ETFKeysAtom), "Encode ETF record keys as atom or binary (only applies to ETF format)")
+	rootCmd.Flags().String("compress", string(config.CompressionNone), "Compress every output file: none, gzip or zstd")
 	rootCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
-		switch formatStr {
-		case "csv":
-			cfg.OutputFormat = config.FormatCSV
-		case "json":
-			cfg.OutputFormat = config.FormatJSON
-		default:
-			return fmt.Errorf("invalid format '%s': must be 'csv' or 'json'", formatStr)
-		}
-		return nil
+		return loadConfig(cmd)
 	}
 }
 
-func runConversion(cmd *cobra.Command, args []string) error {
-	if err := cfg.Validate(); err != nil {
+// loadConfig resolves the configuration with precedence flag > env > file > default.
+func loadConfig(cmd *cobra.Command) error {
+	resolved := config.NewConfig()
+
+	path := configFile
+	if path == "" {
+		path = os.Getenv(config.EnvConfigFile)
+	}
+	if path != "" {
+		if err := resolved.LoadFile(path); err != nil {
+			return err
+		}
+	}
+
+	if err := resolved.ApplyEnv(os.LookupEnv); err != nil {
 		return err
 	}
 
-	// Setup context with cancellation for graceful shutdown
+	// Only flags given explicitly on the command line override file and env values
+	var flagErr error
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if flagErr != nil || !config.IsSetting(f.Name) {
+			return
+		}
+		flagErr = resolved.Set(f.Name, f.Value.String(), config.Source{Kind: config.SourceFlag, Name: "--" + f.Name})
+	})
+	if flagErr != nil {
+		return flagErr
+	}
+
+	*cfg = *resolved
+	return nil
+}
+
+// signalContext returns a context that is cancelled on interrupt, for graceful shutdown.
+func signalContext() (context.Context, context.CancelFunc) {
 	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
 
-	// Handle interrupt signals
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	go func() {
@@ -126,6 +183,60 @@ func runConversion(cmd *cobra.Command, args []string) error {
 		cancel()
 	}()
 
+	return ctx, cancel
+}
+
+// mapTables are the tables the graph and render subcommands draw from.
+var mapTables = []string{config.TableRegions, config.TableConstellations, config.TableSystems, config.TableJumps}
+
+// loadMapDataset loads only the map tables, whatever the config file selects,
+// and fails on validation errors.
+func loadMapDataset(ctx context.Context) (*sde.Dataset, error) {
+	return loadDataset(ctx, mapTables)
+}
+
+// loadDataset loads only the given tables, whatever the config file selects,
+// and fails on validation errors.
+func loadDataset(ctx context.Context, tables []string) (*sde.Dataset, error) {
+	cfg.Tables = tables
+	cfg.ExcludeTables = nil
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	source := sde.Source{
+		Path:       cfg.SDEPath,
+		Download:   cfg.DownloadSDE,
+		CacheDir:   cfg.OutputDir,
+		URL:        cfg.SDEUrl,
+		VersionURL: cfg.VersionURL,
+	}
+	ds, err := sde.Load(ctx, source,
+		sde.WithTables(tables...),
+		sde.WithThresholds(cfg.Thresholds),
+		sde.WithVerbose(cfg.Verbose),
+		sde.WithLog(os.Stdout),
+	)
+	if err == nil {
+		return ds, nil
+	}
+	if errors.As(err, new(*sde.ValidationError)) {
+		fmt.Println("\nErrors:")
+		for _, e := range ds.Validation.Errors {
+			fmt.Printf("  - %s\n", e)
+		}
+	}
+	return nil, err
+}
+
+func runConversion(cmd *cobra.Command, args []string) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
 	if cfg.Verbose {
 		fmt.Println("Configuration:")
 		fmt.Printf("  SDE Path:     %s\n", cfg.SDEPath)
@@ -134,118 +245,61 @@ func runConversion(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Download:     %v\n", cfg.DownloadSDE)
 		fmt.Printf("  Passthrough:  %s\n", cfg.PassthroughDir)
 		fmt.Printf("  Workers:      %d\n", cfg.Workers)
+		fmt.Printf("  Tables:       %s\n", strings.Join(cfg.EnabledTables(), ", "))
 	}
 
-	sdePath := cfg.SDEPath
-	var versionInfo *downloader.VersionInfo
-
-	// Step 1: Download SDE if requested
-	if cfg.DownloadSDE {
-		dl := downloader.New(cfg)
-		vc := downloader.NewVersionChecker(cfg)
-
-		// Default SDE path when downloading is <output-dir>/sde
-		if sdePath == "" {
-			sdePath = filepath.Join(cfg.OutputDir, "sde")
-		}
-
-		// Check if update is needed
-		var needsUpdate bool
-		var err error
-		needsUpdate, versionInfo, err = vc.NeedsUpdate(ctx, cfg.OutputDir)
-		if err != nil {
-			fmt.Printf("Warning: could not check SDE version: %v\n", err)
-			needsUpdate = true // Proceed with download anyway
-		}
-
-		// Also check if the SDE directory exists
-		if _, err := os.Stat(sdePath); os.IsNotExist(err) {
-			needsUpdate = true
-		}
-
-		if needsUpdate {
-			fmt.Println("Downloading latest SDE...")
-
-			// Download to a temp location first
-			downloadedPath, err := dl.DownloadAndExtract(ctx)
-			if err != nil {
-				return fmt.Errorf("failed to download SDE: %w", err)
-			}
-
-			// Remove old SDE directory if it exists
-			if err := os.RemoveAll(sdePath); err != nil && !os.IsNotExist(err) {
-				fmt.Printf("Warning: could not remove old SDE: %v\n", err)
-			}
-
-			// Move downloaded SDE to the persistent location
-			if err := os.Rename(downloadedPath, sdePath); err != nil {
-				// If rename fails (cross-device), fall back to copy
-				if err := copyDir(downloadedPath, sdePath); err != nil {
-					return fmt.Errorf("failed to move SDE to %s: %w", sdePath, err)
-				}
-				_ = os.RemoveAll(downloadedPath)
-			}
-
-			fmt.Printf("SDE downloaded and extracted to: %s\n", sdePath)
-
-			// Store the version
-			if versionInfo != nil {
-				if err := vc.StoreVersion(cfg.OutputDir, versionInfo.BuildNumber); err != nil {
-					fmt.Printf("Warning: could not store version: %v\n", err)
-				}
-			}
-		} else {
-			fmt.Println("SDE is up to date, using cached version")
-		}
+	// Steps 1-3: Download, parse and transform the SDE
+	source := sde.Source{
+		Path:       cfg.SDEPath,
+		Download:   cfg.DownloadSDE,
+		CacheDir:   cfg.OutputDir,
+		URL:        cfg.SDEUrl,
+		VersionURL: cfg.VersionURL,
 	}
-
-	if sdePath == "" {
-		return fmt.Errorf("no SDE path available")
+	ds, err := sde.Load(ctx, source,
+		sde.WithTables(cfg.Tables...),
+		sde.WithoutTables(cfg.ExcludeTables...),
+		sde.WithDogmaTypes(cfg.DogmaTypes...),
+		sde.WithMarketGroupTree(cfg.MarketGroupTree),
+		sde.WithSecurityColumns(cfg.SecurityColumns),
+		sde.WithProjectionColumns(cfg.ProjectionColumns),
+		sde.WithThresholds(cfg.Thresholds),
+		sde.WithVerbose(cfg.Verbose),
+		sde.WithLog(os.Stdout),
+	)
+	// Validation errors are reported below, after the counts and warnings
+	if err != nil && !errors.As(err, new(*sde.ValidationError)) {
+		return err
 	}
+	convertedData := ds.Data()
 
-	// Validate the SDE structure
-	dl := downloader.New(cfg)
-	if err := dl.Validate(sdePath); err != nil {
-		return fmt.Errorf("SDE validation failed: %w", err)
+	// Report the validation of the converted data
+	validationResult := ds.Validation
+	fmt.Printf("\nValidation results:\n")
+	validationCounts := []struct {
+		table string
+		label string
+		count int
+	}{
+		{config.TableRegions, "Regions:        ", validationResult.Regions},
+		{config.TableConstellations, "Constellations: ", validationResult.Constellations},
+		{config.TableSystems, "Solar Systems:  ", validationResult.SolarSystems},
+		{config.TableTypes, "Types:          ", validationResult.InvTypes},
+		{config.TableGroups, "Groups:         ", validationResult.InvGroups},
+		{config.TableWormholeClasses, "Wormhole Classes:", validationResult.WormholeClasses},
+		{config.TableJumps, "System Jumps:   ", validationResult.SystemJumps},
+		{config.TableStations, "Stations:       ", validationResult.Stations},
+		{config.TableFactions, "Factions:       ", validationResult.Factions},
+		{config.TableCorporations, "Corporations:   ", validationResult.Corporations},
+		{config.TableMarketGroups, "Market Groups:  ", validationResult.MarketGroups},
+		{config.TableTypeAttributes, "Type Attributes:", validationResult.TypeAttributes},
 	}
-
-	fmt.Printf("Using SDE at: %s\n", sdePath)
-
-	// Step 2: Parse SDE YAML files
-	p := parser.New(cfg, sdePath)
-	parseResult, err := p.ParseAll()
-	if err != nil {
-		return fmt.Errorf("failed to parse SDE: %w", err)
-	}
-
-	fmt.Printf("\nParsing complete:\n")
-	fmt.Printf("  Regions:         %d\n", len(parseResult.Regions))
-	fmt.Printf("  Constellations:  %d\n", len(parseResult.Constellations))
-	fmt.Printf("  Solar Systems:   %d\n", len(parseResult.SolarSystems))
-	fmt.Printf("  Types:           %d\n", len(parseResult.Types))
-	fmt.Printf("  Groups:          %d\n", len(parseResult.Groups))
-	fmt.Printf("  Categories:      %d\n", len(parseResult.Categories))
-	fmt.Printf("  Wormhole Classes: %d\n", len(parseResult.WormholeClasses))
-	fmt.Printf("  System Jumps:    %d\n", len(parseResult.SystemJumps))
-
-	// Step 3: Transform data
-	t := transformer.New(cfg)
-	convertedData, err := t.Transform(parseResult)
-	if err != nil {
-		return fmt.Errorf("failed to transform data: %w", err)
+	for _, c := range validationCounts {
+		if cfg.TableEnabled(c.table) {
+			fmt.Printf("  %s %d\n", c.label, c.count)
+		}
 	}
 
-	// Validate the converted data
-	validationResult := t.Validate(convertedData)
-	fmt.Printf("\nValidation results:\n")
-	fmt.Printf("  Regions:         %d\n", validationResult.Regions)
-	fmt.Printf("  Constellations:  %d\n", validationResult.Constellations)
-	fmt.Printf("  Solar Systems:   %d\n", validationResult.SolarSystems)
-	fmt.Printf("  Types:           %d\n", validationResult.InvTypes)
-	fmt.Printf("  Groups:          %d\n", validationResult.InvGroups)
-	fmt.Printf("  Wormhole Classes: %d\n", validationResult.WormholeClasses)
-	fmt.Printf("  System Jumps:    %d\n", validationResult.SystemJumps)
-
 	if len(validationResult.Warnings) > 0 {
 		fmt.Println("\nWarnings:")
 		for _, warning := range validationResult.Warnings {
@@ -261,21 +315,33 @@ func runConversion(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("validation failed with %d errors", len(validationResult.Errors))
 	}
 
+	var metadata *models.SDEMetadata
+	if ds.Version != nil {
+		metadata = newMetadata(ds.Version)
+	}
+
 	// Step 4: Write output files
 	w, err := writer.NewWriter(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create writer: %w", err)
 	}
-	if err := w.WriteAll(convertedData); err != nil {
-		return fmt.Errorf("failed to write output: %w", err)
-	}
+	if cfg.Bundle {
+		// The bundle carries the metadata itself
+		if err := writer.New(cfg).WriteBundle(convertedData, metadata); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	} else {
+		if err := w.WriteAll(convertedData); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
 
-	// Step 5: Write metadata file
-	if versionInfo != nil {
-		if err := writeMetadata(cfg.OutputDir, versionInfo); err != nil {
-			fmt.Printf("Warning: could not write metadata file: %v\n", err)
-		} else if cfg.Verbose {
-			fmt.Printf("  Wrote %s\n", MetadataFileName)
+		// Step 5: Write metadata file
+		if metadata != nil {
+			if err := writeMetadata(cfg.OutputDir, metadata, cfg.Compression); err != nil {
+				fmt.Printf("Warning: could not write metadata file: %v\n", err)
+			} else if cfg.Verbose {
+				fmt.Printf("  Wrote %s\n", MetadataFileName+cfg.Compression.Extension())
+			}
 		}
 	}
 
@@ -289,82 +355,113 @@ func runConversion(cmd *cobra.Command, args []string) error {
 	fmt.Printf("\nConversion complete! Output written to: %s\n", cfg.OutputDir)
 	fmt.Printf("Generated files (%s format):\n", cfg.OutputFormat)
 
-	outputFiles := writer.GetOutputFiles(cfg.OutputFormat)
-	counts := []int{
-		len(convertedData.Universe.SolarSystems),
-		len(convertedData.Universe.Regions),
-		len(convertedData.Universe.Constellations),
-		len(convertedData.WormholeClasses),
-		len(convertedData.InvTypes),
-		len(convertedData.InvGroups),
-		len(convertedData.SystemJumps),
+	summary := []struct {
+		table string
+		count int
+		label string
+	}{
+		{config.TableSystems, len(convertedData.Universe.SolarSystems), "systems"},
+		{config.TableRegions, len(convertedData.Universe.Regions), "regions"},
+		{config.TableConstellations, len(convertedData.Universe.Constellations), "constellations"},
+		{config.TableWormholeClasses, len(convertedData.WormholeClasses), "classes"},
+		{config.TableSystemWormholeClasses, len(convertedData.SystemWormholeClasses), "systems"},
+		{config.TableTypes, len(convertedData.InvTypes), "types"},
+		{config.TableGroups, len(convertedData.InvGroups), "groups"},
+		{config.TableJumps, len(convertedData.SystemJumps), "jumps"},
+		{config.TableRegionJumps, len(convertedData.RegionJumps), "connections"},
+		{config.TableConstellationJumps, len(convertedData.ConstellationJumps), "connections"},
+		{config.TableStargates, len(convertedData.Stargates), "stargates"},
+		{config.TableSystemTopology, len(convertedData.SystemTopology), "systems"},
+		{config.TableStations, len(convertedData.Stations), "stations"},
+		{config.TableStationServices, len(convertedData.StationServices), "services"},
+		{config.TableOperationServices, len(convertedData.OperationServices), "links"},
+		{config.TableFactions, len(convertedData.Factions), "factions"},
+		{config.TableRaces, len(convertedData.Races), "races"},
+		{config.TableCorporations, len(convertedData.Corporations), "corporations"},
+		{config.TableMarketGroups, len(convertedData.MarketGroups), "market groups"},
+		{config.TableDogmaAttributes, len(convertedData.DogmaAttributes), "attributes"},
+		{config.TableDogmaEffects, len(convertedData.DogmaEffects), "effects"},
+		{config.TableTypeAttributes, len(convertedData.TypeAttributes), "values"},
+		{config.TableTypeEffects, len(convertedData.TypeEffects), "links"},
 	}
-	labels := []string{"systems", "regions", "constellations", "classes", "types", "groups", "jumps"}
 
-	for i, file := range outputFiles {
-		fmt.Printf("  - %s (%d %s)\n", file, counts[i], labels[i])
+	ext := cfg.Compression.Extension()
+	// A bundle lists its tables and side files beneath it
+	indent, topologyReportName, marketGroupTreeName := "  ", writer.FileTopologyReport+ext, writer.FileMarketGroupTree+ext
+	if cfg.Bundle {
+		fmt.Printf("  - %s\n", writer.FileBundle+ext)
+		indent, topologyReportName, marketGroupTreeName = "    ", "topologyReport", "marketGroupTree"
 	}
 
-	return nil
-}
-
-// copyDir recursively copies a directory tree.
-func copyDir(src, dst string) error {
-	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Calculate destination path
-		relPath, err := filepath.Rel(src, path)
-		if err != nil {
-			return err
+	for _, entry := range summary {
+		if !cfg.TableEnabled(entry.table) {
+			continue
 		}
-		dstPath := filepath.Join(dst, relPath)
-
-		if info.IsDir() {
-			return os.MkdirAll(dstPath, info.Mode())
+		name := writer.OutputFile(cfg.OutputFormat, cfg.Compression, entry.table)
+		if cfg.Bundle {
+			name = entry.table
 		}
+		fmt.Printf("%s- %s (%d %s)\n", indent, name, entry.count, entry.label)
+	}
+	if report := convertedData.TopologyReport; report != nil {
+		fmt.Printf("%s- %s (%d chokepoints, %d bridges, %d dead ends, %d pipes)\n", indent, topologyReportName,
+			len(report.ArticulationPoints), len(report.Bridges), len(report.DeadEnds), len(report.Pipes))
+	}
+	if convertedData.MarketGroupTree != nil {
+		fmt.Printf("%s- %s (%d top-level groups)\n", indent, marketGroupTreeName, len(convertedData.MarketGroupTree))
+	}
+	if cfg.SplitBy == config.SplitByRegion {
+		fmt.Printf("  - %s/%s (%d region directories)\n", writer.RegionsDir, writer.FileRegionIndex+ext, len(convertedData.Universe.Regions))
+	}
 
-		return copyFile(path, dstPath)
-	})
+	return nil
 }
 
-// copyFile copies a single file.
-func copyFile(src, dst string) error {
-	srcFile, err := os.Open(src)
+// writeOutputFile creates the file at path and fills it with write.
+func writeOutputFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to create %s: %w", path, err)
 	}
-	defer func() { _ = srcFile.Close() }()
-
-	dstFile, err := os.Create(dst)
-	if err != nil {
-		return err
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
 	}
-	defer func() { _ = dstFile.Close() }()
-
-	_, err = io.Copy(dstFile, srcFile)
-	return err
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", path, err)
+	}
+	return nil
 }
 
-// writeMetadata writes the SDE metadata file to the output directory.
-func writeMetadata(outputDir string, versionInfo *downloader.VersionInfo) error {
-	metadata := SDEMetadata{
+// newMetadata describes the conversion of the given SDE build.
+func newMetadata(versionInfo *sde.VersionInfo) *models.SDEMetadata {
+	return &models.SDEMetadata{
 		SDEVersion:  versionInfo.BuildNumber,
 		ReleaseDate: versionInfo.ReleaseDate,
 		GeneratedBy: "wanderer-sde",
 		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 		Source:      "https://developers.eveonline.com/static-data",
 	}
+}
 
+// writeMetadata writes the SDE metadata file to the output directory,
+// compressed like the other output files.
+func writeMetadata(outputDir string, metadata *models.SDEMetadata, compression config.Compression) error {
 	data, err := json.MarshalIndent(metadata, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
-	metadataPath := filepath.Join(outputDir, MetadataFileName)
-	if err := os.WriteFile(metadataPath, data, 0644); err != nil {
+	metadataPath := filepath.Join(outputDir, MetadataFileName+compression.Extension())
+	file, err := writer.CreateFile(metadataPath, 0644, compression)
+	if err != nil {
+		return fmt.Errorf("failed to create metadata file: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write metadata file: %w", err)
+	}
+	if err := file.Close(); err != nil {
 		return fmt.Errorf("failed to write metadata file: %w", err)
 	}
 
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/render"
+	"github.com/guarzo/wanderer-sde/pkg/sde"
+)
+
+// RenderDir is the output subdirectory of the render command.
+const RenderDir = "map"
+
+// renderSize and renderRegions are the render command's own flags.
+var (
+	renderSize    int
+	renderRegions []string
+)
+
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Render 2D maps of the universe as SVG and GeoJSON",
+	Long: `Projects solar systems onto the X/Z plane, as seen from above in the
+in-game map, and writes into the map/ directory of the output:
+
+  universe.svg       all known-space systems and gates
+  regions/<id>.svg   one labelled map per region
+  universe.geojson   every system as a Point and every gate as a
+                     LineString, in projected light-year coordinates
+
+Systems are coloured by security as in game.`,
+	Example: `  # Render all maps
+  sdeconvert render --sde-path ./sde --output ./output
+
+  # Render only The Forge and Delve, 4096 pixels wide
+  sdeconvert render --sde-path ./sde --output ./output --region "The Forge",10000060 --size 4096`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return loadConfig(cmd)
+	},
+	RunE: runRender,
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+
+	renderCmd.Flags().IntVar(&renderSize, "size", 2048, "Length of the longer side of each SVG map, in pixels")
+	renderCmd.Flags().StringSliceVar(&renderRegions, "region", nil, "Comma-separated region names or IDs to render maps for (default all)")
+}
+
+func runRender(cmd *cobra.Command, args []string) error {
+	if renderSize < 100 {
+		return fmt.Errorf("invalid --size %d: must be at least 100 pixels", renderSize)
+	}
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	ds, err := loadMapDataset(ctx)
+	if err != nil {
+		return err
+	}
+	regions, err := selectRegions(ds, renderRegions)
+	if err != nil {
+		return err
+	}
+
+	data := ds.Data()
+	systems := data.Universe.SolarSystems
+	gates := render.Gates(data.SystemJumps)
+
+	dir := filepath.Join(cfg.OutputDir, RenderDir)
+	if err := os.MkdirAll(filepath.Join(dir, "regions"), 0755); err != nil {
+		return fmt.Errorf("failed to create map directory: %w", err)
+	}
+
+	universe := render.NewMap("New Eden", systems, gates, render.KnownSpace)
+	if err := writeOutputFile(filepath.Join(dir, "universe.svg"), func(w io.Writer) error {
+		return render.WriteSVG(w, universe, renderSize)
+	}); err != nil {
+		return err
+	}
+	fmt.Printf("  - universe.svg (%d systems, %d gates)\n", len(universe.Systems), len(universe.Gates))
+
+	all := render.NewMap("New Eden", systems, gates, func(models.SolarSystem) bool { return true })
+	if err := writeOutputFile(filepath.Join(dir, "universe.geojson"), func(w io.Writer) error {
+		return render.WriteGeoJSON(w, all)
+	}); err != nil {
+		return err
+	}
+	fmt.Printf("  - universe.geojson (%d systems, %d gates)\n", len(all.Systems), len(all.Gates))
+
+	written := 0
+	for _, region := range regions {
+		m := render.NewMap(region.RegionName, systems, gates, func(s models.SolarSystem) bool {
+			return s.RegionID == region.RegionID
+		})
+		if len(m.Systems) == 0 {
+			continue
+		}
+		m.Labels = true
+		name := filepath.Join("regions", strconv.FormatInt(region.RegionID, 10)+".svg")
+		if err := writeOutputFile(filepath.Join(dir, name), func(w io.Writer) error {
+			return render.WriteSVG(w, m, renderSize)
+		}); err != nil {
+			return err
+		}
+		written++
+	}
+	fmt.Printf("  - regions/<regionID>.svg (%d regions)\n", written)
+
+	fmt.Printf("\nRendering complete! Output written to: %s\n", dir)
+
+	return nil
+}
+
+// selectRegions resolves region names or IDs, or returns all regions when none are given.
+func selectRegions(ds *sde.Dataset, names []string) ([]models.Region, error) {
+	if len(names) == 0 {
+		return ds.Data().Universe.Regions, nil
+	}
+	var regions []models.Region
+	for _, name := range names {
+		region, ok := ds.RegionByName(name)
+		if !ok {
+			if id, err := strconv.ParseInt(name, 10, 64); err == nil {
+				region, ok = ds.Region(id)
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown region %q", name)
+		}
+		regions = append(regions, region)
+	}
+	return regions, nil
+}
@@ -0,0 +1,157 @@
+// Package codegen generates source code declaring typed constants for
+// well-known SDE IDs: categories, groups, selected types, regions and
+// wormhole classes, so services need not hard-code magic numbers.
+package codegen
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// Constant is one generated constant.
+type Constant struct {
+	// Name is the Go identifier, including the block prefix.
+	Name string
+	ID   int64
+	// Label is the English name the constant was derived from.
+	Label string
+}
+
+// Block is a group of constants sharing one Go type.
+type Block struct {
+	// Type is the Go type of the constants, e.g. TypeID.
+	Type string
+	// Doc describes what an ID of Type identifies.
+	Doc string
+	// Kind names one entity in doc comments, e.g. "type".
+	Kind      string
+	Constants []Constant
+}
+
+// Named is an ID with its English name.
+type Named struct {
+	ID   int64
+	Name string
+}
+
+// wormholeClassNames are the names of the well-known wormhole class IDs,
+// which the SDE does not name.
+var wormholeClassNames = map[int64]string{
+	1:  "C1",
+	2:  "C2",
+	3:  "C3",
+	4:  "C4",
+	5:  "C5",
+	6:  "C6",
+	7:  "HighSec",
+	8:  "LowSec",
+	9:  "NullSec",
+	12: "Thera",
+	13: "C13",
+	14: "Sentinel",
+	15: "Barbican",
+	16: "Vidette",
+	17: "Conflux",
+	18: "Redoubt",
+	25: "Pochven",
+}
+
+// Blocks returns the constant blocks for the converted data: every category
+// and group, the published types of typeCategories, every region and every
+// wormhole class in use.
+func Blocks(data *models.ConvertedData, categories []Named, typeCategories []int64) []Block {
+	groups := make([]Named, len(data.InvGroups))
+	categoryOf := make(map[int64]int64, len(data.InvGroups))
+	for i, g := range data.InvGroups {
+		groups[i] = Named{g.GroupID, g.GroupName}
+		categoryOf[g.GroupID] = g.CategoryID
+	}
+
+	var types []Named
+	for _, t := range data.InvTypes {
+		if t.Published && slices.Contains(typeCategories, categoryOf[t.GroupID]) {
+			types = append(types, Named{t.TypeID, t.TypeName})
+		}
+	}
+
+	var regions []Named
+	if data.Universe != nil {
+		for _, r := range data.Universe.Regions {
+			regions = append(regions, Named{r.RegionID, r.RegionName})
+		}
+	}
+
+	var classes []Named
+	seen := make(map[int64]bool)
+	for _, wc := range data.WormholeClasses {
+		if seen[wc.WormholeClassID] {
+			continue
+		}
+		seen[wc.WormholeClassID] = true
+		name, ok := wormholeClassNames[wc.WormholeClassID]
+		if !ok {
+			name = strconv.FormatInt(wc.WormholeClassID, 10)
+		}
+		classes = append(classes, Named{wc.WormholeClassID, name})
+	}
+
+	return []Block{
+		{Type: "CategoryID", Doc: "identifies an item category.", Kind: "category", Constants: Constants("Category", categories)},
+		{Type: "GroupID", Doc: "identifies an item group.", Kind: "group", Constants: Constants("Group", groups)},
+		{Type: "TypeID", Doc: "identifies an item type.", Kind: "type", Constants: Constants("Type", types)},
+		{Type: "RegionID", Doc: "identifies a region of the map.", Kind: "region", Constants: Constants("Region", regions)},
+		{Type: "WormholeClassID", Doc: "identifies the wormhole class of a location.", Kind: "wormhole class", Constants: Constants("WormholeClass", classes)},
+	}
+}
+
+// Constants names the entries after their English names with the given
+// prefix, ordered by ID. When several entries share a name, the lowest ID
+// keeps it and the others get their ID appended, e.g. TypeCapsule_33328.
+func Constants(prefix string, entries []Named) []Constant {
+	sorted := slices.SortedFunc(slices.Values(entries), func(a, b Named) int { return cmp.Compare(a.ID, b.ID) })
+
+	constants := make([]Constant, 0, len(sorted))
+	used := make(map[string]bool, len(sorted))
+	for _, e := range sorted {
+		name := prefix + Identifier(e.Name)
+		if name == prefix {
+			name += strconv.FormatInt(e.ID, 10)
+		}
+		if used[name] {
+			name += "_" + strconv.FormatInt(e.ID, 10)
+		}
+		used[name] = true
+		label := strings.Join(strings.Fields(e.Name), " ")
+		if label == "" {
+			label = strconv.FormatInt(e.ID, 10)
+		}
+		constants = append(constants, Constant{Name: name, ID: e.ID, Label: label})
+	}
+	return constants
+}
+
+// Identifier turns an English name into an exported-style Go identifier
+// fragment: words of ASCII letters and digits are joined with their first
+// letter capitalised, and everything else is dropped, so "'Augmented'
+// Hammerhead II" becomes AugmentedHammerheadII.
+func Identifier(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,160 @@
+package codegen
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+func TestIdentifier(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Rifter", "Rifter"},
+		{"Hobgoblin I", "HobgoblinI"},
+		{"'Augmented' Hammerhead II", "AugmentedHammerheadII"},
+		{"J-Space Region", "JSpaceRegion"},
+		{"capital industrial ship", "CapitalIndustrialShip"},
+		{"Mjölnir Rage", "MjLnirRage"},
+		{"  ", ""},
+	}
+	for _, tt := range tests {
+		if got := Identifier(tt.name); got != tt.want {
+			t.Errorf("Identifier(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConstants(t *testing.T) {
+	constants := Constants("Type", []Named{
+		{ID: 33328, Name: "Capsule"},
+		{ID: 670, Name: "Capsule"},
+		{ID: 999, Name: "???"},
+		{ID: 587, Name: "Rifter  "},
+	})
+
+	want := []Constant{
+		{Name: "TypeRifter", ID: 587, Label: "Rifter"},
+		{Name: "TypeCapsule", ID: 670, Label: "Capsule"},
+		{Name: "Type999", ID: 999, Label: "???"},
+		{Name: "TypeCapsule_33328", ID: 33328, Label: "Capsule"},
+	}
+	if len(constants) != len(want) {
+		t.Fatalf("expected %d constants, got %+v", len(want), constants)
+	}
+	for i := range want {
+		if constants[i] != want[i] {
+			t.Errorf("constant %d = %+v, want %+v", i, constants[i], want[i])
+		}
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	data := &models.ConvertedData{
+		Universe: &models.UniverseData{
+			Regions: []models.Region{{RegionID: 10000002, RegionName: "The Forge"}},
+		},
+		InvGroups: []models.InvGroup{
+			{GroupID: 25, CategoryID: 6, GroupName: "Frigate"},
+			{GroupID: 100, CategoryID: 18, GroupName: "Combat Drone"},
+		},
+		InvTypes: []models.InvType{
+			{TypeID: 587, GroupID: 25, TypeName: "Rifter", Published: true},
+			{TypeID: 596, GroupID: 25, TypeName: "Impairor", Published: false},
+			{TypeID: 2456, GroupID: 100, TypeName: "Hobgoblin I", Published: true},
+		},
+		WormholeClasses: []models.WormholeClassLocation{
+			{LocationID: 10000002, WormholeClassID: 7},
+			{LocationID: 11000001, WormholeClassID: 3},
+			{LocationID: 11000002, WormholeClassID: 3},
+			{LocationID: 11000003, WormholeClassID: 99},
+		},
+	}
+	categories := []Named{{ID: 6, Name: "Ship"}, {ID: 18, Name: "Drone"}}
+
+	blocks := Blocks(data, categories, []int64{6})
+	byType := make(map[string][]Constant)
+	for _, b := range blocks {
+		byType[b.Type] = b.Constants
+	}
+
+	if got := byType["CategoryID"]; len(got) != 2 || got[0].Name != "CategoryShip" {
+		t.Errorf("unexpected categories: %+v", got)
+	}
+	if got := byType["GroupID"]; len(got) != 2 || got[1].Name != "GroupCombatDrone" {
+		t.Errorf("unexpected groups: %+v", got)
+	}
+	// Only published types of the selected categories
+	if got := byType["TypeID"]; len(got) != 1 || got[0].Name != "TypeRifter" {
+		t.Errorf("unexpected types: %+v", got)
+	}
+	if got := byType["RegionID"]; len(got) != 1 || got[0].Name != "RegionTheForge" {
+		t.Errorf("unexpected regions: %+v", got)
+	}
+	want := []string{"WormholeClassC3", "WormholeClassHighSec", "WormholeClass99"}
+	got := byType["WormholeClassID"]
+	if len(got) != len(want) {
+		t.Fatalf("unexpected wormhole classes: %+v", got)
+	}
+	for i, name := range want {
+		if got[i].Name != name {
+			t.Errorf("wormhole class %d = %q, want %q", i, got[i].Name, name)
+		}
+	}
+}
+
+func TestWriteGo(t *testing.T) {
+	blocks := []Block{
+		{Type: "TypeID", Doc: "identifies an item type.", Kind: "type", Constants: []Constant{
+			{Name: "TypeRifter", ID: 587, Label: "Rifter"},
+			{Name: "TypeCapsule_33328", ID: 33328, Label: "Capsule"},
+		}},
+		{Type: "RegionID", Doc: "identifies a region of the map.", Kind: "region"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteGo(&buf, "eveids", "3142455", blocks); err != nil {
+		t.Fatalf("WriteGo failed: %v", err)
+	}
+	source := buf.String()
+
+	file, err := parser.ParseFile(token.NewFileSet(), "eveids.go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, source)
+	}
+	if file.Name.Name != "eveids" {
+		t.Errorf("expected package eveids, got %s", file.Name.Name)
+	}
+
+	for _, want := range []string{
+		"// Code generated by sdeconvert codegen go from SDE build 3142455. DO NOT EDIT.\n",
+		"type TypeID int64\n",
+		"\t// TypeRifter is the type Rifter (SDE build 3142455).\n\tTypeRifter TypeID = 587\n",
+		"TypeCapsule_33328 TypeID = 33328\n",
+		"type RegionID int64\n",
+	} {
+		if !strings.Contains(source, want) {
+			t.Errorf("generated code missing %q:\n%s", want, source)
+		}
+	}
+}
+
+func TestWriteGo_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGo(&buf, "eve-ids", "1", nil); err == nil {
+		t.Error("expected an error for an invalid package name")
+	}
+
+	buf.Reset()
+	if err := WriteGo(&buf, "eveids", "", nil); err != nil {
+		t.Fatalf("WriteGo failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "SDE build unknown.") {
+		t.Errorf("expected an unknown build, got:\n%s", buf.String())
+	}
+}
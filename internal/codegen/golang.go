@@ -0,0 +1,52 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+)
+
+// WriteGo writes a gofmt-formatted Go source file declaring package pkg with
+// one named integer type and const block per block. build is the SDE build
+// number the constants come from, quoted in the file header and in every
+// constant's doc comment.
+func WriteGo(w io.Writer, pkg, build string, blocks []Block) error {
+	if !token.IsIdentifier(pkg) {
+		return fmt.Errorf("invalid Go package name %q", pkg)
+	}
+	if build == "" {
+		build = "unknown"
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by sdeconvert codegen go from SDE build %s. DO NOT EDIT.\n\n", build)
+	fmt.Fprintf(&buf, "// Package %s declares well-known EVE Online IDs from the static data export.\n", pkg)
+	fmt.Fprintf(&buf, "package %s\n", pkg)
+
+	for _, block := range blocks {
+		fmt.Fprintf(&buf, "\n// %s %s\n", block.Type, block.Doc)
+		fmt.Fprintf(&buf, "type %s int64\n", block.Type)
+		if len(block.Constants) == 0 {
+			continue
+		}
+
+		buf.WriteString("\nconst (\n")
+		for i, c := range block.Constants {
+			if i > 0 {
+				buf.WriteByte('\n')
+			}
+			fmt.Fprintf(&buf, "\t// %s is the %s %s (SDE build %s).\n", c.Name, block.Kind, c.Label, build)
+			fmt.Fprintf(&buf, "\t%s %s = %d\n", c.Name, block.Type, c.ID)
+		}
+		buf.WriteString(")\n")
+	}
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated code: %w", err)
+	}
+	_, err = w.Write(source)
+	return err
+}
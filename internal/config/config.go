@@ -1,10 +1,20 @@
 // Package config provides configuration management for the SDE converter.
 package config
 
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
 // SDELatestURL is the download URL for the latest EVE SDE YAML archive.
 // This is a shorthand URL that redirects to the latest build number.
 const SDELatestURL = "https://developers.eveonline.com/static-data/eve-online-static-data-latest-yaml.zip"
 
+// SDEVersionURL is the JSON Lines endpoint that reports the latest SDE build number.
+const SDEVersionURL = "https://developers.eveonline.com/static-data/tranquility/latest.jsonl"
+
 // OutputFormat specifies the output file format.
 type OutputFormat string
 
@@ -13,8 +23,71 @@ const (
 	FormatCSV OutputFormat = "csv"
 	// FormatJSON outputs data in JSON format.
 	FormatJSON OutputFormat = "json"
+	// FormatNDJSON outputs data as newline-delimited JSON, one record per line.
+	FormatNDJSON OutputFormat = "ndjson"
+	// FormatParquet outputs data as Apache Parquet files with typed columns.
+	FormatParquet OutputFormat = "parquet"
+	// FormatETF outputs data in the Erlang External Term Format.
+	FormatETF OutputFormat = "etf"
+)
+
+// ETFKeys specifies how record keys are encoded in ETF output.
+type ETFKeys string
+
+const (
+	// ETFKeysAtom encodes keys as atoms, as in Elixir maps with atom keys.
+	ETFKeysAtom ETFKeys = "atom"
+	// ETFKeysBinary encodes keys as binaries (Elixir strings), which
+	// :erlang.binary_to_term accepts with the :safe option.
+	ETFKeysBinary ETFKeys = "binary"
+)
+
+// JSONLayout specifies how JSON tables are laid out.
+type JSONLayout string
+
+const (
+	// JSONLayoutArray writes each table as an array of records.
+	JSONLayoutArray JSONLayout = "array"
+	// JSONLayoutKeyed writes each table with a unique ID column as an object
+	// mapping the ID to its record, e.g. {"30000142": {...}}.
+	JSONLayoutKeyed JSONLayout = "keyed"
+)
+
+// SplitBy specifies how map tables are additionally split into directories.
+type SplitBy string
+
+const (
+	// SplitByNone writes only the full tables.
+	SplitByNone SplitBy = "none"
+	// SplitByRegion also writes the map tables of each region to
+	// regions/<regionID>/, with an index of the regions.
+	SplitByRegion SplitBy = "region"
+)
+
+// Compression specifies how output files are compressed.
+type Compression string
+
+const (
+	// CompressionNone writes files uncompressed.
+	CompressionNone Compression = "none"
+	// CompressionGzip gzips files and adds a .gz extension.
+	CompressionGzip Compression = "gzip"
+	// CompressionZstd compresses files with Zstandard and adds a .zst extension.
+	CompressionZstd Compression = "zstd"
 )
 
+// Extension returns the file name suffix added by the compression.
+func (c Compression) Extension() string {
+	switch c {
+	case CompressionGzip:
+		return ".gz"
+	case CompressionZstd:
+		return ".zst"
+	default:
+		return ""
+	}
+}
+
 // Config holds all configuration options for the converter.
 type Config struct {
 	// SDEPath is the path to the SDE directory or ZIP file.
@@ -26,12 +99,18 @@ type Config struct {
 	// SDEUrl is the URL to download the SDE from.
 	SDEUrl string
 
+	// VersionURL is the URL used to check the latest SDE build number.
+	VersionURL string
+
 	// DownloadSDE indicates whether to download the SDE.
 	DownloadSDE bool
 
 	// Verbose enables verbose logging.
 	Verbose bool
 
+	// Log receives progress messages. Nil means standard output.
+	Log io.Writer
+
 	// PassthroughDir is the directory containing existing Wanderer JSON files to copy.
 	PassthroughDir string
 
@@ -41,8 +120,170 @@ type Config struct {
 	// Workers is the number of parallel workers for parsing.
 	Workers int
 
-	// OutputFormat specifies the output file format (csv or json).
+	// OutputFormat specifies the output file format (csv, json, ndjson, parquet or etf).
 	OutputFormat OutputFormat
+
+	// JSONLayout specifies whether JSON tables are arrays or objects keyed by ID
+	// (only applies to JSON format).
+	JSONLayout JSONLayout
+
+	// Bundle writes every table, the SDE metadata and the schema version to a
+	// single universe.json instead of one file per table (requires JSON format).
+	Bundle bool
+
+	// SplitBy additionally writes the systems, constellations, jumps and
+	// wormhole classes of each region to their own directory (CSV and JSON formats).
+	SplitBy SplitBy
+
+	// ETFKeys specifies whether ETF records have atom or binary keys (only applies to ETF format).
+	ETFKeys ETFKeys
+
+	// Compression specifies how every output file is compressed, including
+	// passthrough copies and the metadata file. Parquet tables use it as their
+	// internal page codec instead.
+	Compression Compression
+
+	// Tables lists the output tables to generate (--only). Empty means all tables.
+	Tables []string
+
+	// ExcludeTables lists output tables to skip (--exclude).
+	ExcludeTables []string
+
+	// Languages lists the SDE languages to output in addition to English.
+	Languages []string
+
+	// NestedNames writes translated JSON names as {lang: text} objects
+	// instead of separate "<field>_<lang>" keys.
+	NestedNames bool
+
+	// MarketGroupTree additionally writes the market group hierarchy as a nested
+	// JSON tree (invMarketGroupsTree.json), regardless of the output format.
+	MarketGroupTree bool
+
+	// SecurityColumns adds the derived displaySecurity, securityBand and
+	// wormholeSpace columns to the solar systems table.
+	SecurityColumns bool
+
+	// ProjectionColumns adds the projectedX and projectedY map position columns
+	// to the solar systems table.
+	ProjectionColumns bool
+
+	// DogmaTypes limits dgmTypeAttributes and dgmTypeEffects to the types selected
+	// by the named filters (TypeFilterPublished, TypeFilterShips). Empty means all types.
+	DogmaTypes []string
+
+	// Thresholds holds the minimum counts used when validating converted data.
+	// NewConfig sets DefaultThresholds; a zero threshold disables its check.
+	Thresholds ValidationThresholds
+
+	// sources records where each setting was last set from, keyed by setting name.
+	sources map[string]Source
+}
+
+// ValidationThresholds holds the minimum expected row counts for the converted data.
+// Counts below a threshold produce a validation warning.
+type ValidationThresholds struct {
+	MinSolarSystems    int
+	MinRegions         int
+	MinConstellations  int
+	MinTypes           int
+	MinSystemJumps     int
+	MinWormholeClasses int
+}
+
+// DefaultThresholds returns thresholds based on the known EVE universe size.
+func DefaultThresholds() ValidationThresholds {
+	return ValidationThresholds{
+		MinSolarSystems:    8000,
+		MinRegions:         100,
+		MinConstellations:  1000,
+		MinTypes:           30000, // All types, not just ships
+		MinSystemJumps:     13000, // Bidirectional jumps (A→B and B→A), expected ~13,776
+		MinWormholeClasses: 750,   // Regions + constellations + systems, expected ~803
+	}
+}
+
+// SupportedLanguages lists the language codes provided by the SDE.
+var SupportedLanguages = []string{"en", "de", "fr", "ja", "ru", "zh", "ko", "es"}
+
+// Type filters accepted in Config.DogmaTypes.
+const (
+	// TypeFilterPublished keeps published types (models.FilterPublishedTypes).
+	TypeFilterPublished = "published"
+	// TypeFilterShips keeps ship types (models.FilterShipTypes).
+	TypeFilterShips = "ships"
+)
+
+// Table names accepted in Config.Tables and Config.ExcludeTables.
+const (
+	TableSystems         = "systems"
+	TableRegions         = "regions"
+	TableConstellations  = "constellations"
+	TableWormholeClasses = "wormholeClasses"
+	// TableSystemWormholeClasses is the effective wormhole class of each solar system.
+	TableSystemWormholeClasses = "systemWormholeClasses"
+	TableTypes                 = "types"
+	TableGroups                = "groups"
+	TableJumps                 = "jumps"
+	// TableRegionJumps is the mapRegionJumps table of gate connections between regions.
+	TableRegionJumps = "regionJumps"
+	// TableConstellationJumps is the mapConstellationJumps table of gate connections
+	// between constellations.
+	TableConstellationJumps = "constellationJumps"
+	// TableStargates is the mapStargates table with one row per stargate.
+	TableStargates = "stargates"
+	// TableSystemTopology is the mapSystemTopology table of per-system gate network
+	// metrics, written together with its topology report.
+	TableSystemTopology = "systemTopology"
+	// TableStations is the staStations table of NPC stations.
+	TableStations = "stations"
+	// TableStationServices is the staServices table of station service names.
+	TableStationServices = "stationServices"
+	// TableOperationServices is the staOperationServices table linking operations to services.
+	TableOperationServices = "operationServices"
+	// TableFactions is the chrFactions table.
+	TableFactions = "factions"
+	// TableRaces is the chrRaces table.
+	TableRaces = "races"
+	// TableCorporations is the crpNPCCorporations table.
+	TableCorporations = "corporations"
+	// TableMarketGroups is the invMarketGroups table.
+	TableMarketGroups = "marketGroups"
+	// TableDogmaAttributes is the dgmAttributeTypes table of attribute definitions.
+	TableDogmaAttributes = "dogmaAttributes"
+	// TableDogmaEffects is the dgmEffects table of effect definitions.
+	TableDogmaEffects = "dogmaEffects"
+	// TableTypeAttributes is the dgmTypeAttributes table of per-type attribute values.
+	TableTypeAttributes = "typeAttributes"
+	// TableTypeEffects is the dgmTypeEffects table linking types to effects.
+	TableTypeEffects = "typeEffects"
+)
+
+// AllTables lists every output table in output order.
+var AllTables = []string{
+	TableSystems,
+	TableRegions,
+	TableConstellations,
+	TableWormholeClasses,
+	TableSystemWormholeClasses,
+	TableTypes,
+	TableGroups,
+	TableJumps,
+	TableRegionJumps,
+	TableConstellationJumps,
+	TableStargates,
+	TableSystemTopology,
+	TableStations,
+	TableStationServices,
+	TableOperationServices,
+	TableFactions,
+	TableRaces,
+	TableCorporations,
+	TableMarketGroups,
+	TableDogmaAttributes,
+	TableDogmaEffects,
+	TableTypeAttributes,
+	TableTypeEffects,
 }
 
 // NewConfig creates a new Config with default values.
@@ -50,19 +291,178 @@ func NewConfig() *Config {
 	return &Config{
 		OutputDir:    "./output",
 		SDEUrl:       SDELatestURL,
+		VersionURL:   SDEVersionURL,
 		PrettyPrint:  true,
 		Workers:      4,
 		OutputFormat: FormatCSV, // Default to CSV for Fuzzwork compatibility
+		Compression:  CompressionNone,
+		JSONLayout:   JSONLayoutArray,
+		SplitBy:      SplitByNone,
+		ETFKeys:      ETFKeysAtom,
+		Thresholds:   DefaultThresholds(),
+	}
+}
+
+// LogWriter returns Log, or standard output when Log is nil.
+func (c *Config) LogWriter() io.Writer {
+	if c.Log == nil {
+		return os.Stdout
+	}
+	return c.Log
+}
+
+// Logf writes a progress message to LogWriter.
+func (c *Config) Logf(format string, args ...interface{}) {
+	_, _ = fmt.Fprintf(c.LogWriter(), format, args...)
+}
+
+// TableEnabled reports whether the named table should be generated.
+// A table is enabled when it is selected by Tables (or Tables is empty)
+// and it is not listed in ExcludeTables.
+func (c *Config) TableEnabled(name string) bool {
+	if contains(c.ExcludeTables, name) {
+		return false
+	}
+	return len(c.Tables) == 0 || contains(c.Tables, name)
+}
+
+// EnabledTables returns the enabled tables in output order.
+func (c *Config) EnabledTables() []string {
+	var tables []string
+	for _, name := range AllTables {
+		if c.TableEnabled(name) {
+			tables = append(tables, name)
+		}
 	}
+	return tables
 }
 
 // Validate checks that the configuration is valid.
+// Each problem is reported as a *FieldError naming the source of the bad value;
+// multiple problems are joined with errors.Join.
 func (c *Config) Validate() error {
+	var errs []error
+
 	if !c.DownloadSDE && c.SDEPath == "" {
-		return ErrNoSDESource
+		errs = append(errs, c.fieldError(KeySDEPath, ErrNoSDESource))
 	}
 	if c.OutputDir == "" {
-		return ErrNoOutputDir
+		errs = append(errs, c.fieldError(KeyOutput, ErrNoOutputDir))
+	}
+	switch c.OutputFormat {
+	case "", FormatCSV, FormatJSON, FormatNDJSON, FormatParquet, FormatETF:
+	default:
+		errs = append(errs, c.fieldError(KeyFormat, fmt.Errorf("%w: %q", ErrInvalidFormat, c.OutputFormat)))
+	}
+	switch c.Compression {
+	case "", CompressionNone, CompressionGzip, CompressionZstd:
+	default:
+		errs = append(errs, c.fieldError(KeyCompress, fmt.Errorf("%w: %q", ErrInvalidCompression, c.Compression)))
+	}
+	if c.JSONLayout != "" && c.JSONLayout != JSONLayoutArray && c.JSONLayout != JSONLayoutKeyed {
+		errs = append(errs, c.fieldError(KeyJSONLayout, fmt.Errorf("%w: %q", ErrInvalidJSONLayout, c.JSONLayout)))
+	}
+	if c.Bundle && c.OutputFormat != FormatJSON {
+		errs = append(errs, c.fieldError(KeyBundle, fmt.Errorf("%w, not %q", ErrBundleFormat, c.OutputFormat)))
+	}
+	switch c.SplitBy {
+	case "", SplitByNone:
+	case SplitByRegion:
+		if c.OutputFormat != "" && c.OutputFormat != FormatCSV && c.OutputFormat != FormatJSON {
+			errs = append(errs, c.fieldError(KeySplitBy, fmt.Errorf("%w, not %q", ErrSplitFormat, c.OutputFormat)))
+		}
+	default:
+		errs = append(errs, c.fieldError(KeySplitBy, fmt.Errorf("%w: %q", ErrInvalidSplitBy, c.SplitBy)))
+	}
+	if c.ETFKeys != "" && c.ETFKeys != ETFKeysAtom && c.ETFKeys != ETFKeysBinary {
+		errs = append(errs, c.fieldError(KeyETFKeys, fmt.Errorf("%w: %q", ErrInvalidETFKeys, c.ETFKeys)))
+	}
+	if c.Workers < 0 {
+		errs = append(errs, c.fieldError(KeyWorkers, fmt.Errorf("%w: %d", ErrInvalidWorkers, c.Workers)))
+	}
+	errs = append(errs, c.dataErrors()...)
+
+	return joinErrors(errs)
+}
+
+// ValidateData checks only the settings that affect which data is loaded
+// (tables, languages, dogma type filters and thresholds), ignoring the SDE
+// source and output settings. It is used when converting without writing files.
+func (c *Config) ValidateData() error {
+	return joinErrors(c.dataErrors())
+}
+
+// dataErrors returns the errors found by ValidateData.
+func (c *Config) dataErrors() []error {
+	var errs []error
+
+	for _, name := range c.Tables {
+		if !contains(AllTables, name) {
+			errs = append(errs, c.fieldError(KeyOnly, fmt.Errorf("%w: %q", ErrUnknownTable, name)))
+		}
+	}
+	for _, name := range c.ExcludeTables {
+		if !contains(AllTables, name) {
+			errs = append(errs, c.fieldError(KeyExclude, fmt.Errorf("%w: %q", ErrUnknownTable, name)))
+		}
+	}
+	for _, lang := range c.Languages {
+		if !contains(SupportedLanguages, lang) {
+			errs = append(errs, c.fieldError(KeyLanguages, fmt.Errorf("%w: %q", ErrUnknownLanguage, lang)))
+		}
+	}
+	for _, filter := range c.DogmaTypes {
+		if filter != TypeFilterPublished && filter != TypeFilterShips {
+			errs = append(errs, c.fieldError(KeyDogmaTypes, fmt.Errorf("%w: %q", ErrUnknownTypeFilter, filter)))
+		}
+	}
+	if len(c.EnabledTables()) == 0 {
+		errs = append(errs, c.fieldError(KeyExclude, ErrNoTables))
+	}
+	for _, th := range c.thresholdValues() {
+		if th.value < 0 {
+			errs = append(errs, c.fieldError(th.key, fmt.Errorf("%w: %d", ErrNegativeThreshold, th.value)))
+		}
+	}
+	return errs
+}
+
+// joinErrors returns nil, the single error, or all errors joined.
+func joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errors.Join(errs...)
+	}
+}
+
+// thresholdValue pairs a threshold setting name with its value.
+type thresholdValue struct {
+	key   string
+	value int
+}
+
+// thresholdValues returns each threshold with its setting name, in a stable order.
+func (c *Config) thresholdValues() []thresholdValue {
+	return []thresholdValue{
+		{KeyMinSolarSystems, c.Thresholds.MinSolarSystems},
+		{KeyMinRegions, c.Thresholds.MinRegions},
+		{KeyMinConstellations, c.Thresholds.MinConstellations},
+		{KeyMinTypes, c.Thresholds.MinTypes},
+		{KeyMinSystemJumps, c.Thresholds.MinSystemJumps},
+		{KeyMinWormholeClasses, c.Thresholds.MinWormholeClasses},
+	}
+}
+
+// contains reports whether name is in list.
+func contains(list []string, name string) bool {
+	for _, t := range list {
+		if t == name {
+			return true
+		}
 	}
-	return nil
+	return false
 }
@@ -1,6 +1,10 @@
 package config
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -99,6 +103,102 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			expectError: ErrNoSDESource,
 		},
+		{
+			name: "gzipped NDJSON",
+			config: &Config{
+				SDEPath:      "/path/to/sde",
+				OutputDir:    "./output",
+				OutputFormat: FormatNDJSON,
+				Compression:  CompressionGzip,
+			},
+			expectError: nil,
+		},
+		{
+			name: "unknown compression",
+			config: &Config{
+				SDEPath:     "/path/to/sde",
+				OutputDir:   "./output",
+				Compression: "brotli",
+			},
+			expectError: ErrInvalidCompression,
+		},
+		{
+			name: "keyed JSON bundle",
+			config: &Config{
+				SDEPath:      "/path/to/sde",
+				OutputDir:    "./output",
+				OutputFormat: FormatJSON,
+				JSONLayout:   JSONLayoutKeyed,
+				Bundle:       true,
+			},
+			expectError: nil,
+		},
+		{
+			name: "unknown JSON layout",
+			config: &Config{
+				SDEPath:    "/path/to/sde",
+				OutputDir:  "./output",
+				JSONLayout: "nested",
+			},
+			expectError: ErrInvalidJSONLayout,
+		},
+		{
+			name: "bundle requires JSON",
+			config: &Config{
+				SDEPath:      "/path/to/sde",
+				OutputDir:    "./output",
+				OutputFormat: FormatNDJSON,
+				Bundle:       true,
+			},
+			expectError: ErrBundleFormat,
+		},
+		{
+			name: "split CSV by region",
+			config: &Config{
+				SDEPath:   "/path/to/sde",
+				OutputDir: "./output",
+				SplitBy:   SplitByRegion,
+			},
+			expectError: nil,
+		},
+		{
+			name: "unknown split",
+			config: &Config{
+				SDEPath:   "/path/to/sde",
+				OutputDir: "./output",
+				SplitBy:   "constellation",
+			},
+			expectError: ErrInvalidSplitBy,
+		},
+		{
+			name: "split requires CSV or JSON",
+			config: &Config{
+				SDEPath:      "/path/to/sde",
+				OutputDir:    "./output",
+				OutputFormat: FormatParquet,
+				SplitBy:      SplitByRegion,
+			},
+			expectError: ErrSplitFormat,
+		},
+		{
+			name: "ETF with binary keys",
+			config: &Config{
+				SDEPath:      "/path/to/sde",
+				OutputDir:    "./output",
+				OutputFormat: FormatETF,
+				ETFKeys:      ETFKeysBinary,
+			},
+			expectError: nil,
+		},
+		{
+			name: "unknown ETF keys",
+			config: &Config{
+				SDEPath:   "/path/to/sde",
+				OutputDir: "./output",
+				ETFKeys:   "string",
+			},
+			expectError: ErrInvalidETFKeys,
+		},
 	}
 
 	for _, tt := range tests {
@@ -112,7 +212,7 @@ func TestConfig_Validate(t *testing.T) {
 			} else {
 				if err == nil {
 					t.Errorf("Expected error %v, got nil", tt.expectError)
-				} else if err != tt.expectError {
+				} else if !errors.Is(err, tt.expectError) {
 					t.Errorf("Expected error %v, got %v", tt.expectError, err)
 				}
 			}
@@ -137,3 +237,273 @@ func TestErrors(t *testing.T) {
 		t.Error("ErrNoOutputDir has empty message")
 	}
 }
+
+func TestConfig_LoadFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+	}{
+		{
+			name:     "yaml",
+			filename: "sdeconvert.yaml",
+			content: `output: /tmp/out
+format: json
+sde-url: https://example.com/sde.zip
+passthrough: /data
+only: [systems, jumps]
+thresholds:
+  min-solar-systems: 10
+  min-regions: 2
+`,
+		},
+		{
+			name:     "toml",
+			filename: "sdeconvert.toml",
+			content: `output = "/tmp/out"
+format = "json"
+sde-url = "https://example.com/sde.zip"
+passthrough = "/data"
+only = ["systems", "jumps"]
+
+[thresholds]
+min-solar-systems = 10
+min-regions = 2
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.filename)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write config file: %v", err)
+			}
+
+			cfg := NewConfig()
+			if err := cfg.LoadFile(path); err != nil {
+				t.Fatalf("LoadFile failed: %v", err)
+			}
+
+			if cfg.OutputDir != "/tmp/out" {
+				t.Errorf("Expected OutputDir '/tmp/out', got %q", cfg.OutputDir)
+			}
+			if cfg.OutputFormat != FormatJSON {
+				t.Errorf("Expected OutputFormat json, got %q", cfg.OutputFormat)
+			}
+			if cfg.SDEUrl != "https://example.com/sde.zip" {
+				t.Errorf("Unexpected SDEUrl %q", cfg.SDEUrl)
+			}
+			if cfg.PassthroughDir != "/data" {
+				t.Errorf("Expected PassthroughDir '/data', got %q", cfg.PassthroughDir)
+			}
+			if len(cfg.Tables) != 2 || cfg.Tables[0] != TableSystems || cfg.Tables[1] != TableJumps {
+				t.Errorf("Unexpected Tables %v", cfg.Tables)
+			}
+			if cfg.Thresholds.MinSolarSystems != 10 || cfg.Thresholds.MinRegions != 2 {
+				t.Errorf("Unexpected thresholds %+v", cfg.Thresholds)
+			}
+			// Unset thresholds keep their defaults
+			if cfg.Thresholds.MinTypes != DefaultThresholds().MinTypes {
+				t.Errorf("Expected default MinTypes, got %d", cfg.Thresholds.MinTypes)
+			}
+			if src := cfg.SourceOf(KeyOutput); src.Kind != SourceFile || src.Name != path {
+				t.Errorf("Expected output source to be the config file, got %v", src)
+			}
+		})
+	}
+}
+
+func TestConfig_LoadFileErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	unknown := filepath.Join(dir, "unknown.yaml")
+	if err := os.WriteFile(unknown, []byte("colour: blue\n"), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	if err := NewConfig().LoadFile(unknown); !errors.Is(err, ErrUnknownSetting) {
+		t.Errorf("Expected ErrUnknownSetting, got %v", err)
+	}
+
+	badExt := filepath.Join(dir, "config.ini")
+	if err := os.WriteFile(badExt, []byte("output=x\n"), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	if err := NewConfig().LoadFile(badExt); err == nil {
+		t.Error("Expected error for unsupported extension")
+	}
+
+	if err := NewConfig().LoadFile(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestConfig_ApplyEnv(t *testing.T) {
+	env := map[string]string{
+		"SDECONVERT_OUTPUT":                      "/env/out",
+		"SDECONVERT_WORKERS":                     "8",
+		"SDECONVERT_DOWNLOAD":                    "true",
+		"SDECONVERT_ONLY":                        "systems, regions",
+		"SDECONVERT_THRESHOLDS_MIN_SYSTEM_JUMPS": "5",
+	}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+
+	cfg := NewConfig()
+	if err := cfg.ApplyEnv(lookup); err != nil {
+		t.Fatalf("ApplyEnv failed: %v", err)
+	}
+
+	if cfg.OutputDir != "/env/out" {
+		t.Errorf("Expected OutputDir '/env/out', got %q", cfg.OutputDir)
+	}
+	if cfg.Workers != 8 {
+		t.Errorf("Expected Workers 8, got %d", cfg.Workers)
+	}
+	if !cfg.DownloadSDE {
+		t.Error("Expected DownloadSDE to be true")
+	}
+	if len(cfg.Tables) != 2 || cfg.Tables[1] != TableRegions {
+		t.Errorf("Unexpected Tables %v", cfg.Tables)
+	}
+	if cfg.Thresholds.MinSystemJumps != 5 {
+		t.Errorf("Expected MinSystemJumps 5, got %d", cfg.Thresholds.MinSystemJumps)
+	}
+
+	env["SDECONVERT_WORKERS"] = "many"
+	err := NewConfig().ApplyEnv(lookup)
+	if err == nil || !strings.Contains(err.Error(), "SDECONVERT_WORKERS") {
+		t.Errorf("Expected error naming SDECONVERT_WORKERS, got %v", err)
+	}
+}
+
+func TestConfig_Precedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sdeconvert.yaml")
+	if err := os.WriteFile(path, []byte("output: /file/out\nformat: json\nworkers: 2\n"), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg := NewConfig()
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	env := map[string]string{"SDECONVERT_OUTPUT": "/env/out", "SDECONVERT_WORKERS": "6"}
+	if err := cfg.ApplyEnv(func(name string) (string, bool) { v, ok := env[name]; return v, ok }); err != nil {
+		t.Fatalf("ApplyEnv failed: %v", err)
+	}
+	if err := cfg.Set(KeyOutput, "/flag/out", Source{Kind: SourceFlag, Name: "--output"}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	if cfg.OutputDir != "/flag/out" {
+		t.Errorf("Expected flag to win for output, got %q", cfg.OutputDir)
+	}
+	if cfg.Workers != 6 {
+		t.Errorf("Expected env to win for workers, got %d", cfg.Workers)
+	}
+	if cfg.OutputFormat != FormatJSON {
+		t.Errorf("Expected file value for format, got %q", cfg.OutputFormat)
+	}
+	if cfg.PrettyPrint != true {
+		t.Error("Expected default PrettyPrint to be kept")
+	}
+}
+
+func TestConfig_ValidateReportsSource(t *testing.T) {
+	cfg := NewConfig()
+	cfg.SDEPath = "/path/to/sde"
+	env := map[string]string{
+		"SDECONVERT_FORMAT":                 "xml",
+		"SDECONVERT_ONLY":                   "systems,planets",
+		"SDECONVERT_THRESHOLDS_MIN_REGIONS": "-1",
+	}
+	if err := cfg.ApplyEnv(func(name string) (string, bool) { v, ok := env[name]; return v, ok }); err != nil {
+		t.Fatalf("ApplyEnv failed: %v", err)
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+	for _, want := range []error{ErrInvalidFormat, ErrUnknownTable, ErrNegativeThreshold} {
+		if !errors.Is(err, want) {
+			t.Errorf("Expected %v in %v", want, err)
+		}
+	}
+	for _, name := range []string{"SDECONVERT_FORMAT", "SDECONVERT_ONLY", "SDECONVERT_THRESHOLDS_MIN_REGIONS"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Expected error to name %s, got %v", name, err)
+		}
+	}
+}
+
+func TestConfig_TableEnabled(t *testing.T) {
+	cfg := NewConfig()
+	for _, table := range AllTables {
+		if !cfg.TableEnabled(table) {
+			t.Errorf("Expected %s enabled when no tables configured", table)
+		}
+	}
+
+	cfg.Tables = []string{TableSystems, TableJumps}
+	if !cfg.TableEnabled(TableSystems) {
+		t.Error("Expected systems to be enabled")
+	}
+	if cfg.TableEnabled(TableTypes) {
+		t.Error("Expected types to be disabled")
+	}
+
+	cfg.ExcludeTables = []string{TableJumps}
+	if cfg.TableEnabled(TableJumps) {
+		t.Error("Expected excluded jumps to be disabled")
+	}
+	if got := cfg.EnabledTables(); len(got) != 1 || got[0] != TableSystems {
+		t.Errorf("Expected only systems enabled, got %v", got)
+	}
+
+	cfg.SDEPath = "/path/to/sde"
+	cfg.ExcludeTables = []string{TableSystems, TableJumps}
+	if err := cfg.Validate(); !errors.Is(err, ErrNoTables) {
+		t.Errorf("Expected ErrNoTables, got %v", err)
+	}
+}
+
+func TestConfig_Languages(t *testing.T) {
+	cfg := NewConfig()
+	cfg.SDEPath = "/path/to/sde"
+	if err := cfg.Set(KeyLanguages, "EN, de,ru", Source{Kind: SourceFlag, Name: "--languages"}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if len(cfg.Languages) != 3 || cfg.Languages[0] != "en" || cfg.Languages[2] != "ru" {
+		t.Errorf("Unexpected Languages %v", cfg.Languages)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected valid languages, got %v", err)
+	}
+
+	cfg.Languages = []string{"de", "xx"}
+	if err := cfg.Validate(); !errors.Is(err, ErrUnknownLanguage) {
+		t.Errorf("Expected ErrUnknownLanguage, got %v", err)
+	}
+}
+
+func TestConfig_DogmaTypes(t *testing.T) {
+	cfg := NewConfig()
+	cfg.SDEPath = "/path/to/sde"
+	if err := cfg.Set(KeyDogmaTypes, "Published, ships", Source{Kind: SourceEnv, Name: EnvName(KeyDogmaTypes)}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if len(cfg.DogmaTypes) != 2 || cfg.DogmaTypes[0] != TypeFilterPublished || cfg.DogmaTypes[1] != TypeFilterShips {
+		t.Errorf("Unexpected DogmaTypes %v", cfg.DogmaTypes)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected valid dogma filters, got %v", err)
+	}
+
+	cfg.DogmaTypes = []string{"structures"}
+	if err := cfg.Validate(); !errors.Is(err, ErrUnknownTypeFilter) {
+		t.Errorf("Expected ErrUnknownTypeFilter, got %v", err)
+	}
+}
@@ -8,4 +8,46 @@ var (
 
 	// ErrNoOutputDir is returned when no output directory is specified.
 	ErrNoOutputDir = errors.New("output directory must be specified")
+
+	// ErrInvalidFormat is returned when the output format is not recognised.
+	ErrInvalidFormat = errors.New("invalid output format: must be 'csv', 'json', 'ndjson', 'parquet' or 'etf'")
+
+	// ErrInvalidCompression is returned when the compression is not recognised.
+	ErrInvalidCompression = errors.New("invalid compression: must be 'none', 'gzip' or 'zstd'")
+
+	// ErrInvalidJSONLayout is returned when the JSON layout is not recognised.
+	ErrInvalidJSONLayout = errors.New("invalid JSON layout: must be 'array' or 'keyed'")
+
+	// ErrBundleFormat is returned when a bundle is requested for a format other than JSON.
+	ErrBundleFormat = errors.New("--bundle requires --format json")
+
+	// ErrInvalidSplitBy is returned when the output split is not recognised.
+	ErrInvalidSplitBy = errors.New("invalid split: must be 'none' or 'region'")
+
+	// ErrSplitFormat is returned when a split is requested for a format other than CSV or JSON.
+	ErrSplitFormat = errors.New("--split-by requires --format csv or json")
+
+	// ErrInvalidETFKeys is returned when the ETF key encoding is not recognised.
+	ErrInvalidETFKeys = errors.New("invalid ETF keys: must be 'atom' or 'binary'")
+
+	// ErrInvalidWorkers is returned when the worker count is negative.
+	ErrInvalidWorkers = errors.New("worker count must not be negative")
+
+	// ErrUnknownTable is returned when a table name is not recognised.
+	ErrUnknownTable = errors.New("unknown table")
+
+	// ErrNoTables is returned when the table selection leaves nothing to generate.
+	ErrNoTables = errors.New("table selection excludes every table")
+
+	// ErrUnknownLanguage is returned when a language code is not provided by the SDE.
+	ErrUnknownLanguage = errors.New("unsupported language: must be one of en, de, fr, ja, ru, zh, ko, es")
+
+	// ErrUnknownTypeFilter is returned when a dogma type filter is not recognised.
+	ErrUnknownTypeFilter = errors.New("unknown dogma type filter: must be 'published' or 'ships'")
+
+	// ErrNegativeThreshold is returned when a validation threshold is negative.
+	ErrNegativeThreshold = errors.New("validation threshold must not be negative")
+
+	// ErrUnknownSetting is returned when a config file or flag names an unknown setting.
+	ErrUnknownSetting = errors.New("unknown setting")
 )
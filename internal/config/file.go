@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFile reads settings from a YAML (.yaml, .yml) or TOML (.toml) config file.
+// Keys use the same names as the CLI flags; thresholds are nested under a
+// "thresholds" table and table selections may be given as lists:
+//
+//	output: ./output
+//	format: json
+//	only: [systems, jumps, wormholeClasses]
+//	thresholds:
+//	  min-solar-systems: 8000
+func (c *Config) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	raw := make(map[string]interface{})
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return fmt.Errorf("unsupported config file extension %q: must be .yaml, .yml or .toml", ext)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decode config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	if err := flattenSettings("", raw, values); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	// Apply in sorted order so errors are reported deterministically
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	src := Source{Kind: SourceFile, Name: path}
+	for _, key := range keys {
+		if err := c.Set(key, values[key], src); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// flattenSettings converts nested config file values into dotted setting names
+// with string values. Lists are joined with commas.
+func flattenSettings(prefix string, raw map[string]interface{}, out map[string]string) error {
+	for key, value := range raw {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if err := flattenSettings(name, v, out); err != nil {
+				return err
+			}
+		case []interface{}:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			out[name] = strings.Join(items, ",")
+		case nil:
+			return fmt.Errorf("setting %q has no value", name)
+		default:
+			out[name] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix is the prefix for environment variables that override settings.
+const EnvPrefix = "SDECONVERT_"
+
+// EnvConfigFile names the environment variable holding the config file path.
+const EnvConfigFile = EnvPrefix + "CONFIG"
+
+// Setting names shared by config files, environment variables and CLI flags.
+// Nested file keys use a dot separator (e.g. thresholds.min-regions).
+const (
+	KeySDEPath            = "sde-path"
+	KeyOutput             = "output"
+	KeyDownload           = "download"
+	KeyPassthrough        = "passthrough"
+	KeyVerbose            = "verbose"
+	KeyPretty             = "pretty"
+	KeyWorkers            = "workers"
+	KeySDEUrl             = "sde-url"
+	KeyVersionURL         = "version-url"
+	KeyFormat             = "format"
+	KeyCompress           = "compress"
+	KeyJSONLayout         = "json-layout"
+	KeyBundle             = "bundle"
+	KeySplitBy            = "split-by"
+	KeyETFKeys            = "etf-keys"
+	KeyOnly               = "only"
+	KeyExclude            = "exclude"
+	KeyLanguages          = "languages"
+	KeyNestedNames        = "json-nested-names"
+	KeyMarketGroupTree    = "market-group-tree"
+	KeySecurityColumns    = "security-columns"
+	KeyProjectionColumns  = "projection-columns"
+	KeyDogmaTypes         = "dogma-types"
+	KeyMinSolarSystems    = "thresholds.min-solar-systems"
+	KeyMinRegions         = "thresholds.min-regions"
+	KeyMinConstellations  = "thresholds.min-constellations"
+	KeyMinTypes           = "thresholds.min-types"
+	KeyMinSystemJumps     = "thresholds.min-system-jumps"
+	KeyMinWormholeClasses = "thresholds.min-wormhole-classes"
+)
+
+// SourceKind identifies where a setting value came from.
+type SourceKind int
+
+const (
+	// SourceDefault means the built-in default value is in effect.
+	SourceDefault SourceKind = iota
+	// SourceFile means the value was read from a config file.
+	SourceFile
+	// SourceEnv means the value was read from an environment variable.
+	SourceEnv
+	// SourceFlag means the value was given on the command line.
+	SourceFlag
+)
+
+// Source describes where a setting value came from.
+type Source struct {
+	Kind SourceKind
+	// Name is the file path, environment variable or flag that supplied the value.
+	Name string
+}
+
+// String returns a human-readable description of the source.
+func (s Source) String() string {
+	switch s.Kind {
+	case SourceFile:
+		return "config file " + s.Name
+	case SourceEnv:
+		return "environment variable " + s.Name
+	case SourceFlag:
+		return "flag " + s.Name
+	default:
+		return "default"
+	}
+}
+
+// FieldError describes an invalid setting together with the source of its value.
+type FieldError struct {
+	Key    string
+	Source Source
+	Err    error
+}
+
+// Error returns the underlying message, annotated with the source unless it is the default.
+func (e *FieldError) Error() string {
+	if e.Source.Kind == SourceDefault {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v (%s set by %s)", e.Err, e.Key, e.Source)
+}
+
+// Unwrap returns the underlying error so callers can match sentinel errors.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// setter parses a raw string value into the matching Config field.
+type setter func(c *Config, value string) error
+
+// settings maps each setting name to its setter.
+var settings = map[string]setter{
+	KeySDEPath:     func(c *Config, v string) error { c.SDEPath = v; return nil },
+	KeyOutput:      func(c *Config, v string) error { c.OutputDir = v; return nil },
+	KeyDownload:    boolSetter(func(c *Config) *bool { return &c.DownloadSDE }),
+	KeyPassthrough: func(c *Config, v string) error { c.PassthroughDir = v; return nil },
+	KeyVerbose:     boolSetter(func(c *Config) *bool { return &c.Verbose }),
+	KeyPretty:      boolSetter(func(c *Config) *bool { return &c.PrettyPrint }),
+	KeyWorkers:     intSetter(func(c *Config) *int { return &c.Workers }),
+	KeySDEUrl:      func(c *Config, v string) error { c.SDEUrl = v; return nil },
+	KeyVersionURL:  func(c *Config, v string) error { c.VersionURL = v; return nil },
+	KeyFormat: func(c *Config, v string) error {
+		c.OutputFormat = OutputFormat(strings.ToLower(strings.TrimSpace(v)))
+		return nil
+	},
+	KeyCompress: func(c *Config, v string) error {
+		c.Compression = Compression(strings.ToLower(strings.TrimSpace(v)))
+		return nil
+	},
+	KeyJSONLayout: func(c *Config, v string) error {
+		c.JSONLayout = JSONLayout(strings.ToLower(strings.TrimSpace(v)))
+		return nil
+	},
+	KeyBundle: boolSetter(func(c *Config) *bool { return &c.Bundle }),
+	KeySplitBy: func(c *Config, v string) error {
+		c.SplitBy = SplitBy(strings.ToLower(strings.TrimSpace(v)))
+		return nil
+	},
+	KeyETFKeys: func(c *Config, v string) error {
+		c.ETFKeys = ETFKeys(strings.ToLower(strings.TrimSpace(v)))
+		return nil
+	},
+	KeyOnly: func(c *Config, v string) error {
+		c.Tables = SplitList(v)
+		return nil
+	},
+	KeyExclude: func(c *Config, v string) error {
+		c.ExcludeTables = SplitList(v)
+		return nil
+	},
+	KeyLanguages: func(c *Config, v string) error {
+		c.Languages = SplitList(strings.ToLower(v))
+		return nil
+	},
+	KeyNestedNames:       boolSetter(func(c *Config) *bool { return &c.NestedNames }),
+	KeyMarketGroupTree:   boolSetter(func(c *Config) *bool { return &c.MarketGroupTree }),
+	KeySecurityColumns:   boolSetter(func(c *Config) *bool { return &c.SecurityColumns }),
+	KeyProjectionColumns: boolSetter(func(c *Config) *bool { return &c.ProjectionColumns }),
+	KeyDogmaTypes: func(c *Config, v string) error {
+		c.DogmaTypes = SplitList(strings.ToLower(v))
+		return nil
+	},
+	KeyMinSolarSystems:    intSetter(func(c *Config) *int { return &c.Thresholds.MinSolarSystems }),
+	KeyMinRegions:         intSetter(func(c *Config) *int { return &c.Thresholds.MinRegions }),
+	KeyMinConstellations:  intSetter(func(c *Config) *int { return &c.Thresholds.MinConstellations }),
+	KeyMinTypes:           intSetter(func(c *Config) *int { return &c.Thresholds.MinTypes }),
+	KeyMinSystemJumps:     intSetter(func(c *Config) *int { return &c.Thresholds.MinSystemJumps }),
+	KeyMinWormholeClasses: intSetter(func(c *Config) *int { return &c.Thresholds.MinWormholeClasses }),
+}
+
+// boolSetter returns a setter that parses a boolean into the field returned by field.
+func boolSetter(field func(c *Config) *bool) setter {
+	return func(c *Config, v string) error {
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", v)
+		}
+		*field(c) = b
+		return nil
+	}
+}
+
+// intSetter returns a setter that parses an integer into the field returned by field.
+func intSetter(field func(c *Config) *int) setter {
+	return func(c *Config, v string) error {
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", v)
+		}
+		*field(c) = n
+		return nil
+	}
+}
+
+// IsSetting reports whether key names a known setting.
+func IsSetting(key string) bool {
+	_, ok := settings[key]
+	return ok
+}
+
+// Set parses value into the setting named key and records its source.
+func (c *Config) Set(key, value string, src Source) error {
+	set, ok := settings[key]
+	if !ok {
+		return &FieldError{Key: key, Source: src, Err: fmt.Errorf("%w %q", ErrUnknownSetting, key)}
+	}
+	if err := set(c, value); err != nil {
+		return &FieldError{Key: key, Source: src, Err: err}
+	}
+	if c.sources == nil {
+		c.sources = make(map[string]Source)
+	}
+	c.sources[key] = src
+	return nil
+}
+
+// SourceOf returns where the setting named key was last set from.
+func (c *Config) SourceOf(key string) Source {
+	return c.sources[key]
+}
+
+// ApplyEnv applies SDECONVERT_* overrides using lookup (normally os.LookupEnv).
+func (c *Config) ApplyEnv(lookup func(string) (string, bool)) error {
+	for _, key := range sortedKeys() {
+		name := EnvName(key)
+		if value, ok := lookup(name); ok {
+			if err := c.Set(key, value, Source{Kind: SourceEnv, Name: name}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// EnvName returns the environment variable that overrides the setting named key,
+// e.g. thresholds.min-regions becomes SDECONVERT_THRESHOLDS_MIN_REGIONS.
+func EnvName(key string) string {
+	r := strings.NewReplacer(".", "_", "-", "_")
+	return EnvPrefix + strings.ToUpper(r.Replace(key))
+}
+
+// SplitList splits a comma-separated list, trimming spaces and dropping empty items.
+func SplitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// fieldError wraps err with the setting name and the source of its current value.
+func (c *Config) fieldError(key string, err error) error {
+	return &FieldError{Key: key, Source: c.SourceOf(key), Err: err}
+}
+
+// sortedKeys returns all setting names in a stable order.
+func sortedKeys() []string {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
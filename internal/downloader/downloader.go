@@ -55,7 +55,7 @@ type DownloadResult struct {
 // Returns the path to the downloaded ZIP file.
 func (d *Downloader) Download(ctx context.Context) (*DownloadResult, error) {
 	if d.config.Verbose {
-		fmt.Printf("Downloading SDE from: %s\n", d.config.SDEUrl)
+		d.config.Logf("Downloading SDE from: %s\n", d.config.SDEUrl)
 	}
 
 	// Create a temporary directory for the download
@@ -102,6 +102,7 @@ func (d *Downloader) Download(ctx context.Context) (*DownloadResult, error) {
 		writer:        out,
 		total:         contentLength,
 		verbose:       d.config.Verbose,
+		log:           d.config.LogWriter(),
 		lastPrintTime: time.Now(),
 	}
 
@@ -112,7 +113,7 @@ func (d *Downloader) Download(ctx context.Context) (*DownloadResult, error) {
 	}
 
 	if d.config.Verbose {
-		fmt.Printf("\nDownload complete: %d bytes\n", bytesWritten)
+		d.config.Logf("\nDownload complete: %d bytes\n", bytesWritten)
 	}
 
 	return &DownloadResult{
@@ -125,7 +126,7 @@ func (d *Downloader) Download(ctx context.Context) (*DownloadResult, error) {
 // Returns the path to the extracted SDE directory.
 func (d *Downloader) Extract(zipPath, destDir string) (string, error) {
 	if d.config.Verbose {
-		fmt.Printf("Extracting SDE to: %s\n", destDir)
+		d.config.Logf("Extracting SDE to: %s\n", destDir)
 	}
 
 	// Open the ZIP file
@@ -151,12 +152,12 @@ func (d *Downloader) Extract(zipPath, destDir string) (string, error) {
 		extractedCount++
 
 		if d.config.Verbose && extractedCount%1000 == 0 {
-			fmt.Printf("Extracted %d/%d files...\n", extractedCount, totalFiles)
+			d.config.Logf("Extracted %d/%d files...\n", extractedCount, totalFiles)
 		}
 	}
 
 	if d.config.Verbose {
-		fmt.Printf("Extraction complete: %d files\n", extractedCount)
+		d.config.Logf("Extraction complete: %d files\n", extractedCount)
 	}
 
 	// The new SDE format extracts directly to the destination directory
@@ -203,7 +204,7 @@ func (d *Downloader) extractFile(f *zip.File, destDir string) error {
 // Validate checks that the SDE directory has the expected structure.
 func (d *Downloader) Validate(sdePath string) error {
 	if d.config.Verbose {
-		fmt.Printf("Validating SDE structure at: %s\n", sdePath)
+		d.config.Logf("Validating SDE structure at: %s\n", sdePath)
 	}
 
 	// Check for expected files (new flat SDE format)
@@ -215,7 +216,7 @@ func (d *Downloader) Validate(sdePath string) error {
 	}
 
 	if d.config.Verbose {
-		fmt.Println("SDE validation successful")
+		d.config.Logf("SDE validation successful\n")
 	}
 
 	return nil
@@ -244,7 +245,7 @@ func (d *Downloader) DownloadAndExtract(ctx context.Context) (string, error) {
 
 	// Clean up ZIP file
 	if err := os.Remove(result.ZipPath); err != nil && d.config.Verbose {
-		fmt.Printf("Warning: failed to remove ZIP file: %v\n", err)
+		d.config.Logf("Warning: failed to remove ZIP file: %v\n", err)
 	}
 
 	// Validate
@@ -261,6 +262,7 @@ type progressWriter struct {
 	total         int64
 	written       int64
 	verbose       bool
+	log           io.Writer
 	lastPrintTime time.Time
 }
 
@@ -280,12 +282,12 @@ func (pw *progressWriter) Write(p []byte) (int, error) {
 func (pw *progressWriter) printProgress() {
 	if pw.total > 0 {
 		percent := float64(pw.written) / float64(pw.total) * 100
-		fmt.Printf("\rDownloading: %.1f%% (%s / %s)",
+		_, _ = fmt.Fprintf(pw.log, "\rDownloading: %.1f%% (%s / %s)",
 			percent,
 			formatBytes(pw.written),
 			formatBytes(pw.total))
 	} else {
-		fmt.Printf("\rDownloading: %s", formatBytes(pw.written))
+		_, _ = fmt.Fprintf(pw.log, "\rDownloading: %s", formatBytes(pw.written))
 	}
 }
 
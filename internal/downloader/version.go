@@ -15,7 +15,7 @@ import (
 )
 
 // LatestJSONLURL is the URL to check for the latest SDE build number.
-const LatestJSONLURL = "https://developers.eveonline.com/static-data/tranquility/latest.jsonl"
+const LatestJSONLURL = config.SDEVersionURL
 
 // VersionFileName is the name of the file that stores the last processed SDE version.
 const VersionFileName = ".sde-version"
@@ -36,6 +36,14 @@ func NewVersionChecker(cfg *config.Config) *VersionChecker {
 	}
 }
 
+// versionURL returns the configured version check URL, defaulting to LatestJSONLURL.
+func (vc *VersionChecker) versionURL() string {
+	if vc.config.VersionURL != "" {
+		return vc.config.VersionURL
+	}
+	return LatestJSONLURL
+}
+
 // VersionInfo contains information about the SDE version.
 type VersionInfo struct {
 	BuildNumber string `json:"sde"`
@@ -53,10 +61,10 @@ type latestRecord struct {
 // GetLatestVersion fetches the latest SDE version from CCP.
 func (vc *VersionChecker) GetLatestVersion(ctx context.Context) (*VersionInfo, error) {
 	if vc.config.Verbose {
-		fmt.Println("Checking latest SDE version...")
+		vc.config.Logf("Checking latest SDE version...\n")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, LatestJSONLURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, vc.versionURL(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -143,7 +151,7 @@ func (vc *VersionChecker) NeedsUpdate(ctx context.Context, storageDir string) (b
 	}
 
 	if vc.config.Verbose {
-		fmt.Printf("Latest SDE version: %s\n", latest.BuildNumber)
+		vc.config.Logf("Latest SDE version: %s\n", latest.BuildNumber)
 	}
 
 	// Get the stored version
@@ -154,21 +162,21 @@ func (vc *VersionChecker) NeedsUpdate(ctx context.Context, storageDir string) (b
 
 	if stored == "" {
 		if vc.config.Verbose {
-			fmt.Println("No stored version found, update needed")
+			vc.config.Logf("No stored version found, update needed\n")
 		}
 		return true, latest, nil
 	}
 
 	if vc.config.Verbose {
-		fmt.Printf("Stored SDE version: %s\n", stored)
+		vc.config.Logf("Stored SDE version: %s\n", stored)
 	}
 
 	needsUpdate := stored != latest.BuildNumber
 	if vc.config.Verbose {
 		if needsUpdate {
-			fmt.Println("Update available")
+			vc.config.Logf("Update available\n")
 		} else {
-			fmt.Println("SDE is up to date")
+			vc.config.Logf("SDE is up to date\n")
 		}
 	}
 
@@ -178,7 +186,7 @@ func (vc *VersionChecker) NeedsUpdate(ctx context.Context, storageDir string) (b
 // CheckETag performs an HTTP HEAD request to check if the SDE has been updated
 // using ETag headers, which is more efficient than downloading the full version info.
 func (vc *VersionChecker) CheckETag(ctx context.Context, storedETag string) (bool, string, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodHead, LatestJSONLURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, vc.versionURL(), nil)
 	if err != nil {
 		return false, "", fmt.Errorf("failed to create request: %w", err)
 	}
@@ -0,0 +1,307 @@
+// Package etf encodes Go values in the Erlang External Term Format, as read by
+// :erlang.binary_to_term. It covers the subset needed for converted SDE data:
+// integers, floats, booleans, nil, binaries, atoms, lists and maps.
+package etf
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Term tags of the external term format.
+const (
+	tagVersion    = 131
+	tagNewFloat   = 70
+	tagSmallInt   = 97
+	tagInt        = 98
+	tagNil        = 106
+	tagList       = 108
+	tagBinary     = 109
+	tagSmallBig   = 110
+	tagMap        = 116
+	tagAtomUTF8   = 118
+	tagSmallAtom8 = 119
+)
+
+// Atom is a string encoded as an Erlang atom rather than a binary.
+type Atom string
+
+// Atoms for booleans and missing values, as Elixir expects them.
+const (
+	True  Atom = "true"
+	False Atom = "false"
+	Nil   Atom = "nil"
+)
+
+// ErrUnsupported is returned for values that have no term representation here.
+var ErrUnsupported = errors.New("unsupported value")
+
+// Encoder writes terms to a stream. Each top-level term written with Encode
+// or started with List is preceded by the version byte, so a file holding one
+// term can be decoded with :erlang.binary_to_term. Write errors are kept by
+// the buffered writer and returned by Flush.
+type Encoder struct {
+	w *bufio.Writer
+	// open is the length of the list started with List.
+	open int
+
+	// AtomKeys encodes struct field names as atoms instead of binaries.
+	AtomKeys bool
+	// Omit lists JSON field names left out of encoded structs.
+	Omit []string
+
+	fields map[reflect.Type][]field
+}
+
+// field is an encoded struct field.
+type field struct {
+	index int
+	key   string
+}
+
+// NewEncoder returns an encoder writing to w. Flush must be called when done.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w), fields: make(map[reflect.Type][]field)}
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (e *Encoder) Flush() error {
+	return e.w.Flush()
+}
+
+// Encode writes v as a complete term.
+func (e *Encoder) Encode(v any) error {
+	_ = e.w.WriteByte(tagVersion)
+	return e.encode(reflect.ValueOf(v))
+}
+
+// List starts a complete term holding a list of n elements, so large lists
+// can be streamed. The elements follow as n calls to Element, then End.
+func (e *Encoder) List(n int) {
+	_ = e.w.WriteByte(tagVersion)
+	e.listHeader(n)
+	e.open = n
+}
+
+// Element writes one element of a list started with List.
+func (e *Encoder) Element(v any) error {
+	return e.encode(reflect.ValueOf(v))
+}
+
+// End closes a list started with List.
+func (e *Encoder) End() {
+	if e.open > 0 {
+		_ = e.w.WriteByte(tagNil)
+	}
+	e.open = 0
+}
+
+// listHeader writes the head of a proper list, or the empty list when n is 0.
+func (e *Encoder) listHeader(n int) {
+	if n == 0 {
+		_ = e.w.WriteByte(tagNil)
+		return
+	}
+	_ = e.w.WriteByte(tagList)
+	e.uint32(uint32(n))
+}
+
+// encode writes v without the version byte.
+func (e *Encoder) encode(v reflect.Value) error {
+	if !v.IsValid() {
+		return e.atom(Nil)
+	}
+	if v.Type() == reflect.TypeOf(Atom("")) {
+		return e.atom(Atom(v.String()))
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return e.atom(Nil)
+		}
+		return e.encode(v.Elem())
+	case reflect.Bool:
+		if v.Bool() {
+			return e.atom(True)
+		}
+		return e.atom(False)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.int(v.Int())
+		return nil
+	case reflect.Float32, reflect.Float64:
+		return e.float(v.Float())
+	case reflect.String:
+		e.binary(v.String())
+		return nil
+	case reflect.Slice, reflect.Array:
+		e.listHeader(v.Len())
+		for i := range v.Len() {
+			if err := e.encode(v.Index(i)); err != nil {
+				return err
+			}
+		}
+		if v.Len() > 0 {
+			_ = e.w.WriteByte(tagNil)
+		}
+		return nil
+	case reflect.Map:
+		return e.encodeMap(v)
+	case reflect.Struct:
+		return e.encodeStruct(v)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupported, v.Type())
+	}
+}
+
+// encodeMap writes a map with string keys, in key order for stable output.
+func (e *Encoder) encodeMap(v reflect.Value) error {
+	if v.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("%w: map key %s", ErrUnsupported, v.Type().Key())
+	}
+	keys := v.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+
+	_ = e.w.WriteByte(tagMap)
+	e.uint32(uint32(len(keys)))
+	for _, key := range keys {
+		if err := e.key(key.String()); err != nil {
+			return err
+		}
+		if err := e.encode(v.MapIndex(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeStruct writes a struct as a map keyed by its JSON field names.
+func (e *Encoder) encodeStruct(v reflect.Value) error {
+	fields := e.structFields(v.Type())
+	_ = e.w.WriteByte(tagMap)
+	e.uint32(uint32(len(fields)))
+	for _, f := range fields {
+		if err := e.key(f.key); err != nil {
+			return err
+		}
+		if err := e.encode(v.Field(f.index)); err != nil {
+			return fmt.Errorf("field %s: %w", f.key, err)
+		}
+	}
+	return nil
+}
+
+// structFields returns the encoded fields of a struct type: exported fields
+// not tagged json:"-" or omitted, keyed by their JSON name.
+func (e *Encoder) structFields(t reflect.Type) []field {
+	if fields, ok := e.fields[t]; ok {
+		return fields
+	}
+	var fields []field
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if !sf.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if slices.Contains(e.Omit, name) {
+			continue
+		}
+		fields = append(fields, field{index: i, key: name})
+	}
+	e.fields[t] = fields
+	return fields
+}
+
+// key writes a map key as an atom or a binary.
+func (e *Encoder) key(name string) error {
+	if e.AtomKeys {
+		return e.atom(Atom(name))
+	}
+	e.binary(name)
+	return nil
+}
+
+// int writes the smallest integer term holding n.
+func (e *Encoder) int(n int64) {
+	switch {
+	case n >= 0 && n <= math.MaxUint8:
+		_ = e.w.WriteByte(tagSmallInt)
+		_ = e.w.WriteByte(byte(n))
+	case n >= math.MinInt32 && n <= math.MaxInt32:
+		_ = e.w.WriteByte(tagInt)
+		e.uint32(uint32(int32(n)))
+	default:
+		sign := byte(0)
+		magnitude := uint64(n)
+		if n < 0 {
+			sign = 1
+			magnitude = uint64(-n) // -MinInt64 wraps to its own magnitude as uint64
+		}
+		var digits [8]byte
+		binary.LittleEndian.PutUint64(digits[:], magnitude)
+		size := 8
+		for size > 1 && digits[size-1] == 0 {
+			size--
+		}
+		_ = e.w.WriteByte(tagSmallBig)
+		_ = e.w.WriteByte(byte(size))
+		_ = e.w.WriteByte(sign)
+		_, _ = e.w.Write(digits[:size])
+	}
+}
+
+// float writes a 64-bit float. Erlang has no NaN or infinity.
+func (e *Encoder) float(f float64) error {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("%w: float %v", ErrUnsupported, f)
+	}
+	_ = e.w.WriteByte(tagNewFloat)
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], math.Float64bits(f))
+	_, _ = e.w.Write(buf[:])
+	return nil
+}
+
+// atom writes a UTF-8 atom. Atoms hold at most 255 characters.
+func (e *Encoder) atom(a Atom) error {
+	if utf8.RuneCountInString(string(a)) > 255 {
+		return fmt.Errorf("%w: atom longer than 255 characters", ErrUnsupported)
+	}
+	if len(a) <= math.MaxUint8 {
+		_ = e.w.WriteByte(tagSmallAtom8)
+		_ = e.w.WriteByte(byte(len(a)))
+	} else {
+		_ = e.w.WriteByte(tagAtomUTF8)
+		var buf [2]byte
+		binary.BigEndian.PutUint16(buf[:], uint16(len(a)))
+		_, _ = e.w.Write(buf[:])
+	}
+	_, _ = e.w.WriteString(string(a))
+	return nil
+}
+
+// binary writes a string as a binary, the representation of Elixir strings.
+func (e *Encoder) binary(s string) {
+	_ = e.w.WriteByte(tagBinary)
+	e.uint32(uint32(len(s)))
+	_, _ = e.w.WriteString(s)
+}
+
+// uint32 writes a big-endian 32-bit length or value.
+func (e *Encoder) uint32(n uint32) {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], n)
+	_, _ = e.w.Write(buf[:])
+}
@@ -0,0 +1,124 @@
+package etf
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+)
+
+// encode returns the bytes of v encoded as a complete term.
+func encode(t *testing.T, e func(*Encoder), v any) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	if e != nil {
+		e(enc)
+	}
+	if err := enc.Encode(v); err != nil {
+		t.Fatalf("Encode(%v) failed: %v", v, err)
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func atomKeys(e *Encoder) { e.AtomKeys = true }
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		opts func(*Encoder)
+		v    any
+		want []byte
+	}{
+		{"small integer", nil, 1, []byte{131, 97, 1}},
+		{"integer", nil, int64(300), []byte{131, 98, 0, 0, 1, 44}},
+		{"negative integer", nil, -1, []byte{131, 98, 255, 255, 255, 255}},
+		{"big integer", nil, int64(1) << 32, []byte{131, 110, 5, 0, 0, 0, 0, 0, 1}},
+		{"negative big integer", nil, -(int64(1) << 32), []byte{131, 110, 5, 1, 0, 0, 0, 0, 1}},
+		{"min int64", nil, int64(math.MinInt64), []byte{131, 110, 8, 1, 0, 0, 0, 0, 0, 0, 0, 128}},
+		{"float", nil, 1.5, []byte{131, 70, 63, 248, 0, 0, 0, 0, 0, 0}},
+		{"binary", nil, "ab", []byte{131, 109, 0, 0, 0, 2, 97, 98}},
+		{"atom", nil, Atom("ok"), []byte{131, 119, 2, 111, 107}},
+		{"true", nil, true, []byte{131, 119, 4, 116, 114, 117, 101}},
+		{"nil", nil, nil, []byte{131, 119, 3, 110, 105, 108}},
+		{"nil pointer", nil, (*int64)(nil), []byte{131, 119, 3, 110, 105, 108}},
+		{"empty list", nil, []int64{}, []byte{131, 106}},
+		{"list", nil, []int64{1, 2}, []byte{131, 108, 0, 0, 0, 2, 97, 1, 97, 2, 106}},
+		{"map with atom keys", atomKeys, map[string]int{"a": 1}, []byte{131, 116, 0, 0, 0, 1, 119, 1, 97, 97, 1}},
+		{"map with binary keys", nil, map[string]int{"a": 1}, []byte{131, 116, 0, 0, 0, 1, 109, 0, 0, 0, 1, 97, 97, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encode(t, tt.opts, tt.v); !bytes.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncode_Struct(t *testing.T) {
+	type record struct {
+		ID      int64             `json:"id"`
+		Name    *string           `json:"name"`
+		Names   map[string]string `json:"-"`
+		Hidden  int64             `json:"hidden"`
+		private int64
+	}
+
+	got := encode(t, func(e *Encoder) {
+		e.AtomKeys = true
+		e.Omit = []string{"hidden"}
+	}, record{ID: 7, Hidden: 1, private: 2})
+	want := []byte{
+		131, 116, 0, 0, 0, 2,
+		119, 2, 'i', 'd', 97, 7,
+		119, 4, 'n', 'a', 'm', 'e', 119, 3, 'n', 'i', 'l',
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestEncode_Unsupported(t *testing.T) {
+	for _, v := range []any{math.NaN(), math.Inf(1), map[int]int{1: 1}, make(chan int)} {
+		err := NewEncoder(&bytes.Buffer{}).Encode(v)
+		if !errors.Is(err, ErrUnsupported) {
+			t.Errorf("Encode(%T) = %v, want ErrUnsupported", v, err)
+		}
+	}
+}
+
+func TestEncoder_List(t *testing.T) {
+	tests := []struct {
+		name     string
+		elements []any
+		want     []byte
+	}{
+		{"empty", nil, []byte{131, 106}},
+		{"elements", []any{"a", 2}, []byte{131, 108, 0, 0, 0, 2, 109, 0, 0, 0, 1, 97, 97, 2, 106}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := NewEncoder(&buf)
+			enc.List(len(tt.elements))
+			for _, v := range tt.elements {
+				if err := enc.Element(v); err != nil {
+					t.Fatalf("Element(%v) failed: %v", v, err)
+				}
+			}
+			enc.End()
+			if err := enc.Flush(); err != nil {
+				t.Fatalf("Flush failed: %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), tt.want) {
+				t.Errorf("got %v, want %v", buf.Bytes(), tt.want)
+			}
+		})
+	}
+}
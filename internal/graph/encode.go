@@ -0,0 +1,262 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format is a graph file format.
+type Format string
+
+const (
+	// FormatGraphML is GraphML, read by yEd, Gephi, NetworkX and igraph.
+	FormatGraphML Format = "graphml"
+	// FormatDOT is Graphviz DOT.
+	FormatDOT Format = "dot"
+	// FormatGEXF is GEXF 1.3, Gephi's native format.
+	FormatGEXF Format = "gexf"
+)
+
+// Formats lists the supported formats.
+var Formats = []Format{FormatGraphML, FormatDOT, FormatGEXF}
+
+// ParseFormat returns the format with the given name.
+func ParseFormat(name string) (Format, error) {
+	for _, format := range Formats {
+		if string(format) == name {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown graph format %q (valid: graphml, dot, gexf)", name)
+}
+
+// Encode writes g to w in the given format.
+func Encode(w io.Writer, g *Graph, format Format) error {
+	switch format {
+	case FormatGraphML:
+		return EncodeGraphML(w, g)
+	case FormatDOT:
+		return EncodeDOT(w, g)
+	case FormatGEXF:
+		return EncodeGEXF(w, g)
+	default:
+		return fmt.Errorf("unknown graph format %q", format)
+	}
+}
+
+// graphID names the graph in formats that want one.
+func graphID(g *Graph) string {
+	if g.Level == LevelSystem {
+		return "gates"
+	}
+	return "gates_" + string(g.Level)
+}
+
+// nodeID formats a node or edge endpoint ID.
+func nodeID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// graphMLDoc is the GraphML document layout.
+type graphMLDoc struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// EncodeGraphML writes g as GraphML. Node attributes become node keys named
+// after the attribute, and the edge weight becomes the "weight" edge key.
+func EncodeGraphML(w io.Writer, g *Graph) error {
+	doc := graphMLDoc{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Graph: graphMLGraph{ID: graphID(g), EdgeDefault: "undirected"},
+	}
+	for _, key := range g.NodeKeys {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: key.Name, For: "node", Name: key.Name, Type: string(key.Type)})
+	}
+	doc.Keys = append(doc.Keys, graphMLKey{ID: "weight", For: "edge", Name: "weight", Type: string(AttrLong)})
+
+	for _, n := range g.Nodes {
+		node := graphMLNode{ID: nodeID(n.ID)}
+		for i, key := range g.NodeKeys {
+			node.Data = append(node.Data, graphMLData{Key: key.Name, Value: n.Values[i]})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node)
+	}
+	for _, e := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Source: nodeID(e.Source),
+			Target: nodeID(e.Target),
+			Data:   []graphMLData{{Key: "weight", Value: strconv.Itoa(e.Weight)}},
+		})
+	}
+	return encodeXML(w, doc)
+}
+
+// gexfDoc is the GEXF 1.3 document layout.
+type gexfDoc struct {
+	XMLName xml.Name  `xml:"gexf"`
+	Xmlns   string    `xml:"xmlns,attr"`
+	Version string    `xml:"version,attr"`
+	Graph   gexfGraph `xml:"graph"`
+}
+
+type gexfGraph struct {
+	DefaultEdgeType string         `xml:"defaultedgetype,attr"`
+	Attributes      gexfAttributes `xml:"attributes"`
+	Nodes           []gexfNode     `xml:"nodes>node"`
+	Edges           []gexfEdge     `xml:"edges>edge"`
+}
+
+type gexfAttributes struct {
+	Class      string          `xml:"class,attr"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfAttribute struct {
+	ID    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"`
+}
+
+type gexfNode struct {
+	ID        string         `xml:"id,attr"`
+	Label     string         `xml:"label,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfAttValue struct {
+	For   string `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type gexfEdge struct {
+	ID     string `xml:"id,attr"`
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+	Weight int    `xml:"weight,attr"`
+}
+
+// EncodeGEXF writes g as GEXF 1.3 with node attributes declared in the node
+// attribute class and edge weights as the native weight attribute.
+func EncodeGEXF(w io.Writer, g *Graph) error {
+	doc := gexfDoc{
+		Xmlns:   "http://gexf.net/1.3",
+		Version: "1.3",
+		Graph: gexfGraph{
+			DefaultEdgeType: "undirected",
+			Attributes:      gexfAttributes{Class: "node"},
+		},
+	}
+	for _, key := range g.NodeKeys {
+		doc.Graph.Attributes.Attributes = append(doc.Graph.Attributes.Attributes,
+			gexfAttribute{ID: key.Name, Title: key.Name, Type: string(key.Type)})
+	}
+
+	for _, n := range g.Nodes {
+		node := gexfNode{ID: nodeID(n.ID), Label: n.Label}
+		for i, key := range g.NodeKeys {
+			node.AttValues = append(node.AttValues, gexfAttValue{For: key.Name, Value: n.Values[i]})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node)
+	}
+	for i, e := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, gexfEdge{
+			ID:     strconv.Itoa(i),
+			Source: nodeID(e.Source),
+			Target: nodeID(e.Target),
+			Weight: e.Weight,
+		})
+	}
+	return encodeXML(w, doc)
+}
+
+// encodeXML writes doc as an indented XML document with a declaration.
+func encodeXML(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("failed to write XML header: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode XML: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("failed to write XML: %w", err)
+	}
+	return nil
+}
+
+// EncodeDOT writes g as an undirected Graphviz graph. Node attributes are
+// written as quoted DOT attributes, with the name as the label.
+func EncodeDOT(w io.Writer, g *Graph) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "graph %s {\n", graphID(g))
+	for _, n := range g.Nodes {
+		attrs := []string{"label=" + dotQuote(n.Label)}
+		for i, key := range g.NodeKeys {
+			if key.Name == "name" {
+				continue
+			}
+			attrs = append(attrs, key.Name+"="+dotQuote(n.Values[i]))
+		}
+		fmt.Fprintf(bw, "  %d [%s];\n", n.ID, strings.Join(attrs, ", "))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(bw, "  %d -- %d [weight=%d];\n", e.Source, e.Target, e.Weight)
+	}
+	fmt.Fprintln(bw, "}")
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write DOT: %w", err)
+	}
+	return nil
+}
+
+// dotQuote quotes s as a DOT string.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// FileName returns the output file name for a graph at level in format, such
+// as gateNetwork.graphml or gateNetwork-region.dot.
+func FileName(level Level, format Format) string {
+	if level == LevelSystem {
+		return "gateNetwork." + string(format)
+	}
+	return "gateNetwork-" + string(level) + "." + string(format)
+}
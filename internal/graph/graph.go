@@ -0,0 +1,212 @@
+// Package graph builds the stargate network as a graph and encodes it for
+// graph tools (GraphML, DOT and GEXF).
+package graph
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// Level selects what a graph node stands for.
+type Level string
+
+const (
+	// LevelSystem makes each solar system a node.
+	LevelSystem Level = "system"
+	// LevelConstellation collapses the systems of each constellation into one node.
+	LevelConstellation Level = "constellation"
+	// LevelRegion collapses the systems of each region into one node.
+	LevelRegion Level = "region"
+)
+
+// Levels lists the supported levels.
+var Levels = []Level{LevelSystem, LevelConstellation, LevelRegion}
+
+// ParseLevel returns the level with the given name.
+func ParseLevel(name string) (Level, error) {
+	for _, level := range Levels {
+		if string(level) == name {
+			return level, nil
+		}
+	}
+	return "", fmt.Errorf("unknown graph level %q (valid: system, constellation, region)", name)
+}
+
+// AttrType is the value type of a node attribute.
+type AttrType string
+
+const (
+	AttrString AttrType = "string"
+	AttrDouble AttrType = "double"
+	AttrLong   AttrType = "long"
+)
+
+// AttrKey declares a node attribute.
+type AttrKey struct {
+	Name string
+	Type AttrType
+}
+
+// Node is a solar system, constellation or region. Values holds one formatted
+// value per key of the graph's NodeKeys.
+type Node struct {
+	ID     int64
+	Label  string
+	Values []string
+}
+
+// Edge is an undirected connection. Weight is the number of gates it stands
+// for, which is 1 between systems.
+type Edge struct {
+	Source int64
+	Target int64
+	Weight int
+}
+
+// Graph is an undirected stargate network.
+type Graph struct {
+	Level    Level
+	NodeKeys []AttrKey
+	Nodes    []Node
+	Edges    []Edge
+}
+
+// systemKeys are the node attributes at LevelSystem.
+var systemKeys = []AttrKey{
+	{"name", AttrString},
+	{"security", AttrDouble},
+	{"regionID", AttrLong},
+	{"constellationID", AttrLong},
+	{"x", AttrDouble},
+	{"y", AttrDouble},
+	{"z", AttrDouble},
+}
+
+// constellationKeys are the node attributes at LevelConstellation.
+var constellationKeys = []AttrKey{
+	{"name", AttrString},
+	{"regionID", AttrLong},
+	{"systemCount", AttrLong},
+	{"x", AttrDouble},
+	{"y", AttrDouble},
+	{"z", AttrDouble},
+}
+
+// regionKeys are the node attributes at LevelRegion.
+var regionKeys = []AttrKey{
+	{"name", AttrString},
+	{"systemCount", AttrLong},
+	{"x", AttrDouble},
+	{"y", AttrDouble},
+	{"z", AttrDouble},
+}
+
+// Build turns the solar systems and jumps of data into a graph at the given
+// level. Collapsed nodes are placed at the centroid of their systems; gates
+// inside one node are dropped and gates between two nodes are merged into one
+// weighted edge. Gates to unknown systems are ignored.
+func Build(data *models.ConvertedData, level Level) (*Graph, error) {
+	if data.Universe == nil {
+		return nil, fmt.Errorf("graph needs solar systems")
+	}
+	index := models.NewDataset(data)
+
+	var node func(models.SolarSystem) int64
+	g := &Graph{Level: level}
+	switch level {
+	case LevelSystem:
+		g.NodeKeys = systemKeys
+		node = func(s models.SolarSystem) int64 { return s.SolarSystemID }
+		for _, s := range data.Universe.SolarSystems {
+			g.Nodes = append(g.Nodes, Node{
+				ID:    s.SolarSystemID,
+				Label: s.SolarSystemName,
+				Values: []string{
+					s.SolarSystemName, models.FormatSecurity(s.Security),
+					formatID(s.RegionID), formatID(s.ConstellationID),
+					models.FormatFloat(s.X), models.FormatFloat(s.Y), models.FormatFloat(s.Z),
+				},
+			})
+		}
+	case LevelConstellation:
+		g.NodeKeys = constellationKeys
+		node = func(s models.SolarSystem) int64 { return s.ConstellationID }
+		for _, c := range data.Universe.Constellations {
+			count := len(slices.Collect(index.SystemsInConstellation(c.ConstellationID)))
+			if count == 0 {
+				continue
+			}
+			g.Nodes = append(g.Nodes, Node{
+				ID:    c.ConstellationID,
+				Label: c.ConstellationName,
+				Values: []string{
+					c.ConstellationName, formatID(c.RegionID), strconv.Itoa(count),
+					models.FormatFloat(c.CentroidX), models.FormatFloat(c.CentroidY), models.FormatFloat(c.CentroidZ),
+				},
+			})
+		}
+	case LevelRegion:
+		g.NodeKeys = regionKeys
+		node = func(s models.SolarSystem) int64 { return s.RegionID }
+		for _, r := range data.Universe.Regions {
+			count := len(slices.Collect(index.SystemsInRegion(r.RegionID)))
+			if count == 0 {
+				continue
+			}
+			g.Nodes = append(g.Nodes, Node{
+				ID:    r.RegionID,
+				Label: r.RegionName,
+				Values: []string{
+					r.RegionName, strconv.Itoa(count),
+					models.FormatFloat(r.CentroidX), models.FormatFloat(r.CentroidY), models.FormatFloat(r.CentroidZ),
+				},
+			})
+		}
+	default:
+		return nil, fmt.Errorf("unknown graph level %q", level)
+	}
+
+	g.Edges = edges(index, data.SystemJumps, node)
+	return g, nil
+}
+
+// edges merges jumps into undirected edges between the nodes that node maps
+// their systems to, weighted by the number of distinct gates.
+func edges(index *models.Dataset, jumps []models.SystemJump, node func(models.SolarSystem) int64) []Edge {
+	gates := make(map[[2]int64]bool)
+	for _, j := range jumps {
+		gates[[2]int64{min(j.FromSolarSystemID, j.ToSolarSystemID), max(j.FromSolarSystemID, j.ToSolarSystemID)}] = true
+	}
+
+	weights := make(map[[2]int64]int)
+	for gate := range gates {
+		from, fromOK := index.SolarSystem(gate[0])
+		to, toOK := index.SolarSystem(gate[1])
+		if !fromOK || !toOK {
+			continue
+		}
+		a, b := node(from), node(to)
+		if a == b {
+			continue
+		}
+		weights[[2]int64{min(a, b), max(a, b)}]++
+	}
+
+	result := make([]Edge, 0, len(weights))
+	for pair, weight := range weights {
+		result = append(result, Edge{Source: pair[0], Target: pair[1], Weight: weight})
+	}
+	slices.SortFunc(result, func(a, b Edge) int {
+		return cmp.Or(cmp.Compare(a.Source, b.Source), cmp.Compare(a.Target, b.Target))
+	})
+	return result
+}
+
+// formatID formats an ID attribute value.
+func formatID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,184 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// testData has region 1 with constellations 11 (101, 102) and 12 (103), and
+// region 2 with constellation 21 (201, 202). Gates are 101-102, 102-103,
+// 101-201, 103-201 and 201-202, each listed in both directions, plus a gate
+// from 202 to an unknown system.
+func testData() *models.ConvertedData {
+	var jumps []models.SystemJump
+	for _, gate := range [][2]int64{{101, 102}, {102, 103}, {101, 201}, {103, 201}, {201, 202}} {
+		jumps = append(jumps,
+			models.SystemJump{FromSolarSystemID: gate[0], ToSolarSystemID: gate[1]},
+			models.SystemJump{FromSolarSystemID: gate[1], ToSolarSystemID: gate[0]})
+	}
+	jumps = append(jumps, models.SystemJump{FromSolarSystemID: 202, ToSolarSystemID: 999})
+
+	return &models.ConvertedData{
+		Universe: &models.UniverseData{
+			Regions: []models.Region{
+				{RegionID: 1, RegionName: "Alpha", CentroidX: 1},
+				{RegionID: 2, RegionName: "Beta"},
+				{RegionID: 3, RegionName: "Empty"},
+			},
+			Constellations: []models.Constellation{
+				{RegionID: 1, ConstellationID: 11, ConstellationName: "A-1"},
+				{RegionID: 1, ConstellationID: 12, ConstellationName: "A-2"},
+				{RegionID: 2, ConstellationID: 21, ConstellationName: "B-1"},
+			},
+			SolarSystems: []models.SolarSystem{
+				{RegionID: 1, ConstellationID: 11, SolarSystemID: 101, SolarSystemName: "One", Security: 0.9, X: 1.5},
+				{RegionID: 1, ConstellationID: 11, SolarSystemID: 102, SolarSystemName: "Two", Security: 0.5},
+				{RegionID: 1, ConstellationID: 12, SolarSystemID: 103, SolarSystemName: "Three", Security: 0.1},
+				{RegionID: 2, ConstellationID: 21, SolarSystemID: 201, SolarSystemName: `Four "4"`, Security: -0.5},
+				{RegionID: 2, ConstellationID: 21, SolarSystemID: 202, SolarSystemName: "Five & Six", Security: -1},
+			},
+		},
+		SystemJumps: jumps,
+	}
+}
+
+func TestBuild_SystemLevel(t *testing.T) {
+	g, err := Build(testData(), LevelSystem)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	if len(g.Nodes) != 5 {
+		t.Fatalf("expected 5 nodes, got %d", len(g.Nodes))
+	}
+	want := []string{"One", "0.9", "1", "11", "1.5", "0", "0"}
+	if got := g.Nodes[0].Values; strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("node values = %v, want %v", got, want)
+	}
+
+	wantEdges := []Edge{{101, 102, 1}, {101, 201, 1}, {102, 103, 1}, {103, 201, 1}, {201, 202, 1}}
+	if len(g.Edges) != len(wantEdges) {
+		t.Fatalf("expected %d deduplicated edges, got %v", len(wantEdges), g.Edges)
+	}
+	for i, e := range wantEdges {
+		if g.Edges[i] != e {
+			t.Errorf("edge %d = %+v, want %+v", i, g.Edges[i], e)
+		}
+	}
+}
+
+func TestBuild_Collapsed(t *testing.T) {
+	g, err := Build(testData(), LevelRegion)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if len(g.Nodes) != 2 {
+		t.Fatalf("expected 2 regions with systems, got %+v", g.Nodes)
+	}
+	if got := g.Nodes[0].Values; got[1] != "3" || got[2] != "1" {
+		t.Errorf("region values = %v, want systemCount 3 and centroid x 1", got)
+	}
+	if len(g.Edges) != 1 || g.Edges[0] != (Edge{1, 2, 2}) {
+		t.Errorf("region edges = %+v, want one edge 1-2 of weight 2", g.Edges)
+	}
+
+	g, err = Build(testData(), LevelConstellation)
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	wantEdges := []Edge{{11, 12, 1}, {11, 21, 1}, {12, 21, 1}}
+	if len(g.Edges) != len(wantEdges) {
+		t.Fatalf("constellation edges = %+v, want %+v", g.Edges, wantEdges)
+	}
+	for i, e := range wantEdges {
+		if g.Edges[i] != e {
+			t.Errorf("edge %d = %+v, want %+v", i, g.Edges[i], e)
+		}
+	}
+}
+
+func TestParseLevelAndFormat(t *testing.T) {
+	if _, err := ParseLevel("galaxy"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+	if _, err := ParseFormat("png"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	if got := FileName(LevelRegion, FormatDOT); got != "gateNetwork-region.dot" {
+		t.Errorf("FileName = %q", got)
+	}
+	if got := FileName(LevelSystem, FormatGraphML); got != "gateNetwork.graphml" {
+		t.Errorf("FileName = %q", got)
+	}
+}
+
+func TestEncodeGraphML(t *testing.T) {
+	g, _ := Build(testData(), LevelSystem)
+	var buf bytes.Buffer
+	if err := EncodeGraphML(&buf, g); err != nil {
+		t.Fatalf("EncodeGraphML failed: %v", err)
+	}
+
+	var doc graphMLDoc
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+	if doc.Graph.EdgeDefault != "undirected" {
+		t.Errorf("edgedefault = %q", doc.Graph.EdgeDefault)
+	}
+	if len(doc.Keys) != len(systemKeys)+1 || len(doc.Graph.Nodes) != 5 || len(doc.Graph.Edges) != 5 {
+		t.Errorf("got %d keys, %d nodes, %d edges", len(doc.Keys), len(doc.Graph.Nodes), len(doc.Graph.Edges))
+	}
+	if got := doc.Graph.Nodes[4].Data[0].Value; got != "Five & Six" {
+		t.Errorf("escaped name round-tripped as %q", got)
+	}
+}
+
+func TestEncodeGEXF(t *testing.T) {
+	g, _ := Build(testData(), LevelConstellation)
+	var buf bytes.Buffer
+	if err := EncodeGEXF(&buf, g); err != nil {
+		t.Fatalf("EncodeGEXF failed: %v", err)
+	}
+
+	var doc gexfDoc
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+	if doc.Version != "1.3" || doc.Graph.DefaultEdgeType != "undirected" {
+		t.Errorf("unexpected header: version %q, edge type %q", doc.Version, doc.Graph.DefaultEdgeType)
+	}
+	if len(doc.Graph.Nodes) != 3 || doc.Graph.Nodes[0].Label != "A-1" {
+		t.Errorf("unexpected nodes: %+v", doc.Graph.Nodes)
+	}
+	if len(doc.Graph.Edges) != 3 || doc.Graph.Edges[0].Weight != 1 {
+		t.Errorf("unexpected edges: %+v", doc.Graph.Edges)
+	}
+}
+
+func TestEncodeDOT(t *testing.T) {
+	g, _ := Build(testData(), LevelSystem)
+	var buf bytes.Buffer
+	if err := EncodeDOT(&buf, g); err != nil {
+		t.Fatalf("EncodeDOT failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"graph gates {\n",
+		`  201 [label="Four \"4\"", security="-0.5", regionID="2", constellationID="21"`,
+		"  101 -- 102 [weight=1];\n",
+		"}\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("DOT output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "999") {
+		t.Error("gate to unknown system should be dropped")
+	}
+}
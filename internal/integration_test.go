@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/guarzo/wanderer-sde/internal/config"
@@ -697,7 +698,7 @@ func TestIntegration_CSVOutput(t *testing.T) {
 	}
 
 	// Verify all expected CSV files exist
-	expectedFiles := writer.GetOutputFiles(config.FormatCSV)
+	expectedFiles := writer.GetOutputFiles(config.FormatCSV, config.CompressionNone)
 	for _, filename := range expectedFiles {
 		path := filepath.Join(outputDir, filename)
 		info, err := os.Stat(path)
@@ -1045,3 +1046,77 @@ func validateBooleanColumn(t *testing.T, colName, value string) {
 		t.Errorf("column %s should be '0' or '1', got '%s'", colName, value)
 	}
 }
+
+func TestIntegration_SelectiveTables(t *testing.T) {
+	sdeDir := createTestSDE(t)
+	defer func() { _ = os.RemoveAll(sdeDir) }()
+
+	// Remove types.yaml to prove it is never read when types are excluded
+	if err := os.Remove(filepath.Join(sdeDir, "types.yaml")); err != nil {
+		t.Fatalf("failed to remove types.yaml: %v", err)
+	}
+
+	outputDir := t.TempDir()
+	cfg := &config.Config{
+		SDEPath:       sdeDir,
+		OutputDir:     outputDir,
+		OutputFormat:  config.FormatCSV,
+		Tables:        []string{config.TableSystems, config.TableJumps, config.TableTypes},
+		ExcludeTables: []string{config.TableTypes},
+	}
+
+	p := parser.New(cfg, sdeDir)
+	parseResult, err := p.ParseAll()
+	if err != nil {
+		t.Fatalf("ParseAll failed: %v", err)
+	}
+	if parseResult.Types != nil {
+		t.Error("Expected types not to be parsed")
+	}
+	if len(parseResult.SolarSystems) == 0 || len(parseResult.SystemJumps) == 0 {
+		t.Error("Expected systems and jumps to be parsed")
+	}
+
+	tr := transformer.New(cfg)
+	convertedData, err := tr.Transform(parseResult)
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+
+	// Jumps still get region/constellation IDs from the systems lookup
+	for _, jump := range convertedData.SystemJumps {
+		if jump.FromRegionID == 0 || jump.ToRegionID == 0 {
+			t.Errorf("Jump %d->%d missing region IDs", jump.FromSolarSystemID, jump.ToSolarSystemID)
+		}
+	}
+
+	// Constellations were never parsed, but that must not be a validation error
+	result := tr.Validate(convertedData)
+	if len(result.Errors) > 0 {
+		t.Errorf("Unexpected validation errors: %v", result.Errors)
+	}
+	for _, warning := range result.Warnings {
+		if strings.Contains(warning, "Constellation") || strings.Contains(warning, "Type") {
+			t.Errorf("Unexpected warning for disabled table: %s", warning)
+		}
+	}
+
+	w, err := writer.NewWriter(cfg)
+	if err != nil {
+		t.Fatalf("NewWriter failed: %v", err)
+	}
+	if err := w.WriteAll(convertedData); err != nil {
+		t.Fatalf("WriteAll failed: %v", err)
+	}
+
+	for _, filename := range []string{writer.CSVFileSolarSystems, writer.CSVFileSystemJumps} {
+		if _, err := os.Stat(filepath.Join(outputDir, filename)); err != nil {
+			t.Errorf("Expected %s to be written: %v", filename, err)
+		}
+	}
+	for _, filename := range []string{writer.CSVFileRegions, writer.CSVFileTypes, writer.CSVFileGroups} {
+		if _, err := os.Stat(filepath.Join(outputDir, filename)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s not to be written", filename)
+		}
+	}
+}
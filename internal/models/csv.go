@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 // CSVHeaders defines the exact column headers for each CSV file to match Fuzzwork format.
@@ -12,17 +13,17 @@ var CSVHeaders = map[string][]string{
 		"x", "y", "z", "xMin", "xMax", "yMin", "yMax", "zMin", "zMax",
 		"luminosity", "border", "fringe", "corridor", "hub", "international",
 		"regional", "constellation", "security", "factionID", "radius",
-		"sunTypeID", "securityClass",
+		"sunTypeID", "securityClass", "wormholeClassID",
 	},
 	"mapRegions": {
 		"regionID", "regionName", "x", "y", "z",
 		"xMin", "xMax", "yMin", "yMax", "zMin", "zMax",
-		"factionID", "nebula", "radius",
+		"factionID", "nebula", "radius", "centroidX", "centroidY", "centroidZ",
 	},
 	"mapConstellations": {
 		"regionID", "constellationID", "constellationName",
 		"x", "y", "z", "xMin", "xMax", "yMin", "yMax", "zMin", "zMax",
-		"factionID", "radius",
+		"factionID", "radius", "centroidX", "centroidY", "centroidZ",
 	},
 	"invTypes": {
 		"typeID", "groupID", "typeName", "description",
@@ -36,10 +37,136 @@ var CSVHeaders = map[string][]string{
 	"mapLocationWormholeClasses": {
 		"locationID", "wormholeClassID",
 	},
+	"mapSolarSystemWormholeClasses": {
+		"solarSystemID", "wormholeClassID", "source",
+	},
 	"mapSolarSystemJumps": {
 		"fromRegionID", "fromConstellationID", "fromSolarSystemID",
 		"toSolarSystemID", "toConstellationID", "toRegionID",
 	},
+	"mapRegionJumps": {
+		"fromRegionID", "toRegionID", "gateCount", "fromSolarSystemIDs", "toSolarSystemIDs",
+	},
+	"mapStargates": {
+		"stargateID", "solarSystemID", "destinationStargateID", "destinationSolarSystemID",
+		"typeID", "x", "y", "z",
+	},
+	"mapSystemTopology": {
+		"solarSystemID", "regionID", "gateCount", "articulationPoint", "bridgeCount",
+		"deadEnd", "pipeID", "pipeLength", "betweenness",
+	},
+	"mapConstellationJumps": {
+		"fromRegionID", "fromConstellationID", "toConstellationID", "toRegionID",
+		"gateCount", "fromSolarSystemIDs", "toSolarSystemIDs",
+	},
+	"staStations": {
+		"stationID", "security", "dockingCostPerVolume", "maxShipVolumeDockable",
+		"officeRentalCost", "operationID", "stationTypeID", "corporationID",
+		"solarSystemID", "constellationID", "regionID", "stationName",
+		"x", "y", "z", "reprocessingEfficiency", "reprocessingStationsTake",
+		"reprocessingHangarFlag",
+	},
+	"staServices": {
+		"serviceID", "serviceName", "description",
+	},
+	"staOperationServices": {
+		"operationID", "serviceID",
+	},
+	"chrFactions": {
+		"factionID", "factionName", "description", "raceIDs", "solarSystemID",
+		"corporationID", "sizeFactor", "stationCount", "stationSystemCount",
+		"militiaCorporationID", "iconID",
+	},
+	"chrRaces": {
+		"raceID", "raceName", "description", "iconID", "shortDescription",
+	},
+	"crpNPCCorporations": {
+		"corporationID", "size", "extent", "solarSystemID", "friendID", "enemyID",
+		"publicShares", "initialPrice", "minSecurity", "factionID", "sizeFactor",
+		"stationCount", "stationSystemCount", "description", "iconID",
+		"corporationName", "tickerName",
+	},
+	"invMarketGroups": {
+		"marketGroupID", "parentGroupID", "marketGroupName", "description",
+		"iconID", "hasTypes", "marketGroupPath", "rootMarketGroupID",
+	},
+	"dgmAttributeTypes": {
+		"attributeID", "attributeName", "description", "iconID", "defaultValue",
+		"published", "displayName", "unitID", "stackable", "highIsGood", "categoryID",
+	},
+	"dgmEffects": {
+		"effectID", "effectName", "effectCategory", "description", "guid", "iconID",
+		"isOffensive", "isAssistance", "durationAttributeID", "trackingSpeedAttributeID",
+		"dischargeAttributeID", "rangeAttributeID", "falloffAttributeID",
+		"disallowAutoRepeat", "published", "displayName", "isWarpSafe", "rangeChance",
+		"electronicChance", "propulsionChance", "distribution",
+		"npcUsageChanceAttributeID", "npcActivationChanceAttributeID",
+		"fittingUsageChanceAttributeID",
+	},
+	"dgmTypeAttributes": {
+		"typeID", "attributeID", "valueInt", "valueFloat",
+	},
+	"dgmTypeEffects": {
+		"typeID", "effectID", "isDefault",
+	},
+}
+
+// LocalizedColumns lists the columns of each CSV table that have SDE translations.
+// With extra languages configured, each gets a "<column>_<lang>" column appended.
+var LocalizedColumns = map[string][]string{
+	"mapSolarSystems":    {"solarSystemName"},
+	"mapRegions":         {"regionName"},
+	"mapConstellations":  {"constellationName"},
+	"invTypes":           {"typeName", "description"},
+	"invGroups":          {"groupName"},
+	"chrFactions":        {"factionName"},
+	"chrRaces":           {"raceName"},
+	"crpNPCCorporations": {"corporationName"},
+	"invMarketGroups":    {"marketGroupName", "description"},
+}
+
+// SecurityColumns lists the derived security columns appended to mapSolarSystems
+// when they are enabled, before any translated name columns.
+var SecurityColumns = []string{"displaySecurity", "securityBand", "wormholeSpace"}
+
+// ProjectionColumns lists the projected map position columns appended to
+// mapSolarSystems when they are enabled, after any security columns.
+var ProjectionColumns = []string{"projectedX", "projectedY"}
+
+// ExtraLanguages returns the requested languages other than DefaultLanguage,
+// which is always present in the primary columns.
+func ExtraLanguages(languages []string) []string {
+	var extra []string
+	for _, lang := range languages {
+		if lang != DefaultLanguage {
+			extra = append(extra, lang)
+		}
+	}
+	return extra
+}
+
+// LocalizedHeaders returns the "<column>_<lang>" headers appended to a CSV table
+// for the given languages, grouped by language.
+func LocalizedHeaders(headerKey string, languages []string) []string {
+	var headers []string
+	for _, lang := range ExtraLanguages(languages) {
+		for _, column := range LocalizedColumns[headerKey] {
+			headers = append(headers, column+"_"+lang)
+		}
+	}
+	return headers
+}
+
+// localizedValues returns translated values matching LocalizedHeaders.
+// Each text falls back to its English value when a translation is missing.
+func localizedValues(languages []string, texts []LocalizedText, english []string) []string {
+	var values []string
+	for _, lang := range ExtraLanguages(languages) {
+		for i, text := range texts {
+			values = append(values, text.Get(lang, english[i]))
+		}
+	}
+	return values
 }
 
 // FormatNullableInt64 formats an optional int64 for CSV output.
@@ -51,6 +178,24 @@ func FormatNullableInt64(v *int64) string {
 	return strconv.FormatInt(*v, 10)
 }
 
+// FormatInt64List formats a list of IDs for CSV output, separated by ";".
+func FormatInt64List(ids []int64) string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(values, ";")
+}
+
+// FormatNullableFloat formats an optional float64 for CSV output.
+// Returns "None" if nil, otherwise the value at full precision.
+func FormatNullableFloat(v *float64) string {
+	if v == nil {
+		return "None"
+	}
+	return FormatFloat(*v)
+}
+
 // FormatBool formats a boolean for CSV output.
 // Returns "1" for true, "0" for false (Fuzzwork format).
 func FormatBool(v bool) string {
@@ -81,6 +226,11 @@ func Int64Ptr(v int64) *int64 {
 	return &v
 }
 
+// Float64Ptr returns a pointer to a float64 value, even if 0.
+func Float64Ptr(v float64) *float64 {
+	return &v
+}
+
 // Int64PtrAlways returns a pointer to an int64 value, even if 0.
 func Int64PtrAlways(v int64) *int64 {
 	return &v
@@ -115,9 +265,27 @@ func (s *SolarSystem) ToCSVRow() []string {
 		FormatFloat(s.Radius),
 		FormatNullableInt64(s.SunTypeID),
 		s.SecurityClass,
+		FormatNullableInt64(s.WormholeClassID),
 	}
 }
 
+// SecurityCSVRow returns the values of SecurityColumns.
+func (s *SolarSystem) SecurityCSVRow() []string {
+	display, wormhole := "None", "None"
+	if s.DisplaySecurity != nil {
+		display = FormatFloat(*s.DisplaySecurity)
+	}
+	if s.WormholeSpace != nil {
+		wormhole = FormatBool(*s.WormholeSpace)
+	}
+	return []string{display, s.SecurityBand, wormhole}
+}
+
+// ProjectionCSVRow returns the values of ProjectionColumns.
+func (s *SolarSystem) ProjectionCSVRow() []string {
+	return []string{FormatNullableFloat(s.ProjectedX), FormatNullableFloat(s.ProjectedY)}
+}
+
 // ToCSVRow converts a Region to a CSV row matching Fuzzwork format.
 func (r *Region) ToCSVRow() []string {
 	return []string{
@@ -135,6 +303,9 @@ func (r *Region) ToCSVRow() []string {
 		FormatNullableInt64(r.FactionID),
 		strconv.FormatInt(r.Nebula, 10),
 		FormatFloat(r.Radius),
+		FormatFloat(r.CentroidX),
+		FormatFloat(r.CentroidY),
+		FormatFloat(r.CentroidZ),
 	}
 }
 
@@ -155,6 +326,9 @@ func (c *Constellation) ToCSVRow() []string {
 		FormatFloat(c.ZMax),
 		FormatNullableInt64(c.FactionID),
 		FormatFloat(c.Radius),
+		FormatFloat(c.CentroidX),
+		FormatFloat(c.CentroidY),
+		FormatFloat(c.CentroidZ),
 	}
 }
 
@@ -202,6 +376,15 @@ func (w *WormholeClassLocation) ToCSVRow() []string {
 	}
 }
 
+// ToCSVRow converts a SystemWormholeClass to a CSV row.
+func (w *SystemWormholeClass) ToCSVRow() []string {
+	return []string{
+		strconv.FormatInt(w.SolarSystemID, 10),
+		strconv.FormatInt(w.WormholeClassID, 10),
+		w.Source,
+	}
+}
+
 // ToCSVRow converts a SystemJump to a CSV row matching Fuzzwork format.
 func (j *SystemJump) ToCSVRow() []string {
 	return []string{
@@ -213,3 +396,278 @@ func (j *SystemJump) ToCSVRow() []string {
 		strconv.FormatInt(j.ToRegionID, 10),
 	}
 }
+
+// ToCSVRow converts a RegionJump to a CSV row matching Fuzzwork format.
+func (j *RegionJump) ToCSVRow() []string {
+	return []string{
+		strconv.FormatInt(j.FromRegionID, 10),
+		strconv.FormatInt(j.ToRegionID, 10),
+		strconv.FormatInt(j.GateCount, 10),
+		FormatInt64List(j.FromSolarSystemIDs),
+		FormatInt64List(j.ToSolarSystemIDs),
+	}
+}
+
+// ToCSVRow converts a ConstellationJump to a CSV row matching Fuzzwork format.
+func (j *ConstellationJump) ToCSVRow() []string {
+	return []string{
+		strconv.FormatInt(j.FromRegionID, 10),
+		strconv.FormatInt(j.FromConstellationID, 10),
+		strconv.FormatInt(j.ToConstellationID, 10),
+		strconv.FormatInt(j.ToRegionID, 10),
+		strconv.FormatInt(j.GateCount, 10),
+		FormatInt64List(j.FromSolarSystemIDs),
+		FormatInt64List(j.ToSolarSystemIDs),
+	}
+}
+
+// ToCSVRow converts a Stargate to a mapStargates CSV row.
+func (g *Stargate) ToCSVRow() []string {
+	return []string{
+		strconv.FormatInt(g.StargateID, 10),
+		strconv.FormatInt(g.SolarSystemID, 10),
+		strconv.FormatInt(g.DestinationStargateID, 10),
+		strconv.FormatInt(g.DestinationSolarSystemID, 10),
+		strconv.FormatInt(g.TypeID, 10),
+		FormatNullableFloat(g.X),
+		FormatNullableFloat(g.Y),
+		FormatNullableFloat(g.Z),
+	}
+}
+
+// ToCSVRow converts a SystemTopology to a mapSystemTopology CSV row.
+func (t *SystemTopology) ToCSVRow() []string {
+	return []string{
+		strconv.FormatInt(t.SolarSystemID, 10),
+		strconv.FormatInt(t.RegionID, 10),
+		strconv.FormatInt(t.GateCount, 10),
+		FormatBool(t.ArticulationPoint),
+		strconv.FormatInt(t.BridgeCount, 10),
+		FormatBool(t.DeadEnd),
+		FormatNullableInt64(t.PipeID),
+		strconv.FormatInt(t.PipeLength, 10),
+		FormatFloat(t.Betweenness),
+	}
+}
+
+// ToCSVRow converts a StaStation to a CSV row matching Fuzzwork format.
+func (s *StaStation) ToCSVRow() []string {
+	return []string{
+		strconv.FormatInt(s.StationID, 10),
+		FormatSecurity(s.Security),
+		FormatFloat(s.DockingCostPerVolume),
+		FormatFloat(s.MaxShipVolumeDockable),
+		strconv.FormatInt(s.OfficeRentalCost, 10),
+		strconv.FormatInt(s.OperationID, 10),
+		strconv.FormatInt(s.StationTypeID, 10),
+		strconv.FormatInt(s.CorporationID, 10),
+		strconv.FormatInt(s.SolarSystemID, 10),
+		strconv.FormatInt(s.ConstellationID, 10),
+		strconv.FormatInt(s.RegionID, 10),
+		s.StationName,
+		FormatFloat(s.X),
+		FormatFloat(s.Y),
+		FormatFloat(s.Z),
+		FormatFloat(s.ReprocessingEfficiency),
+		FormatFloat(s.ReprocessingStationsTake),
+		strconv.FormatInt(s.ReprocessingHangarFlag, 10),
+	}
+}
+
+// ToCSVRow converts a StaService to a CSV row matching Fuzzwork format.
+func (s *StaService) ToCSVRow() []string {
+	return []string{
+		strconv.FormatInt(s.ServiceID, 10),
+		s.ServiceName,
+		s.Description,
+	}
+}
+
+// ToCSVRow converts a StaOperationService to a CSV row matching Fuzzwork format.
+func (o *StaOperationService) ToCSVRow() []string {
+	return []string{
+		strconv.FormatInt(o.OperationID, 10),
+		strconv.FormatInt(o.ServiceID, 10),
+	}
+}
+
+// LocalizedCSVRow returns the translated name columns for the given languages.
+func (s *SolarSystem) LocalizedCSVRow(languages []string) []string {
+	return localizedValues(languages, []LocalizedText{s.Names}, []string{s.SolarSystemName})
+}
+
+// LocalizedCSVRow returns the translated name columns for the given languages.
+func (r *Region) LocalizedCSVRow(languages []string) []string {
+	return localizedValues(languages, []LocalizedText{r.Names}, []string{r.RegionName})
+}
+
+// LocalizedCSVRow returns the translated name columns for the given languages.
+func (c *Constellation) LocalizedCSVRow(languages []string) []string {
+	return localizedValues(languages, []LocalizedText{c.Names}, []string{c.ConstellationName})
+}
+
+// LocalizedCSVRow returns the translated name and description columns for the given languages.
+func (t *InvType) LocalizedCSVRow(languages []string) []string {
+	return localizedValues(languages,
+		[]LocalizedText{t.Names, t.Descriptions},
+		[]string{t.TypeName, t.Description})
+}
+
+// LocalizedCSVRow returns the translated name columns for the given languages.
+func (g *InvGroup) LocalizedCSVRow(languages []string) []string {
+	return localizedValues(languages, []LocalizedText{g.Names}, []string{g.GroupName})
+}
+
+// ToCSVRow converts a ChrFaction to a CSV row matching Fuzzwork format.
+func (f *ChrFaction) ToCSVRow() []string {
+	return []string{
+		strconv.FormatInt(f.FactionID, 10),
+		f.FactionName,
+		f.Description,
+		FormatInt64List(f.RaceIDs),
+		FormatNullableInt64(f.SolarSystemID),
+		FormatNullableInt64(f.CorporationID),
+		FormatFloat(f.SizeFactor),
+		strconv.FormatInt(f.StationCount, 10),
+		strconv.FormatInt(f.StationSystemCount, 10),
+		FormatNullableInt64(f.MilitiaCorporationID),
+		FormatNullableInt64(f.IconID),
+	}
+}
+
+// LocalizedCSVRow returns the translated name columns for the given languages.
+func (f *ChrFaction) LocalizedCSVRow(languages []string) []string {
+	return localizedValues(languages, []LocalizedText{f.Names}, []string{f.FactionName})
+}
+
+// ToCSVRow converts a ChrRace to a CSV row matching Fuzzwork format.
+func (r *ChrRace) ToCSVRow() []string {
+	return []string{
+		strconv.FormatInt(r.RaceID, 10),
+		r.RaceName,
+		r.Description,
+		FormatNullableInt64(r.IconID),
+		r.ShortDescription,
+	}
+}
+
+// LocalizedCSVRow returns the translated name columns for the given languages.
+func (r *ChrRace) LocalizedCSVRow(languages []string) []string {
+	return localizedValues(languages, []LocalizedText{r.Names}, []string{r.RaceName})
+}
+
+// ToCSVRow converts a CrpNPCCorporation to a CSV row.
+func (c *CrpNPCCorporation) ToCSVRow() []string {
+	return []string{
+		strconv.FormatInt(c.CorporationID, 10),
+		c.Size,
+		c.Extent,
+		FormatNullableInt64(c.SolarSystemID),
+		FormatNullableInt64(c.FriendID),
+		FormatNullableInt64(c.EnemyID),
+		strconv.FormatInt(c.PublicShares, 10),
+		FormatFloat(c.InitialPrice),
+		FormatFloat(c.MinSecurity),
+		FormatNullableInt64(c.FactionID),
+		FormatFloat(c.SizeFactor),
+		strconv.FormatInt(c.StationCount, 10),
+		strconv.FormatInt(c.StationSystemCount, 10),
+		c.Description,
+		FormatNullableInt64(c.IconID),
+		c.CorporationName,
+		c.TickerName,
+	}
+}
+
+// LocalizedCSVRow returns the translated name columns for the given languages.
+func (c *CrpNPCCorporation) LocalizedCSVRow(languages []string) []string {
+	return localizedValues(languages, []LocalizedText{c.Names}, []string{c.CorporationName})
+}
+
+// ToCSVRow converts an InvMarketGroup to a CSV row matching Fuzzwork format
+// with the derived path columns appended.
+func (g *InvMarketGroup) ToCSVRow() []string {
+	return []string{
+		strconv.FormatInt(g.MarketGroupID, 10),
+		FormatNullableInt64(g.ParentGroupID),
+		g.MarketGroupName,
+		g.Description,
+		FormatNullableInt64(g.IconID),
+		FormatBool(g.HasTypes),
+		g.MarketGroupPath,
+		strconv.FormatInt(g.RootMarketGroupID, 10),
+	}
+}
+
+// LocalizedCSVRow returns the translated name and description columns for the given languages.
+func (g *InvMarketGroup) LocalizedCSVRow(languages []string) []string {
+	return localizedValues(languages,
+		[]LocalizedText{g.Names, g.Descriptions},
+		[]string{g.MarketGroupName, g.Description})
+}
+
+// ToCSVRow converts a DgmAttributeType to a CSV row matching Fuzzwork format.
+func (a *DgmAttributeType) ToCSVRow() []string {
+	return []string{
+		strconv.FormatInt(a.AttributeID, 10),
+		a.AttributeName,
+		a.Description,
+		FormatNullableInt64(a.IconID),
+		FormatFloat(a.DefaultValue),
+		FormatBool(a.Published),
+		a.DisplayName,
+		FormatNullableInt64(a.UnitID),
+		FormatBool(a.Stackable),
+		FormatBool(a.HighIsGood),
+		FormatNullableInt64(a.CategoryID),
+	}
+}
+
+// ToCSVRow converts a DgmEffect to a CSV row.
+func (e *DgmEffect) ToCSVRow() []string {
+	return []string{
+		strconv.FormatInt(e.EffectID, 10),
+		e.EffectName,
+		strconv.FormatInt(e.EffectCategory, 10),
+		e.Description,
+		e.GUID,
+		FormatNullableInt64(e.IconID),
+		FormatBool(e.IsOffensive),
+		FormatBool(e.IsAssistance),
+		FormatNullableInt64(e.DurationAttributeID),
+		FormatNullableInt64(e.TrackingSpeedAttributeID),
+		FormatNullableInt64(e.DischargeAttributeID),
+		FormatNullableInt64(e.RangeAttributeID),
+		FormatNullableInt64(e.FalloffAttributeID),
+		FormatBool(e.DisallowAutoRepeat),
+		FormatBool(e.Published),
+		e.DisplayName,
+		FormatBool(e.IsWarpSafe),
+		FormatBool(e.RangeChance),
+		FormatBool(e.ElectronicChance),
+		FormatBool(e.PropulsionChance),
+		FormatNullableInt64(e.Distribution),
+		FormatNullableInt64(e.NPCUsageChanceAttributeID),
+		FormatNullableInt64(e.NPCActivationChanceAttributeID),
+		FormatNullableInt64(e.FittingUsageChanceAttributeID),
+	}
+}
+
+// ToCSVRow converts a DgmTypeAttribute to a CSV row matching Fuzzwork format.
+func (a *DgmTypeAttribute) ToCSVRow() []string {
+	return []string{
+		strconv.FormatInt(a.TypeID, 10),
+		strconv.FormatInt(a.AttributeID, 10),
+		FormatNullableInt64(a.ValueInt),
+		FormatFloat(a.ValueFloat),
+	}
+}
+
+// ToCSVRow converts a DgmTypeEffect to a CSV row matching Fuzzwork format.
+func (e *DgmTypeEffect) ToCSVRow() []string {
+	return []string{
+		strconv.FormatInt(e.TypeID, 10),
+		strconv.FormatInt(e.EffectID, 10),
+		FormatBool(e.IsDefault),
+	}
+}
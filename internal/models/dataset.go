@@ -0,0 +1,321 @@
+package models
+
+import (
+	"iter"
+	"slices"
+	"strings"
+)
+
+// Dataset indexes ConvertedData for lookups and queries. Indexes hold row
+// positions, so later in-place changes to rows are visible through the Dataset,
+// but rows must not be added, removed or reordered after it is built.
+type Dataset struct {
+	data *ConvertedData
+
+	systems        []SolarSystem
+	regions        []Region
+	constellations []Constellation
+
+	systemByID             map[int64]int
+	systemByName           map[string]int
+	systemsByRegion        map[int64][]int
+	systemsByConstellation map[int64][]int
+	constellationsByRegion map[int64][]int
+	jumpsFromRegion        map[int64][]int
+	regionByID             map[int64]int
+	regionByName           map[string]int
+	constellationByID      map[int64]int
+	constellationByName    map[string]int
+	neighbours             map[int64][]int64
+
+	typeByID        map[int64]int
+	typeByName      map[string]int
+	typesByGroup    map[int64][]int
+	typesByCategory map[int64][]int
+	groupByID       map[int64]int
+	groupByName     map[string]int
+
+	wormholeClassByLocation map[int64]int64
+
+	stationByID       map[int64]int
+	stationByName     map[string]int
+	factionByID       map[int64]int
+	factionByName     map[string]int
+	corporationByID   map[int64]int
+	corporationByName map[string]int
+	marketGroupByID   map[int64]int
+}
+
+// NewDataset indexes data. Tables that are empty (or a nil Universe) produce
+// empty indexes.
+func NewDataset(data *ConvertedData) *Dataset {
+	d := &Dataset{data: data}
+	if data.Universe != nil {
+		d.systems = data.Universe.SolarSystems
+		d.regions = data.Universe.Regions
+		d.constellations = data.Universe.Constellations
+	}
+
+	d.systemByID = indexBy(d.systems, func(s SolarSystem) int64 { return s.SolarSystemID })
+	d.systemByName = indexBy(d.systems, func(s SolarSystem) string { return foldName(s.SolarSystemName) })
+	d.systemsByRegion = groupBy(d.systems, func(s SolarSystem) int64 { return s.RegionID })
+	d.systemsByConstellation = groupBy(d.systems, func(s SolarSystem) int64 { return s.ConstellationID })
+	d.regionByID = indexBy(d.regions, func(r Region) int64 { return r.RegionID })
+	d.regionByName = indexBy(d.regions, func(r Region) string { return foldName(r.RegionName) })
+	d.constellationByID = indexBy(d.constellations, func(c Constellation) int64 { return c.ConstellationID })
+	d.constellationByName = indexBy(d.constellations, func(c Constellation) string { return foldName(c.ConstellationName) })
+	d.constellationsByRegion = groupBy(d.constellations, func(c Constellation) int64 { return c.RegionID })
+	d.jumpsFromRegion = groupBy(data.SystemJumps, d.jumpRegion)
+	d.neighbours = gateNeighbours(data.SystemJumps)
+
+	d.typeByID = indexBy(data.InvTypes, func(t InvType) int64 { return t.TypeID })
+	d.typeByName = indexBy(data.InvTypes, func(t InvType) string { return foldName(t.TypeName) })
+	d.typesByGroup = groupBy(data.InvTypes, func(t InvType) int64 { return t.GroupID })
+	d.groupByID = indexBy(data.InvGroups, func(g InvGroup) int64 { return g.GroupID })
+	d.groupByName = indexBy(data.InvGroups, func(g InvGroup) string { return foldName(g.GroupName) })
+	d.typesByCategory = make(map[int64][]int)
+	for _, g := range data.InvGroups {
+		d.typesByCategory[g.CategoryID] = append(d.typesByCategory[g.CategoryID], d.typesByGroup[g.GroupID]...)
+	}
+	for _, positions := range d.typesByCategory {
+		slices.Sort(positions)
+	}
+
+	d.wormholeClassByLocation = make(map[int64]int64, len(data.WormholeClasses))
+	for _, wc := range data.WormholeClasses {
+		d.wormholeClassByLocation[wc.LocationID] = wc.WormholeClassID
+	}
+
+	d.stationByID = indexBy(data.Stations, func(s StaStation) int64 { return s.StationID })
+	d.stationByName = indexBy(data.Stations, func(s StaStation) string { return foldName(s.StationName) })
+	d.factionByID = indexBy(data.Factions, func(f ChrFaction) int64 { return f.FactionID })
+	d.factionByName = indexBy(data.Factions, func(f ChrFaction) string { return foldName(f.FactionName) })
+	d.corporationByID = indexBy(data.Corporations, func(c CrpNPCCorporation) int64 { return c.CorporationID })
+	d.corporationByName = indexBy(data.Corporations, func(c CrpNPCCorporation) string { return foldName(c.CorporationName) })
+	d.marketGroupByID = indexBy(data.MarketGroups, func(g InvMarketGroup) int64 { return g.MarketGroupID })
+
+	return d
+}
+
+// foldName normalises a name for case-insensitive lookups.
+func foldName(name string) string {
+	return strings.ToLower(name)
+}
+
+// indexBy maps each row's key to its position. The first row wins on duplicate keys.
+func indexBy[T any, K comparable](rows []T, key func(T) K) map[K]int {
+	index := make(map[K]int, len(rows))
+	for i, row := range rows {
+		k := key(row)
+		if _, ok := index[k]; !ok {
+			index[k] = i
+		}
+	}
+	return index
+}
+
+// groupBy maps each key to the positions of its rows, in row order.
+func groupBy[T any](rows []T, key func(T) int64) map[int64][]int {
+	groups := make(map[int64][]int)
+	for i, row := range rows {
+		k := key(row)
+		groups[k] = append(groups[k], i)
+	}
+	return groups
+}
+
+// jumpRegion returns the region of a jump's origin system, falling back to its
+// FromRegionID when the system is not indexed, so jumps whose region is filled
+// in after indexing are still grouped.
+func (d *Dataset) jumpRegion(j SystemJump) int64 {
+	if i, ok := d.systemByID[j.FromSolarSystemID]; ok {
+		return d.systems[i].RegionID
+	}
+	return j.FromRegionID
+}
+
+// gateNeighbours maps each system to the sorted, distinct systems it has a gate to.
+func gateNeighbours(jumps []SystemJump) map[int64][]int64 {
+	neighbours := make(map[int64][]int64)
+	for _, j := range jumps {
+		neighbours[j.FromSolarSystemID] = append(neighbours[j.FromSolarSystemID], j.ToSolarSystemID)
+	}
+	for id, ids := range neighbours {
+		slices.Sort(ids)
+		neighbours[id] = slices.Compact(ids)
+	}
+	return neighbours
+}
+
+// row returns rows[index[key]].
+func row[T any, K comparable](rows []T, index map[K]int, key K) (T, bool) {
+	i, ok := index[key]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return rows[i], true
+}
+
+// rowsAt iterates over rows at the given positions.
+func rowsAt[T any](rows []T, positions []int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, i := range positions {
+			if !yield(rows[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Data returns the indexed data.
+func (d *Dataset) Data() *ConvertedData {
+	return d.data
+}
+
+// SolarSystem returns the solar system with the given ID.
+func (d *Dataset) SolarSystem(id int64) (SolarSystem, bool) {
+	return row(d.systems, d.systemByID, id)
+}
+
+// SolarSystemByName returns the solar system with the given English name, ignoring case.
+func (d *Dataset) SolarSystemByName(name string) (SolarSystem, bool) {
+	return row(d.systems, d.systemByName, foldName(name))
+}
+
+// SystemsInRegion iterates over the solar systems of a region in row order.
+func (d *Dataset) SystemsInRegion(regionID int64) iter.Seq[SolarSystem] {
+	return rowsAt(d.systems, d.systemsByRegion[regionID])
+}
+
+// SystemsInConstellation iterates over the solar systems of a constellation in row order.
+func (d *Dataset) SystemsInConstellation(constellationID int64) iter.Seq[SolarSystem] {
+	return rowsAt(d.systems, d.systemsByConstellation[constellationID])
+}
+
+// ConstellationsInRegion iterates over the constellations of a region in row order.
+func (d *Dataset) ConstellationsInRegion(regionID int64) iter.Seq[Constellation] {
+	return rowsAt(d.constellations, d.constellationsByRegion[regionID])
+}
+
+// JumpsFromRegion iterates over the system jumps leaving systems of a region
+// in row order, including the gates to other regions.
+func (d *Dataset) JumpsFromRegion(regionID int64) iter.Seq[SystemJump] {
+	return rowsAt(d.data.SystemJumps, d.jumpsFromRegion[regionID])
+}
+
+// Neighbours returns the sorted IDs of the systems a system has stargates to.
+func (d *Dataset) Neighbours(systemID int64) []int64 {
+	return slices.Clone(d.neighbours[systemID])
+}
+
+// Region returns the region with the given ID.
+func (d *Dataset) Region(id int64) (Region, bool) {
+	return row(d.regions, d.regionByID, id)
+}
+
+// RegionByName returns the region with the given English name, ignoring case.
+func (d *Dataset) RegionByName(name string) (Region, bool) {
+	return row(d.regions, d.regionByName, foldName(name))
+}
+
+// Constellation returns the constellation with the given ID.
+func (d *Dataset) Constellation(id int64) (Constellation, bool) {
+	return row(d.constellations, d.constellationByID, id)
+}
+
+// ConstellationByName returns the constellation with the given English name, ignoring case.
+func (d *Dataset) ConstellationByName(name string) (Constellation, bool) {
+	return row(d.constellations, d.constellationByName, foldName(name))
+}
+
+// Type returns the item type with the given ID.
+func (d *Dataset) Type(id int64) (InvType, bool) {
+	return row(d.data.InvTypes, d.typeByID, id)
+}
+
+// TypeByName returns the item type with the given English name, ignoring case.
+func (d *Dataset) TypeByName(name string) (InvType, bool) {
+	return row(d.data.InvTypes, d.typeByName, foldName(name))
+}
+
+// TypesInGroup iterates over the item types of a group in row order.
+func (d *Dataset) TypesInGroup(groupID int64) iter.Seq[InvType] {
+	return rowsAt(d.data.InvTypes, d.typesByGroup[groupID])
+}
+
+// TypesInCategory iterates over the item types of every group in a category, in row order.
+// It needs the groups table to map groups to categories.
+func (d *Dataset) TypesInCategory(categoryID int64) iter.Seq[InvType] {
+	return rowsAt(d.data.InvTypes, d.typesByCategory[categoryID])
+}
+
+// Group returns the item group with the given ID.
+func (d *Dataset) Group(id int64) (InvGroup, bool) {
+	return row(d.data.InvGroups, d.groupByID, id)
+}
+
+// GroupByName returns the item group with the given English name, ignoring case.
+func (d *Dataset) GroupByName(name string) (InvGroup, bool) {
+	return row(d.data.InvGroups, d.groupByName, foldName(name))
+}
+
+// LocationWormholeClass returns the wormhole class set directly on a region,
+// constellation or solar system, without inheritance.
+func (d *Dataset) LocationWormholeClass(locationID int64) (int64, bool) {
+	class, ok := d.wormholeClassByLocation[locationID]
+	return class, ok
+}
+
+// WormholeClass returns the wormhole class of a solar system. A class set on the
+// system itself wins, otherwise the system inherits its constellation's class
+// and then its region's.
+func (d *Dataset) WormholeClass(systemID int64) (int64, bool) {
+	if class, ok := d.wormholeClassByLocation[systemID]; ok {
+		return class, true
+	}
+	sys, ok := d.SolarSystem(systemID)
+	if !ok {
+		return 0, false
+	}
+	if class, ok := d.wormholeClassByLocation[sys.ConstellationID]; ok {
+		return class, true
+	}
+	class, ok := d.wormholeClassByLocation[sys.RegionID]
+	return class, ok
+}
+
+// Station returns the NPC station with the given ID.
+func (d *Dataset) Station(id int64) (StaStation, bool) {
+	return row(d.data.Stations, d.stationByID, id)
+}
+
+// StationByName returns the NPC station with the given English name, ignoring case.
+func (d *Dataset) StationByName(name string) (StaStation, bool) {
+	return row(d.data.Stations, d.stationByName, foldName(name))
+}
+
+// Faction returns the faction with the given ID.
+func (d *Dataset) Faction(id int64) (ChrFaction, bool) {
+	return row(d.data.Factions, d.factionByID, id)
+}
+
+// FactionByName returns the faction with the given English name, ignoring case.
+func (d *Dataset) FactionByName(name string) (ChrFaction, bool) {
+	return row(d.data.Factions, d.factionByName, foldName(name))
+}
+
+// Corporation returns the NPC corporation with the given ID.
+func (d *Dataset) Corporation(id int64) (CrpNPCCorporation, bool) {
+	return row(d.data.Corporations, d.corporationByID, id)
+}
+
+// CorporationByName returns the NPC corporation with the given English name, ignoring case.
+func (d *Dataset) CorporationByName(name string) (CrpNPCCorporation, bool) {
+	return row(d.data.Corporations, d.corporationByName, foldName(name))
+}
+
+// MarketGroup returns the market group with the given ID.
+func (d *Dataset) MarketGroup(id int64) (InvMarketGroup, bool) {
+	return row(d.data.MarketGroups, d.marketGroupByID, id)
+}
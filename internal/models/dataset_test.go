@@ -0,0 +1,185 @@
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+func testConvertedData() *ConvertedData {
+	return &ConvertedData{
+		Universe: &UniverseData{
+			Regions: []Region{
+				{RegionID: 10000002, RegionName: "The Forge"},
+				{RegionID: 11000001, RegionName: "A-R00001"},
+			},
+			Constellations: []Constellation{
+				{RegionID: 10000002, ConstellationID: 20000020, ConstellationName: "Kimotoro"},
+				{RegionID: 11000001, ConstellationID: 21000001, ConstellationName: "A-C00311"},
+				{RegionID: 11000001, ConstellationID: 21000002, ConstellationName: "A-C00312"},
+			},
+			SolarSystems: []SolarSystem{
+				{RegionID: 10000002, ConstellationID: 20000020, SolarSystemID: 30000142, SolarSystemName: "Jita"},
+				{RegionID: 10000002, ConstellationID: 20000020, SolarSystemID: 30000144, SolarSystemName: "Perimeter"},
+				{RegionID: 11000001, ConstellationID: 21000001, SolarSystemID: 31000001, SolarSystemName: "J100001"},
+				{RegionID: 11000001, ConstellationID: 21000002, SolarSystemID: 31000002, SolarSystemName: "J100002"},
+				{RegionID: 11000001, ConstellationID: 21000002, SolarSystemID: 31000003, SolarSystemName: "J100003"},
+			},
+		},
+		SystemJumps: []SystemJump{
+			{FromSolarSystemID: 30000142, ToSolarSystemID: 30000144},
+			{FromSolarSystemID: 30000144, ToSolarSystemID: 30000142},
+			{FromSolarSystemID: 30000142, ToSolarSystemID: 30000144}, // duplicate gate
+		},
+		InvTypes: []InvType{
+			{TypeID: 587, GroupID: 25, TypeName: "Rifter"},
+			{TypeID: 588, GroupID: 25, TypeName: "Reaper"},
+			{TypeID: 621, GroupID: 26, TypeName: "Caracal"},
+			{TypeID: 34, GroupID: 18, TypeName: "Tritanium"},
+		},
+		InvGroups: []InvGroup{
+			{GroupID: 18, CategoryID: 4, GroupName: "Mineral"},
+			{GroupID: 25, CategoryID: 6, GroupName: "Frigate"},
+			{GroupID: 26, CategoryID: 6, GroupName: "Cruiser"},
+		},
+		WormholeClasses: []WormholeClassLocation{
+			{LocationID: 11000001, WormholeClassID: 1},
+			{LocationID: 21000002, WormholeClassID: 2},
+			{LocationID: 31000003, WormholeClassID: 3},
+		},
+	}
+}
+
+func TestDataset_Lookups(t *testing.T) {
+	d := NewDataset(testConvertedData())
+
+	if sys, ok := d.SolarSystem(30000142); !ok || sys.SolarSystemName != "Jita" {
+		t.Errorf("SolarSystem(30000142) = %q, %v; want Jita", sys.SolarSystemName, ok)
+	}
+	for _, name := range []string{"Jita", "jita", "JITA"} {
+		if sys, ok := d.SolarSystemByName(name); !ok || sys.SolarSystemID != 30000142 {
+			t.Errorf("SolarSystemByName(%q) = %d, %v; want 30000142", name, sys.SolarSystemID, ok)
+		}
+	}
+	if _, ok := d.SolarSystemByName("Amarr"); ok {
+		t.Error("SolarSystemByName(Amarr) found, want missing")
+	}
+	if r, ok := d.RegionByName("the forge"); !ok || r.RegionID != 10000002 {
+		t.Errorf("RegionByName(the forge) = %d, %v; want 10000002", r.RegionID, ok)
+	}
+	if c, ok := d.Constellation(20000020); !ok || c.ConstellationName != "Kimotoro" {
+		t.Errorf("Constellation(20000020) = %q, %v; want Kimotoro", c.ConstellationName, ok)
+	}
+	if typ, ok := d.TypeByName("rifter"); !ok || typ.TypeID != 587 {
+		t.Errorf("TypeByName(rifter) = %d, %v; want 587", typ.TypeID, ok)
+	}
+	if g, ok := d.Group(26); !ok || g.GroupName != "Cruiser" {
+		t.Errorf("Group(26) = %q, %v; want Cruiser", g.GroupName, ok)
+	}
+}
+
+func TestDataset_Queries(t *testing.T) {
+	d := NewDataset(testConvertedData())
+
+	systemIDs := func(systems []SolarSystem) []int64 {
+		ids := make([]int64, len(systems))
+		for i, s := range systems {
+			ids[i] = s.SolarSystemID
+		}
+		return ids
+	}
+	typeIDs := func(types []InvType) []int64 {
+		ids := make([]int64, len(types))
+		for i, t := range types {
+			ids[i] = t.TypeID
+		}
+		return ids
+	}
+
+	if got := systemIDs(slices.Collect(d.SystemsInRegion(11000001))); !slices.Equal(got, []int64{31000001, 31000002, 31000003}) {
+		t.Errorf("SystemsInRegion(11000001) = %v", got)
+	}
+	if got := systemIDs(slices.Collect(d.SystemsInConstellation(20000020))); !slices.Equal(got, []int64{30000142, 30000144}) {
+		t.Errorf("SystemsInConstellation(20000020) = %v", got)
+	}
+	var constellationIDs []int64
+	for c := range d.ConstellationsInRegion(11000001) {
+		constellationIDs = append(constellationIDs, c.ConstellationID)
+	}
+	if !slices.Equal(constellationIDs, []int64{21000001, 21000002}) {
+		t.Errorf("ConstellationsInRegion(11000001) = %v", constellationIDs)
+	}
+	jumps := NewDataset(&ConvertedData{SystemJumps: []SystemJump{
+		{FromRegionID: 10000002, FromSolarSystemID: 30000142, ToSolarSystemID: 30000144, ToRegionID: 10000002},
+		{FromRegionID: 10000033, FromSolarSystemID: 30002813, ToSolarSystemID: 30000142, ToRegionID: 10000002},
+		{FromRegionID: 10000002, FromSolarSystemID: 30000142, ToSolarSystemID: 30002813, ToRegionID: 10000033},
+	}})
+	var targets []int64
+	for j := range jumps.JumpsFromRegion(10000002) {
+		targets = append(targets, j.ToSolarSystemID)
+	}
+	if !slices.Equal(targets, []int64{30000144, 30002813}) {
+		t.Errorf("JumpsFromRegion(10000002) reaches %v, want [30000144 30002813]", targets)
+	}
+	// Jumps without region IDs are grouped by their origin system's region
+	if got := len(slices.Collect(d.JumpsFromRegion(10000002))); got != 3 {
+		t.Errorf("JumpsFromRegion(10000002) of unenriched jumps has %d jumps, want 3", got)
+	}
+	if got := d.Neighbours(30000142); !slices.Equal(got, []int64{30000144}) {
+		t.Errorf("Neighbours(30000142) = %v, want [30000144]", got)
+	}
+	if got := d.Neighbours(31000001); len(got) != 0 {
+		t.Errorf("Neighbours(31000001) = %v, want none", got)
+	}
+	if got := typeIDs(slices.Collect(d.TypesInGroup(25))); !slices.Equal(got, []int64{587, 588}) {
+		t.Errorf("TypesInGroup(25) = %v", got)
+	}
+	if got := typeIDs(slices.Collect(d.TypesInCategory(6))); !slices.Equal(got, []int64{587, 588, 621}) {
+		t.Errorf("TypesInCategory(6) = %v", got)
+	}
+}
+
+func TestDataset_WormholeClass(t *testing.T) {
+	d := NewDataset(testConvertedData())
+
+	tests := []struct {
+		name     string
+		systemID int64
+		want     int64
+		wantOK   bool
+	}{
+		{"inherited from region", 31000001, 1, true},
+		{"inherited from constellation", 31000002, 2, true},
+		{"set on system", 31000003, 3, true},
+		{"no class", 30000142, 0, false},
+		{"unknown system", 1, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := d.WormholeClass(tt.systemID)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("WormholeClass(%d) = %d, %v; want %d, %v", tt.systemID, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDataset_ReflectsInPlaceChanges(t *testing.T) {
+	data := testConvertedData()
+	d := NewDataset(data)
+
+	data.Universe.SolarSystems[0].Security = 0.9
+	if sys, _ := d.SolarSystem(30000142); sys.Security != 0.9 {
+		t.Errorf("Security = %v, want in-place change 0.9", sys.Security)
+	}
+}
+
+func TestDataset_Empty(t *testing.T) {
+	d := NewDataset(&ConvertedData{})
+
+	if _, ok := d.SolarSystem(30000142); ok {
+		t.Error("SolarSystem found in empty dataset")
+	}
+	if got := slices.Collect(d.SystemsInRegion(10000002)); len(got) != 0 {
+		t.Errorf("SystemsInRegion = %v, want none", got)
+	}
+}
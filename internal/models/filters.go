@@ -1,10 +1,6 @@
-package transformer
+package models
 
-import (
-	"sort"
-
-	"github.com/guarzo/wanderer-sde/internal/models"
-)
+import "sort"
 
 // ShipCategoryID is the category ID for ships in EVE Online.
 const ShipCategoryID = 6
@@ -12,7 +8,7 @@ const ShipCategoryID = 6
 // FilterShipTypes filters the type data to only include ships.
 // Ships are identified by having a groupID that belongs to a group
 // with categoryID == ShipCategoryID (6).
-func FilterShipTypes(types map[int64]models.SDEType, groups map[int64]models.SDEGroup) []models.ShipType {
+func FilterShipTypes(types map[int64]SDEType, groups map[int64]SDEGroup) []ShipType {
 	// Build set of ship group IDs (groups that belong to the ship category)
 	shipGroupIDs := make(map[int64]bool)
 	for groupID, group := range groups {
@@ -22,7 +18,7 @@ func FilterShipTypes(types map[int64]models.SDEType, groups map[int64]models.SDE
 	}
 
 	// Filter types to only include ships
-	var ships []models.ShipType
+	var ships []ShipType
 	for typeID, typeData := range types {
 		if shipGroupIDs[typeData.GroupID] {
 			// Get the English name, or fallback to empty string
@@ -31,7 +27,7 @@ func FilterShipTypes(types map[int64]models.SDEType, groups map[int64]models.SDE
 				typeName = name
 			}
 
-			ships = append(ships, models.ShipType{
+			ships = append(ships, ShipType{
 				TypeID:   typeID,
 				GroupID:  typeData.GroupID,
 				TypeName: typeName,
@@ -51,8 +47,8 @@ func FilterShipTypes(types map[int64]models.SDEType, groups map[int64]models.SDE
 }
 
 // FilterShipGroups filters the group data to only include ship groups.
-func FilterShipGroups(groups map[int64]models.SDEGroup) []models.ItemGroup {
-	var shipGroups []models.ItemGroup
+func FilterShipGroups(groups map[int64]SDEGroup) []ItemGroup {
+	var shipGroups []ItemGroup
 
 	for groupID, group := range groups {
 		if group.CategoryID == ShipCategoryID {
@@ -62,7 +58,7 @@ func FilterShipGroups(groups map[int64]models.SDEGroup) []models.ItemGroup {
 				groupName = name
 			}
 
-			shipGroups = append(shipGroups, models.ItemGroup{
+			shipGroups = append(shipGroups, ItemGroup{
 				GroupID:    groupID,
 				CategoryID: group.CategoryID,
 				GroupName:  groupName,
@@ -79,8 +75,8 @@ func FilterShipGroups(groups map[int64]models.SDEGroup) []models.ItemGroup {
 }
 
 // FilterPublishedTypes filters to only include published types.
-func FilterPublishedTypes(types map[int64]models.SDEType) map[int64]models.SDEType {
-	published := make(map[int64]models.SDEType)
+func FilterPublishedTypes(types map[int64]SDEType) map[int64]SDEType {
+	published := make(map[int64]SDEType)
 	for typeID, typeData := range types {
 		if typeData.Published {
 			published[typeID] = typeData
@@ -90,8 +86,8 @@ func FilterPublishedTypes(types map[int64]models.SDEType) map[int64]models.SDETy
 }
 
 // FilterPublishedGroups filters to only include published groups.
-func FilterPublishedGroups(groups map[int64]models.SDEGroup) map[int64]models.SDEGroup {
-	published := make(map[int64]models.SDEGroup)
+func FilterPublishedGroups(groups map[int64]SDEGroup) map[int64]SDEGroup {
+	published := make(map[int64]SDEGroup)
 	for groupID, groupData := range groups {
 		if groupData.Published {
 			published[groupID] = groupData
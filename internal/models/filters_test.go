@@ -1,20 +1,16 @@
-package transformer
+package models
 
-import (
-	"testing"
-
-	"github.com/guarzo/wanderer-sde/internal/models"
-)
+import "testing"
 
 func TestFilterShipTypes(t *testing.T) {
 	// Create test data
-	groups := map[int64]models.SDEGroup{
+	groups := map[int64]SDEGroup{
 		25: {CategoryID: ShipCategoryID, Name: map[string]string{"en": "Frigate"}},
 		26: {CategoryID: ShipCategoryID, Name: map[string]string{"en": "Cruiser"}},
 		18: {CategoryID: 7, Name: map[string]string{"en": "Drone"}}, // Not a ship
 	}
 
-	types := map[int64]models.SDEType{
+	types := map[int64]SDEType{
 		587:  {GroupID: 25, Name: map[string]string{"en": "Rifter"}, Mass: 1350000, Volume: 27500},
 		588:  {GroupID: 25, Name: map[string]string{"en": "Slasher"}, Mass: 1200000, Volume: 26000},
 		625:  {GroupID: 26, Name: map[string]string{"en": "Caracal"}, Mass: 11000000, Volume: 92000},
@@ -42,7 +38,7 @@ func TestFilterShipTypes(t *testing.T) {
 }
 
 func TestFilterShipGroups(t *testing.T) {
-	groups := map[int64]models.SDEGroup{
+	groups := map[int64]SDEGroup{
 		25: {CategoryID: ShipCategoryID, Name: map[string]string{"en": "Frigate"}},
 		26: {CategoryID: ShipCategoryID, Name: map[string]string{"en": "Cruiser"}},
 		27: {CategoryID: ShipCategoryID, Name: map[string]string{"en": "Battleship"}},
@@ -72,7 +68,7 @@ func TestFilterShipGroups(t *testing.T) {
 }
 
 func TestFilterPublishedTypes(t *testing.T) {
-	types := map[int64]models.SDEType{
+	types := map[int64]SDEType{
 		1: {GroupID: 1, Published: true},
 		2: {GroupID: 1, Published: false},
 		3: {GroupID: 1, Published: true},
@@ -90,7 +86,7 @@ func TestFilterPublishedTypes(t *testing.T) {
 }
 
 func TestFilterPublishedGroups(t *testing.T) {
-	groups := map[int64]models.SDEGroup{
+	groups := map[int64]SDEGroup{
 		1: {CategoryID: 1, Published: true},
 		2: {CategoryID: 1, Published: false},
 		3: {CategoryID: 1, Published: true},
@@ -108,11 +104,11 @@ func TestFilterPublishedGroups(t *testing.T) {
 }
 
 func TestFilterShipTypes_EmptyName(t *testing.T) {
-	groups := map[int64]models.SDEGroup{
+	groups := map[int64]SDEGroup{
 		25: {CategoryID: ShipCategoryID, Name: map[string]string{"en": "Frigate"}},
 	}
 
-	types := map[int64]models.SDEType{
+	types := map[int64]SDEType{
 		587: {GroupID: 25, Name: map[string]string{}}, // No English name
 	}
 
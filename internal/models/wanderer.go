@@ -1,5 +1,19 @@
 package models
 
+// DefaultLanguage is the SDE language used for the primary name columns.
+const DefaultLanguage = "en"
+
+// LocalizedText holds translations of a name or description keyed by SDE language code.
+type LocalizedText map[string]string
+
+// Get returns the translation for lang, or fallback when it is missing or empty.
+func (l LocalizedText) Get(lang, fallback string) string {
+	if text := l[lang]; text != "" {
+		return text
+	}
+	return fallback
+}
+
 // SolarSystem represents a solar system in Wanderer's format.
 // Fields match Fuzzwork CSV column order for mapSolarSystems.csv.
 type SolarSystem struct {
@@ -29,6 +43,39 @@ type SolarSystem struct {
 	Radius          float64 `json:"radius"`
 	SunTypeID       *int64  `json:"sunTypeID,omitempty"` // Pointer to allow "None" in CSV
 	SecurityClass   string  `json:"securityClass,omitempty"`
+	// WormholeClassID is the effective wormhole class, inherited from the
+	// constellation and then the region when the system sets none.
+	WormholeClassID *int64 `json:"wormholeClassID,omitempty"` // Pointer to allow "None" in CSV
+	// DisplaySecurity, SecurityBand and WormholeSpace are derived from the
+	// security and IDs, and only set when security columns are enabled.
+	DisplaySecurity *float64 `json:"displaySecurity,omitempty"`
+	SecurityBand    string   `json:"securityBand,omitempty"`
+	WormholeSpace   *bool    `json:"wormholeSpace,omitempty"`
+	// ProjectedX and ProjectedY are the 2D map position in light-years, x east
+	// and z north, only set when projection columns are enabled.
+	ProjectedX *float64 `json:"projectedX,omitempty"`
+	ProjectedY *float64 `json:"projectedY,omitempty"`
+
+	// Names holds all SDE translations of SolarSystemName.
+	Names LocalizedText `json:"-"`
+	// GateFlags holds the flags derived from the stargate graph. The transformer
+	// sets it on every system whenever the systems table is converted. It is not
+	// written out; it only fills in the flags the SDE lacks and backs the
+	// validation warnings.
+	GateFlags *GateFlags `json:"-"`
+}
+
+// GateFlags are the solar system flags that follow from its stargates and
+// the faction ownership of the systems they lead to.
+type GateFlags struct {
+	// Border is set when a gate leads to another constellation.
+	Border bool
+	// Regional is set when a gate leads to another region.
+	Regional bool
+	// International is set when a gate leads to a system of another faction.
+	International bool
+	// Hub is set when gates lead to three or more systems.
+	Hub bool
 }
 
 // Region represents a region in Wanderer's format.
@@ -48,6 +95,14 @@ type Region struct {
 	FactionID  *int64  `json:"factionID,omitempty"` // Pointer to allow "None" in CSV
 	Nebula     int64   `json:"nebula"`              // Not in SDE, use 0
 	Radius     float64 `json:"radius"`
+	// X, Y and Z are the SDE position. The centroid is the mean position of
+	// the region's systems, and Radius the bounding sphere around it.
+	CentroidX float64 `json:"centroidX"`
+	CentroidY float64 `json:"centroidY"`
+	CentroidZ float64 `json:"centroidZ"`
+
+	// Names holds all SDE translations of RegionName.
+	Names LocalizedText `json:"-"`
 }
 
 // Constellation represents a constellation in Wanderer's format.
@@ -67,6 +122,14 @@ type Constellation struct {
 	ZMax              float64 `json:"zMax"`
 	FactionID         *int64  `json:"factionID,omitempty"` // Pointer to allow "None" in CSV
 	Radius            float64 `json:"radius"`
+	// X, Y and Z are the SDE position. The centroid is the mean position of
+	// the constellation's systems, and Radius the bounding sphere around it.
+	CentroidX float64 `json:"centroidX"`
+	CentroidY float64 `json:"centroidY"`
+	CentroidZ float64 `json:"centroidZ"`
+
+	// Names holds all SDE translations of ConstellationName.
+	Names LocalizedText `json:"-"`
 }
 
 // WormholeClassLocation represents a wormhole class assignment in Wanderer's format.
@@ -75,6 +138,33 @@ type WormholeClassLocation struct {
 	WormholeClassID int64 `json:"wormholeClassID"`
 }
 
+// Security bands recorded in SolarSystem.SecurityBand.
+const (
+	SecurityBandHighsec  = "highsec"
+	SecurityBandLowsec   = "lowsec"
+	SecurityBandNullsec  = "nullsec"
+	SecurityBandWormhole = "wormhole"
+	SecurityBandPochven  = "pochven"
+	SecurityBandAbyssal  = "abyssal"
+	SecurityBandZarzakh  = "zarzakh"
+)
+
+// Wormhole class sources recorded in SystemWormholeClass.Source.
+const (
+	WormholeClassFromSystem        = "system"
+	WormholeClassFromConstellation = "constellation"
+	WormholeClassFromRegion        = "region"
+)
+
+// SystemWormholeClass is the effective wormhole class of a solar system,
+// flattened from mapLocationWormholeClasses.
+type SystemWormholeClass struct {
+	SolarSystemID   int64 `json:"solarSystemID"`
+	WormholeClassID int64 `json:"wormholeClassID"`
+	// Source is the level the class comes from: system, constellation or region.
+	Source string `json:"source"`
+}
+
 // InvType represents an item type in Wanderer's format.
 // Fields match Fuzzwork CSV column order for invTypes.csv.
 type InvType struct {
@@ -93,6 +183,10 @@ type InvType struct {
 	IconID        *int64  `json:"iconID,omitempty"`        // Pointer to allow "None" in CSV
 	SoundID       *int64  `json:"soundID,omitempty"`       // Pointer to allow "None" in CSV
 	GraphicID     *int64  `json:"graphicID,omitempty"`     // Pointer to allow "None" in CSV
+
+	// Names and Descriptions hold all SDE translations of TypeName and Description.
+	Names        LocalizedText `json:"-"`
+	Descriptions LocalizedText `json:"-"`
 }
 
 // ShipType is an alias for backward compatibility.
@@ -111,6 +205,21 @@ type InvGroup struct {
 	Anchorable           bool   `json:"anchorable"`
 	FittableNonSingleton bool   `json:"fittableNonSingleton"`
 	Published            bool   `json:"published"`
+
+	// Names holds all SDE translations of GroupName.
+	Names LocalizedText `json:"-"`
+}
+
+// InvCategory represents an item category in Wanderer's format.
+// Fields match Fuzzwork CSV column order for invCategories.csv.
+type InvCategory struct {
+	CategoryID   int64  `json:"categoryID"`
+	CategoryName string `json:"categoryName"`
+	IconID       *int64 `json:"iconID,omitempty"`
+	Published    bool   `json:"published"`
+
+	// Names holds all SDE translations of CategoryName.
+	Names LocalizedText `json:"-"`
 }
 
 // ItemGroup is an alias for backward compatibility.
@@ -128,6 +237,299 @@ type SystemJump struct {
 	ToRegionID          int64 `json:"toRegionID"`
 }
 
+// RegionJump is a directed stargate connection between two regions.
+// Fields match Fuzzwork CSV column order for mapRegionJumps.csv, followed by
+// the derived gate count and border systems.
+type RegionJump struct {
+	FromRegionID int64 `json:"fromRegionID"`
+	ToRegionID   int64 `json:"toRegionID"`
+	// GateCount is the number of stargates leading from one region to the other.
+	GateCount int64 `json:"gateCount"`
+	// FromSolarSystemIDs and ToSolarSystemIDs are the sorted border systems
+	// holding those gates and their destinations.
+	FromSolarSystemIDs []int64 `json:"fromSolarSystemIDs"`
+	ToSolarSystemIDs   []int64 `json:"toSolarSystemIDs"`
+}
+
+// ConstellationJump is a directed stargate connection between two constellations.
+// Fields match Fuzzwork CSV column order for mapConstellationJumps.csv, followed
+// by the derived gate count and border systems.
+type ConstellationJump struct {
+	FromRegionID        int64 `json:"fromRegionID"`
+	FromConstellationID int64 `json:"fromConstellationID"`
+	ToConstellationID   int64 `json:"toConstellationID"`
+	ToRegionID          int64 `json:"toRegionID"`
+	// GateCount is the number of stargates leading from one constellation to the other.
+	GateCount int64 `json:"gateCount"`
+	// FromSolarSystemIDs and ToSolarSystemIDs are the sorted border systems
+	// holding those gates and their destinations.
+	FromSolarSystemIDs []int64 `json:"fromSolarSystemIDs"`
+	ToSolarSystemIDs   []int64 `json:"toSolarSystemIDs"`
+}
+
+// Stargate is one stargate, linked to the gate it jumps to.
+// Fields match the mapStargates.csv column order.
+type Stargate struct {
+	StargateID               int64 `json:"stargateID"`
+	SolarSystemID            int64 `json:"solarSystemID"`
+	DestinationStargateID    int64 `json:"destinationStargateID"`
+	DestinationSolarSystemID int64 `json:"destinationSolarSystemID"`
+	TypeID                   int64 `json:"typeID"`
+	// X, Y and Z are the in-system position, or nil when the SDE has none.
+	X *float64 `json:"x,omitempty"` // Pointer to allow "None" in CSV
+	Y *float64 `json:"y,omitempty"`
+	Z *float64 `json:"z,omitempty"`
+}
+
+// SystemTopology describes a solar system's place in the stargate network.
+// Fields match the mapSystemTopology.csv column order.
+type SystemTopology struct {
+	SolarSystemID int64 `json:"solarSystemID"`
+	RegionID      int64 `json:"regionID"`
+	// GateCount is the number of distinct systems reachable through one gate.
+	GateCount int64 `json:"gateCount"`
+	// ArticulationPoint marks chokepoints whose loss splits the network.
+	ArticulationPoint bool `json:"articulationPoint"`
+	// BridgeCount is the number of this system's gates that are bridges,
+	// the only connection between two parts of the network.
+	BridgeCount int64 `json:"bridgeCount"`
+	DeadEnd     bool  `json:"deadEnd"`
+	// PipeID is the lowest system ID of the pipe this system lies in, or nil
+	// outside pipes; PipeLength is that pipe's number of systems.
+	PipeID     *int64 `json:"pipeID,omitempty"` // Pointer to allow "None" in CSV
+	PipeLength int64  `json:"pipeLength"`
+	// Betweenness is the share of shortest routes between other systems that
+	// pass through this one, from 0 to 1.
+	Betweenness float64 `json:"betweenness"`
+}
+
+// TopologyReport summarises the stargate network for mapSystemTopologyReport.json.
+type TopologyReport struct {
+	// Systems and Gates count the systems with gates and the undirected
+	// connections between them; Components counts the separate networks.
+	Systems            int              `json:"systems"`
+	Gates              int              `json:"gates"`
+	Components         int              `json:"components"`
+	ArticulationPoints []int64          `json:"articulationPoints"`
+	Bridges            []TopologyBridge `json:"bridges"`
+	DeadEnds           []int64          `json:"deadEnds"`
+	Pipes              []TopologyPipe   `json:"pipes"`
+	// MostCentral ranks the systems with the highest betweenness.
+	MostCentral []TopologyRank `json:"mostCentral"`
+}
+
+// TopologyBridge is a gate connection whose loss splits the network.
+type TopologyBridge struct {
+	FromSolarSystemID int64 `json:"fromSolarSystemID"`
+	ToSolarSystemID   int64 `json:"toSolarSystemID"`
+}
+
+// TopologyPipe is a chain of systems with exactly two gate neighbours each,
+// listed in route order.
+type TopologyPipe struct {
+	PipeID         int64   `json:"pipeID"`
+	SolarSystemIDs []int64 `json:"solarSystemIDs"`
+}
+
+// TopologyRank is a system with its betweenness.
+type TopologyRank struct {
+	SolarSystemID   int64   `json:"solarSystemID"`
+	SolarSystemName string  `json:"solarSystemName"`
+	RegionID        int64   `json:"regionID"`
+	Betweenness     float64 `json:"betweenness"`
+}
+
+// StaStation represents an NPC station in Wanderer's format.
+// Fields match Fuzzwork CSV column order for staStations.csv.
+type StaStation struct {
+	StationID                int64   `json:"stationID"`
+	Security                 float64 `json:"security"`
+	DockingCostPerVolume     float64 `json:"dockingCostPerVolume"`  // Not in SDE, use 0
+	MaxShipVolumeDockable    float64 `json:"maxShipVolumeDockable"` // Not in SDE, use 0
+	OfficeRentalCost         int64   `json:"officeRentalCost"`      // Not in SDE, use 0
+	OperationID              int64   `json:"operationID"`
+	StationTypeID            int64   `json:"stationTypeID"`
+	CorporationID            int64   `json:"corporationID"`
+	SolarSystemID            int64   `json:"solarSystemID"`
+	ConstellationID          int64   `json:"constellationID"`
+	RegionID                 int64   `json:"regionID"`
+	StationName              string  `json:"stationName"`
+	X                        float64 `json:"x"`
+	Y                        float64 `json:"y"`
+	Z                        float64 `json:"z"`
+	ReprocessingEfficiency   float64 `json:"reprocessingEfficiency"`
+	ReprocessingStationsTake float64 `json:"reprocessingStationsTake"`
+	ReprocessingHangarFlag   int64   `json:"reprocessingHangarFlag"`
+}
+
+// StaService represents a station service in Wanderer's format.
+// Fields match Fuzzwork CSV column order for staServices.csv.
+type StaService struct {
+	ServiceID   int64  `json:"serviceID"`
+	ServiceName string `json:"serviceName"`
+	Description string `json:"description"`
+}
+
+// StaOperationService links a station operation to a service it provides.
+// Fields match Fuzzwork CSV column order for staOperationServices.csv.
+type StaOperationService struct {
+	OperationID int64 `json:"operationID"`
+	ServiceID   int64 `json:"serviceID"`
+}
+
+// ChrFaction represents a faction in Wanderer's format.
+// Fields match Fuzzwork CSV column order for chrFactions.csv.
+type ChrFaction struct {
+	FactionID            int64   `json:"factionID"`
+	FactionName          string  `json:"factionName"`
+	Description          string  `json:"description"`
+	RaceIDs              []int64 `json:"raceIDs"` // Sorted member race IDs
+	SolarSystemID        *int64  `json:"solarSystemID,omitempty"`
+	CorporationID        *int64  `json:"corporationID,omitempty"`
+	SizeFactor           float64 `json:"sizeFactor"`
+	StationCount         int64   `json:"stationCount"`
+	StationSystemCount   int64   `json:"stationSystemCount"`
+	MilitiaCorporationID *int64  `json:"militiaCorporationID,omitempty"`
+	IconID               *int64  `json:"iconID,omitempty"`
+
+	// Names holds all SDE translations of FactionName.
+	Names LocalizedText `json:"-"`
+}
+
+// ChrRace represents a character race in Wanderer's format.
+// Fields match Fuzzwork CSV column order for chrRaces.csv.
+type ChrRace struct {
+	RaceID           int64  `json:"raceID"`
+	RaceName         string `json:"raceName"`
+	Description      string `json:"description"`
+	IconID           *int64 `json:"iconID,omitempty"`
+	ShortDescription string `json:"shortDescription"` // Not in SDE, use ""
+
+	// Names holds all SDE translations of RaceName.
+	Names LocalizedText `json:"-"`
+}
+
+// CrpNPCCorporation represents an NPC corporation in Wanderer's format.
+// Fields follow Fuzzwork crpNPCCorporations.csv without the investor and
+// location flag columns, with the corporation name and ticker appended.
+type CrpNPCCorporation struct {
+	CorporationID      int64   `json:"corporationID"`
+	Size               string  `json:"size"`
+	Extent             string  `json:"extent"`
+	SolarSystemID      *int64  `json:"solarSystemID,omitempty"`
+	FriendID           *int64  `json:"friendID,omitempty"`
+	EnemyID            *int64  `json:"enemyID,omitempty"`
+	PublicShares       int64   `json:"publicShares"`
+	InitialPrice       float64 `json:"initialPrice"`
+	MinSecurity        float64 `json:"minSecurity"`
+	FactionID          *int64  `json:"factionID,omitempty"`
+	SizeFactor         float64 `json:"sizeFactor"`
+	StationCount       int64   `json:"stationCount"`
+	StationSystemCount int64   `json:"stationSystemCount"`
+	Description        string  `json:"description"`
+	IconID             *int64  `json:"iconID,omitempty"`
+	CorporationName    string  `json:"corporationName"`
+	TickerName         string  `json:"tickerName"`
+
+	// Names holds all SDE translations of CorporationName.
+	Names LocalizedText `json:"-"`
+}
+
+// InvMarketGroup represents a market group in Wanderer's format.
+// Fields match Fuzzwork CSV column order for invMarketGroups.csv, followed by
+// the derived path columns.
+type InvMarketGroup struct {
+	MarketGroupID   int64  `json:"marketGroupID"`
+	ParentGroupID   *int64 `json:"parentGroupID,omitempty"` // Pointer to allow "None" in CSV
+	MarketGroupName string `json:"marketGroupName"`
+	Description     string `json:"description"`
+	IconID          *int64 `json:"iconID,omitempty"` // Pointer to allow "None" in CSV
+	HasTypes        bool   `json:"hasTypes"`
+
+	// MarketGroupPath lists the group IDs from the top-level group down to this
+	// group, separated by "/" (e.g. "4/9/1374"), so subtrees can be selected by prefix.
+	MarketGroupPath string `json:"marketGroupPath"`
+	// RootMarketGroupID is the top-level market group containing this group.
+	RootMarketGroupID int64 `json:"rootMarketGroupID"`
+
+	// Names and Descriptions hold all SDE translations of MarketGroupName and Description.
+	Names        LocalizedText `json:"-"`
+	Descriptions LocalizedText `json:"-"`
+}
+
+// MarketGroupNode is a market group with its child groups, used for the
+// nested invMarketGroupsTree JSON output.
+type MarketGroupNode struct {
+	MarketGroupID   int64             `json:"marketGroupID"`
+	MarketGroupName string            `json:"marketGroupName"`
+	IconID          *int64            `json:"iconID,omitempty"`
+	HasTypes        bool              `json:"hasTypes"`
+	Children        []MarketGroupNode `json:"children,omitempty"`
+}
+
+// DgmAttributeType represents a dogma attribute definition in Wanderer's format.
+// Fields match Fuzzwork CSV column order for dgmAttributeTypes.csv.
+type DgmAttributeType struct {
+	AttributeID   int64   `json:"attributeID"`
+	AttributeName string  `json:"attributeName"`
+	Description   string  `json:"description"`
+	IconID        *int64  `json:"iconID,omitempty"` // Pointer to allow "None" in CSV
+	DefaultValue  float64 `json:"defaultValue"`
+	Published     bool    `json:"published"`
+	DisplayName   string  `json:"displayName"`
+	UnitID        *int64  `json:"unitID,omitempty"` // Pointer to allow "None" in CSV
+	Stackable     bool    `json:"stackable"`
+	HighIsGood    bool    `json:"highIsGood"`
+	CategoryID    *int64  `json:"categoryID,omitempty"` // Pointer to allow "None" in CSV
+}
+
+// DgmEffect represents a dogma effect definition in Wanderer's format.
+// Fields follow Fuzzwork dgmEffects.csv without the expression, sfxName and
+// modifierInfo columns.
+type DgmEffect struct {
+	EffectID                       int64  `json:"effectID"`
+	EffectName                     string `json:"effectName"`
+	EffectCategory                 int64  `json:"effectCategory"`
+	Description                    string `json:"description"`
+	GUID                           string `json:"guid"`
+	IconID                         *int64 `json:"iconID,omitempty"`
+	IsOffensive                    bool   `json:"isOffensive"`
+	IsAssistance                   bool   `json:"isAssistance"`
+	DurationAttributeID            *int64 `json:"durationAttributeID,omitempty"`
+	TrackingSpeedAttributeID       *int64 `json:"trackingSpeedAttributeID,omitempty"`
+	DischargeAttributeID           *int64 `json:"dischargeAttributeID,omitempty"`
+	RangeAttributeID               *int64 `json:"rangeAttributeID,omitempty"`
+	FalloffAttributeID             *int64 `json:"falloffAttributeID,omitempty"`
+	DisallowAutoRepeat             bool   `json:"disallowAutoRepeat"`
+	Published                      bool   `json:"published"`
+	DisplayName                    string `json:"displayName"`
+	IsWarpSafe                     bool   `json:"isWarpSafe"`
+	RangeChance                    bool   `json:"rangeChance"`
+	ElectronicChance               bool   `json:"electronicChance"`
+	PropulsionChance               bool   `json:"propulsionChance"`
+	Distribution                   *int64 `json:"distribution,omitempty"`
+	NPCUsageChanceAttributeID      *int64 `json:"npcUsageChanceAttributeID,omitempty"`
+	NPCActivationChanceAttributeID *int64 `json:"npcActivationChanceAttributeID,omitempty"`
+	FittingUsageChanceAttributeID  *int64 `json:"fittingUsageChanceAttributeID,omitempty"`
+}
+
+// DgmTypeAttribute holds one dogma attribute value of a type.
+// Fields match Fuzzwork CSV column order for dgmTypeAttributes.csv.
+type DgmTypeAttribute struct {
+	TypeID      int64   `json:"typeID"`
+	AttributeID int64   `json:"attributeID"`
+	ValueInt    *int64  `json:"valueInt,omitempty"` // Not in SDE, always None
+	ValueFloat  float64 `json:"valueFloat"`
+}
+
+// DgmTypeEffect links a type to one of its dogma effects.
+// Fields match Fuzzwork CSV column order for dgmTypeEffects.csv.
+type DgmTypeEffect struct {
+	TypeID    int64 `json:"typeID"`
+	EffectID  int64 `json:"effectID"`
+	IsDefault bool  `json:"isDefault"`
+}
+
 // UniverseData holds all parsed universe data.
 type UniverseData struct {
 	Regions        []Region
@@ -142,6 +544,33 @@ type ConvertedData struct {
 	InvGroups       []InvGroup
 	WormholeClasses []WormholeClassLocation
 	SystemJumps     []SystemJump
+
+	// InvCategories holds the item categories whenever types or groups are
+	// converted. It is not written as a table.
+	InvCategories []InvCategory
+
+	SystemWormholeClasses []SystemWormholeClass
+	RegionJumps           []RegionJump
+	ConstellationJumps    []ConstellationJump
+	Stargates             []Stargate
+	SystemTopology        []SystemTopology
+	TopologyReport        *TopologyReport
+
+	Stations          []StaStation
+	StationServices   []StaService
+	OperationServices []StaOperationService
+
+	Factions     []ChrFaction
+	Races        []ChrRace
+	Corporations []CrpNPCCorporation
+
+	MarketGroups    []InvMarketGroup
+	MarketGroupTree []MarketGroupNode
+
+	DogmaAttributes []DgmAttributeType
+	DogmaEffects    []DgmEffect
+	TypeAttributes  []DgmTypeAttribute
+	TypeEffects     []DgmTypeEffect
 }
 
 // ShipTypes returns InvTypes for backward compatibility.
@@ -156,6 +585,15 @@ func (c *ConvertedData) ItemGroups() []ItemGroup {
 	return c.InvGroups
 }
 
+// SDEMetadata contains metadata about the SDE conversion.
+type SDEMetadata struct {
+	SDEVersion  string `json:"sde_version"`
+	ReleaseDate string `json:"release_date"`
+	GeneratedBy string `json:"generated_by"`
+	GeneratedAt string `json:"generated_at"`
+	Source      string `json:"source"`
+}
+
 // ValidationResult holds the results of data validation.
 type ValidationResult struct {
 	SolarSystems    int
@@ -165,6 +603,11 @@ type ValidationResult struct {
 	InvGroups       int
 	SystemJumps     int
 	WormholeClasses int
+	Stations        int
+	Factions        int
+	Corporations    int
+	MarketGroups    int
+	TypeAttributes  int
 	Errors          []string
 	Warnings        []string
 }
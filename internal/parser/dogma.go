@@ -0,0 +1,187 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/pkg/yaml"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// SDEText is a text field that the SDE writes either as a plain string or as
+// a map of translations keyed by language. Plain strings are stored as English.
+type SDEText map[string]string
+
+// UnmarshalYAML accepts both a scalar string and a {lang: text} mapping.
+func (t *SDEText) UnmarshalYAML(node *yamlv3.Node) error {
+	if node.Kind == yamlv3.ScalarNode {
+		*t = SDEText{models.DefaultLanguage: node.Value}
+		return nil
+	}
+	var translations map[string]string
+	if err := node.Decode(&translations); err != nil {
+		return err
+	}
+	*t = translations
+	return nil
+}
+
+// SDEDogmaAttribute represents a dogma attribute from dogmaAttributes.yaml.
+type SDEDogmaAttribute struct {
+	Name                string  `yaml:"name"`
+	Description         SDEText `yaml:"description,omitempty"`
+	DisplayName         SDEText `yaml:"displayName,omitempty"`
+	AttributeCategoryID int64   `yaml:"attributeCategoryID,omitempty"`
+	DefaultValue        float64 `yaml:"defaultValue,omitempty"`
+	IconID              int64   `yaml:"iconID,omitempty"`
+	UnitID              int64   `yaml:"unitID,omitempty"`
+	Published           bool    `yaml:"published,omitempty"`
+	Stackable           bool    `yaml:"stackable,omitempty"`
+	HighIsGood          bool    `yaml:"highIsGood,omitempty"`
+}
+
+// SDEDogmaEffect represents a dogma effect from dogmaEffects.yaml.
+type SDEDogmaEffect struct {
+	Name                           string  `yaml:"name"`
+	Description                    SDEText `yaml:"description,omitempty"`
+	DisplayName                    SDEText `yaml:"displayName,omitempty"`
+	EffectCategoryID               int64   `yaml:"effectCategoryID,omitempty"`
+	GUID                           string  `yaml:"guid,omitempty"`
+	IconID                         int64   `yaml:"iconID,omitempty"`
+	IsOffensive                    bool    `yaml:"isOffensive,omitempty"`
+	IsAssistance                   bool    `yaml:"isAssistance,omitempty"`
+	IsWarpSafe                     bool    `yaml:"isWarpSafe,omitempty"`
+	Published                      bool    `yaml:"published,omitempty"`
+	DisallowAutoRepeat             bool    `yaml:"disallowAutoRepeat,omitempty"`
+	DurationAttributeID            int64   `yaml:"durationAttributeID,omitempty"`
+	TrackingSpeedAttributeID       int64   `yaml:"trackingSpeedAttributeID,omitempty"`
+	DischargeAttributeID           int64   `yaml:"dischargeAttributeID,omitempty"`
+	RangeAttributeID               int64   `yaml:"rangeAttributeID,omitempty"`
+	FalloffAttributeID             int64   `yaml:"falloffAttributeID,omitempty"`
+	NPCUsageChanceAttributeID      int64   `yaml:"npcUsageChanceAttributeID,omitempty"`
+	NPCActivationChanceAttributeID int64   `yaml:"npcActivationChanceAttributeID,omitempty"`
+	FittingUsageChanceAttributeID  int64   `yaml:"fittingUsageChanceAttributeID,omitempty"`
+	RangeChance                    bool    `yaml:"rangeChance,omitempty"`
+	ElectronicChance               bool    `yaml:"electronicChance,omitempty"`
+	PropulsionChance               bool    `yaml:"propulsionChance,omitempty"`
+	Distribution                   int64   `yaml:"distribution,omitempty"`
+}
+
+// SDETypeDogma represents the dogma attributes and effects of one type in typeDogma.yaml.
+type SDETypeDogma struct {
+	DogmaAttributes []struct {
+		AttributeID int64   `yaml:"attributeID"`
+		Value       float64 `yaml:"value"`
+	} `yaml:"dogmaAttributes,omitempty"`
+	DogmaEffects []struct {
+		EffectID  int64 `yaml:"effectID"`
+		IsDefault bool  `yaml:"isDefault"`
+	} `yaml:"dogmaEffects,omitempty"`
+}
+
+// ParseDogmaAttributes streams the dogmaAttributes.yaml file.
+func (p *Parser) ParseDogmaAttributes() (map[int64]SDEDogmaAttribute, error) {
+	path := p.filePath("dogmaAttributes.yaml")
+
+	attributes := make(map[int64]SDEDogmaAttribute)
+	err := yaml.StreamFileMap(path, func(attributeID int64, attr SDEDogmaAttribute) error {
+		attributes[attributeID] = attr
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dogma attributes file: %w", err)
+	}
+
+	return attributes, nil
+}
+
+// ParseDogmaEffects streams the dogmaEffects.yaml file.
+func (p *Parser) ParseDogmaEffects() (map[int64]SDEDogmaEffect, error) {
+	path := p.filePath("dogmaEffects.yaml")
+
+	effects := make(map[int64]SDEDogmaEffect)
+	err := yaml.StreamFileMap(path, func(effectID int64, effect SDEDogmaEffect) error {
+		effects[effectID] = effect
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dogma effects file: %w", err)
+	}
+
+	return effects, nil
+}
+
+// TypeDogma holds the flattened rows of typeDogma.yaml.
+type TypeDogma struct {
+	Attributes []models.DgmTypeAttribute
+	Effects    []models.DgmTypeEffect
+}
+
+// dogmaTypeFilter returns the type IDs selected by Config.DogmaTypes using
+// models.FilterPublishedTypes and models.FilterShipTypes, or nil when every
+// type is kept. Filters combine, so "published,ships" keeps published ships.
+func (p *Parser) dogmaTypeFilter(types map[int64]models.SDEType, groups map[int64]models.SDEGroup) map[int64]bool {
+	filters := p.config.DogmaTypes
+	if len(filters) == 0 {
+		return nil
+	}
+
+	if slices.Contains(filters, config.TypeFilterPublished) {
+		types = models.FilterPublishedTypes(types)
+	}
+
+	allowed := make(map[int64]bool)
+	if slices.Contains(filters, config.TypeFilterShips) {
+		for _, ship := range models.FilterShipTypes(types, groups) {
+			allowed[ship.TypeID] = true
+		}
+		return allowed
+	}
+	for typeID := range types {
+		allowed[typeID] = true
+	}
+	return allowed
+}
+
+// ParseTypeDogma streams the typeDogma.yaml file, flattening each type's
+// attributes and effects into rows as it goes so the decoded file is never
+// held in memory at once. Only types in allowed are kept, or every type when
+// allowed is nil, and only the rows of enabled tables are collected.
+func (p *Parser) ParseTypeDogma(allowed map[int64]bool) (*TypeDogma, error) {
+	path := p.filePath("typeDogma.yaml")
+	attributes := p.config.TableEnabled(config.TableTypeAttributes)
+	effects := p.config.TableEnabled(config.TableTypeEffects)
+
+	result := &TypeDogma{}
+	err := yaml.StreamFileMap(path, func(typeID int64, dogma SDETypeDogma) error {
+		if allowed != nil && !allowed[typeID] {
+			return nil
+		}
+		if attributes {
+			for _, attr := range dogma.DogmaAttributes {
+				result.Attributes = append(result.Attributes, models.DgmTypeAttribute{
+					TypeID:      typeID,
+					AttributeID: attr.AttributeID,
+					ValueFloat:  attr.Value,
+				})
+			}
+		}
+		if effects {
+			for _, effect := range dogma.DogmaEffects {
+				result.Effects = append(result.Effects, models.DgmTypeEffect{
+					TypeID:    typeID,
+					EffectID:  effect.EffectID,
+					IsDefault: effect.IsDefault,
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse type dogma file: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,147 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+)
+
+func TestParser_ParseDogma(t *testing.T) {
+	tmpDir := createTestSDE(t)
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	files := map[string]string{
+		// Descriptions may be plain strings or translation maps
+		"dogmaAttributes.yaml": `552:
+  name: signatureRadius
+  description: Radius of the signature
+  displayName:
+    en: Signature Radius
+    de: Signaturradius
+  unitID: 1
+  published: true
+4:
+  name: mass
+  displayName:
+    en: Mass
+`,
+		"dogmaEffects.yaml": `11:
+  name: loPower
+  effectCategoryID: 0
+  guid: effects.LoPower
+  published: false
+`,
+		"typeDogma.yaml": `587:
+  dogmaAttributes:
+  - attributeID: 552
+    value: 35.0
+  - attributeID: 4
+    value: 1067000.0
+  dogmaEffects:
+  - effectID: 11
+    isDefault: false
+588:
+  dogmaAttributes:
+  - attributeID: 4
+    value: 1148000.0
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+
+	p := New(&config.Config{}, tmpDir)
+	result, err := p.ParseAll()
+	if err != nil {
+		t.Fatalf("ParseAll failed: %v", err)
+	}
+
+	sig := result.DogmaAttributes[552]
+	if sig.Name != "signatureRadius" || sig.Description["en"] != "Radius of the signature" {
+		t.Errorf("unexpected attribute: %+v", sig)
+	}
+	if sig.DisplayName["de"] != "Signaturradius" || !sig.Published || sig.UnitID != 1 {
+		t.Errorf("unexpected attribute fields: %+v", sig)
+	}
+	if result.DogmaEffects[11].GUID != "effects.LoPower" {
+		t.Errorf("unexpected effect: %+v", result.DogmaEffects[11])
+	}
+
+	if result.TypeDogma == nil {
+		t.Fatal("type dogma not parsed")
+	}
+	if len(result.TypeDogma.Attributes) != 3 || len(result.TypeDogma.Effects) != 1 {
+		t.Fatalf("expected 3 attribute rows and 1 effect row, got %d and %d",
+			len(result.TypeDogma.Attributes), len(result.TypeDogma.Effects))
+	}
+	first := result.TypeDogma.Attributes[0]
+	if first.TypeID != 587 || first.AttributeID != 552 || first.ValueFloat != 35 || first.ValueInt != nil {
+		t.Errorf("unexpected attribute row: %+v", first)
+	}
+}
+
+func TestParser_ParseTypeDogmaFiltered(t *testing.T) {
+	tmpDir := createTestSDE(t)
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	// 587 and 588 are published ships, 2456 is a published drone and 999 is unknown
+	typeDogma := `587:
+  dogmaAttributes:
+  - attributeID: 4
+    value: 1067000.0
+  dogmaEffects:
+  - effectID: 11
+    isDefault: false
+2456:
+  dogmaAttributes:
+  - attributeID: 4
+    value: 2500.0
+999:
+  dogmaAttributes:
+  - attributeID: 4
+    value: 1.0
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "typeDogma.yaml"), []byte(typeDogma), 0644); err != nil {
+		t.Fatalf("failed to create typeDogma.yaml: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		filters []string
+		want    []int64
+	}{
+		{"all types", nil, []int64{587, 2456, 999}},
+		{"published", []string{config.TypeFilterPublished}, []int64{587, 2456}},
+		{"ships", []string{config.TypeFilterShips}, []int64{587}},
+		{"published ships", []string{config.TypeFilterPublished, config.TypeFilterShips}, []int64{587}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{DogmaTypes: tt.filters, Tables: []string{config.TableTypeAttributes}}
+			result, err := New(cfg, tmpDir).ParseAll()
+			if err != nil {
+				t.Fatalf("ParseAll failed: %v", err)
+			}
+
+			var got []int64
+			for _, row := range result.TypeDogma.Attributes {
+				got = append(got, row.TypeID)
+			}
+			slices.Sort(got)
+			want := slices.Sorted(slices.Values(tt.want))
+			if !slices.Equal(got, want) {
+				t.Errorf("expected attribute rows of types %v, got %v", want, got)
+			}
+
+			// Effects are not collected when their table is disabled
+			if len(result.TypeDogma.Effects) != 0 {
+				t.Errorf("expected no effect rows, got %+v", result.TypeDogma.Effects)
+			}
+		})
+	}
+}
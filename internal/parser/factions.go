@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/guarzo/wanderer-sde/pkg/yaml"
+)
+
+// SDEFaction represents a faction from factions.yaml.
+type SDEFaction struct {
+	Name                 map[string]string `yaml:"name"`
+	Description          map[string]string `yaml:"description,omitempty"`
+	ShortDescription     map[string]string `yaml:"shortDescription,omitempty"`
+	CorporationID        int64             `yaml:"corporationID,omitempty"`
+	MilitiaCorporationID int64             `yaml:"militiaCorporationID,omitempty"`
+	SolarSystemID        int64             `yaml:"solarSystemID,omitempty"`
+	MemberRaces          []int64           `yaml:"memberRaces,omitempty"`
+	SizeFactor           float64           `yaml:"sizeFactor,omitempty"`
+	UniqueName           bool              `yaml:"uniqueName,omitempty"`
+	IconID               int64             `yaml:"iconID,omitempty"`
+}
+
+// SDERace represents a character race from races.yaml.
+type SDERace struct {
+	Name        map[string]string `yaml:"name"`
+	Description map[string]string `yaml:"description,omitempty"`
+	IconID      int64             `yaml:"iconID,omitempty"`
+	ShipTypeID  int64             `yaml:"shipTypeID,omitempty"`
+}
+
+// SDENPCCorporation represents an NPC corporation from npcCorporations.yaml.
+type SDENPCCorporation struct {
+	Name          map[string]string `yaml:"name"`
+	Description   map[string]string `yaml:"description,omitempty"`
+	TickerName    string            `yaml:"tickerName,omitempty"`
+	FactionID     int64             `yaml:"factionID,omitempty"`
+	RaceID        int64             `yaml:"raceID,omitempty"`
+	SolarSystemID int64             `yaml:"solarSystemID,omitempty"`
+	StationID     int64             `yaml:"stationID,omitempty"`
+	CeoID         int64             `yaml:"ceoID,omitempty"`
+	FriendID      int64             `yaml:"friendID,omitempty"`
+	EnemyID       int64             `yaml:"enemyID,omitempty"`
+	Size          string            `yaml:"size,omitempty"`
+	Extent        string            `yaml:"extent,omitempty"`
+	SizeFactor    float64           `yaml:"sizeFactor,omitempty"`
+	PublicShares  int64             `yaml:"publicShares,omitempty"`
+	InitialPrice  float64           `yaml:"initialPrice,omitempty"`
+	MinSecurity   float64           `yaml:"minSecurity,omitempty"`
+	IconID        int64             `yaml:"iconID,omitempty"`
+	Deleted       bool              `yaml:"deleted,omitempty"`
+}
+
+// ParseFactions parses the factions.yaml file.
+func (p *Parser) ParseFactions() (map[int64]SDEFaction, error) {
+	path := p.filePath("factions.yaml")
+
+	// Parse the file as a map of faction ID to faction data
+	factions, err := yaml.ParseFileMap[int64, SDEFaction](path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse factions file: %w", err)
+	}
+
+	return factions, nil
+}
+
+// ParseRaces parses the races.yaml file.
+func (p *Parser) ParseRaces() (map[int64]SDERace, error) {
+	path := p.filePath("races.yaml")
+
+	races, err := yaml.ParseFileMap[int64, SDERace](path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse races file: %w", err)
+	}
+
+	return races, nil
+}
+
+// ParseNPCCorporations parses the npcCorporations.yaml file.
+func (p *Parser) ParseNPCCorporations() (map[int64]SDENPCCorporation, error) {
+	path := p.filePath("npcCorporations.yaml")
+
+	corporations, err := yaml.ParseFileMap[int64, SDENPCCorporation](path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse NPC corporations file: %w", err)
+	}
+
+	return corporations, nil
+}
@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+)
+
+func TestParser_ParseFactionsAndRaces(t *testing.T) {
+	tmpDir := createTestSDE(t)
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	files := map[string]string{
+		"factions.yaml": `500001:
+  name:
+    en: "Caldari State"
+    de: "Staat der Caldari"
+  corporationID: 1000035
+  militiaCorporationID: 1000180
+  solarSystemID: 30000145
+  memberRaces: [1]
+  sizeFactor: 5.0
+  iconID: 1439
+`,
+		"races.yaml": `1:
+  name:
+    en: "Caldari"
+  iconID: 1439
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+
+	p := New(&config.Config{}, tmpDir)
+	result, err := p.ParseAll()
+	if err != nil {
+		t.Fatalf("ParseAll failed: %v", err)
+	}
+
+	faction, ok := result.Factions[500001]
+	if !ok {
+		t.Fatal("faction 500001 not found")
+	}
+	if faction.Name["de"] != "Staat der Caldari" || faction.CorporationID != 1000035 {
+		t.Errorf("unexpected faction: %+v", faction)
+	}
+	if len(faction.MemberRaces) != 1 || faction.MemberRaces[0] != 1 {
+		t.Errorf("unexpected member races: %v", faction.MemberRaces)
+	}
+	if result.Races[1].Name["en"] != "Caldari" {
+		t.Errorf("unexpected race: %+v", result.Races[1])
+	}
+
+	// Faction files are skipped when their tables are excluded
+	p = New(&config.Config{ExcludeTables: []string{config.TableFactions, config.TableRaces}}, tmpDir)
+	result, err = p.ParseAll()
+	if err != nil {
+		t.Fatalf("ParseAll failed: %v", err)
+	}
+	if result.Factions != nil || result.Races != nil {
+		t.Error("expected factions and races to be skipped")
+	}
+}
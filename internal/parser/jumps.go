@@ -19,12 +19,23 @@ type SDEMapStargate struct {
 	SolarSystemID int64                  `yaml:"solarSystemID"`
 	Destination   SDEStargateDestination `yaml:"destination"`
 	TypeID        int64                  `yaml:"typeID,omitempty"`
+	Position      *SDEPosition           `yaml:"position,omitempty"`
 }
 
 // ParseStargates parses the mapStargates.yaml file and extracts system jumps.
 // Both directions of each stargate connection are included (A→B and B→A)
 // to match Fuzzwork CSV format.
 func (p *Parser) ParseStargates() ([]models.SystemJump, error) {
+	gates, err := p.ParseStargateRows()
+	if err != nil {
+		return nil, err
+	}
+	return StargateJumps(gates), nil
+}
+
+// ParseStargateRows parses the mapStargates.yaml file into one row per stargate,
+// sorted by stargate ID.
+func (p *Parser) ParseStargateRows() ([]models.Stargate, error) {
 	path := p.filePath("mapStargates.yaml")
 
 	// Parse the file as a map of stargate ID to stargate data
@@ -33,12 +44,39 @@ func (p *Parser) ParseStargates() ([]models.SystemJump, error) {
 		return nil, fmt.Errorf("failed to parse stargates file: %w", err)
 	}
 
+	gates := make([]models.Stargate, 0, len(rawStargates))
+	for id, data := range rawStargates {
+		gate := models.Stargate{
+			StargateID:               id,
+			SolarSystemID:            data.SolarSystemID,
+			DestinationStargateID:    data.Destination.StargateID,
+			DestinationSolarSystemID: data.Destination.SolarSystemID,
+			TypeID:                   data.TypeID,
+		}
+		if data.Position != nil {
+			gate.X = models.Float64Ptr(data.Position.X)
+			gate.Y = models.Float64Ptr(data.Position.Y)
+			gate.Z = models.Float64Ptr(data.Position.Z)
+		}
+		gates = append(gates, gate)
+	}
+
+	// Sort by stargate ID for consistent output
+	sort.Slice(gates, func(i, j int) bool {
+		return gates[i].StargateID < gates[j].StargateID
+	})
+
+	return gates, nil
+}
+
+// StargateJumps reduces stargates to system jumps, one per direction.
+func StargateJumps(gates []models.Stargate) []models.SystemJump {
 	// Use a map to deduplicate identical A→B entries (but keep A→B and B→A as separate)
 	jumpSet := make(map[[2]int64]struct{})
 
-	for _, data := range rawStargates {
-		fromSystem := data.SolarSystemID
-		toSystem := data.Destination.SolarSystemID
+	for _, gate := range gates {
+		fromSystem := gate.SolarSystemID
+		toSystem := gate.DestinationSolarSystemID
 
 		if fromSystem == 0 || toSystem == 0 {
 			// Invalid data, skip
@@ -67,5 +105,5 @@ func (p *Parser) ParseStargates() ([]models.SystemJump, error) {
 		return jumps[i].ToSolarSystemID < jumps[j].ToSolarSystemID
 	})
 
-	return jumps, nil
+	return jumps
 }
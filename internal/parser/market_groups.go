@@ -0,0 +1,29 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/guarzo/wanderer-sde/pkg/yaml"
+)
+
+// SDEMarketGroup represents a market group from marketGroups.yaml.
+type SDEMarketGroup struct {
+	Name          map[string]string `yaml:"name"`
+	Description   map[string]string `yaml:"description,omitempty"`
+	ParentGroupID int64             `yaml:"parentGroupID,omitempty"`
+	IconID        int64             `yaml:"iconID,omitempty"`
+	HasTypes      bool              `yaml:"hasTypes"`
+}
+
+// ParseMarketGroups parses the marketGroups.yaml file.
+func (p *Parser) ParseMarketGroups() (map[int64]SDEMarketGroup, error) {
+	path := p.filePath("marketGroups.yaml")
+
+	// Parse the file as a map of market group ID to market group data
+	groups, err := yaml.ParseFileMap[int64, SDEMarketGroup](path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse market groups file: %w", err)
+	}
+
+	return groups, nil
+}
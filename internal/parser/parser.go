@@ -3,6 +3,7 @@ package parser
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/guarzo/wanderer-sde/internal/config"
@@ -23,6 +24,72 @@ func New(cfg *config.Config, sdePath string) *Parser {
 	}
 }
 
+// tableFiles lists the SDE files each output table depends on.
+// Systems need regions for faction inheritance, constellations for wormhole
+// class inheritance and stargates for the gate flags; regions and constellations
+// need systems for bounds; jumps need systems for region/constellation lookup.
+var tableFiles = map[string][]string{
+	config.TableSystems: {
+		"mapSolarSystems.yaml", "mapStars.yaml", "mapRegions.yaml", "mapConstellations.yaml",
+		"mapStargates.yaml",
+	},
+	config.TableRegions:         {"mapRegions.yaml", "mapSolarSystems.yaml"},
+	config.TableConstellations:  {"mapConstellations.yaml", "mapSolarSystems.yaml"},
+	config.TableWormholeClasses: {"mapRegions.yaml", "mapConstellations.yaml", "mapSolarSystems.yaml"},
+	config.TableSystemWormholeClasses: {
+		"mapRegions.yaml", "mapConstellations.yaml", "mapSolarSystems.yaml",
+	},
+	config.TableTypes:  {"types.yaml", "groups.yaml", "categories.yaml"},
+	config.TableGroups: {"groups.yaml", "categories.yaml"},
+	config.TableJumps:  {"mapStargates.yaml", "mapSolarSystems.yaml"},
+	// Region and constellation jumps are aggregated from the system jumps
+	config.TableRegionJumps:        {"mapStargates.yaml", "mapSolarSystems.yaml"},
+	config.TableConstellationJumps: {"mapStargates.yaml", "mapSolarSystems.yaml"},
+	config.TableStargates:          {"mapStargates.yaml"},
+	config.TableSystemTopology:     {"mapStargates.yaml", "mapSolarSystems.yaml"},
+	config.TableStations: {
+		"npcStations.yaml", "stationOperations.yaml", "npcCorporations.yaml",
+		"mapSolarSystems.yaml",
+	},
+	config.TableStationServices:   {"stationServices.yaml"},
+	config.TableOperationServices: {"stationOperations.yaml"},
+	// Faction and corporation station counts come from station owners.
+	config.TableFactions:        {"factions.yaml", "npcCorporations.yaml", "npcStations.yaml"},
+	config.TableRaces:           {"races.yaml"},
+	config.TableCorporations:    {"npcCorporations.yaml", "npcStations.yaml"},
+	config.TableMarketGroups:    {"marketGroups.yaml"},
+	config.TableDogmaAttributes: {"dogmaAttributes.yaml"},
+	config.TableDogmaEffects:    {"dogmaEffects.yaml"},
+	config.TableTypeAttributes:  {"typeDogma.yaml"},
+	config.TableTypeEffects:     {"typeDogma.yaml"},
+}
+
+// typeFilterFiles lists the SDE files needed to apply Config.DogmaTypes.
+var typeFilterFiles = []string{"types.yaml", "groups.yaml"}
+
+// needsFile reports whether any enabled table depends on the given SDE file.
+func (p *Parser) needsFile(filename string) bool {
+	for table, files := range tableFiles {
+		if !p.config.TableEnabled(table) {
+			continue
+		}
+		// Filtering per-type dogma rows needs the types and their groups
+		if len(p.config.DogmaTypes) > 0 && (table == config.TableTypeAttributes || table == config.TableTypeEffects) {
+			for _, f := range typeFilterFiles {
+				if f == filename {
+					return true
+				}
+			}
+		}
+		for _, f := range files {
+			if f == filename {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // ParseResult contains all parsed data from the SDE.
 type ParseResult struct {
 	Regions         []models.Region
@@ -33,6 +100,28 @@ type ParseResult struct {
 	Categories      map[int64]models.SDECategory
 	WormholeClasses []models.WormholeClassLocation
 	SystemJumps     []models.SystemJump
+	// Stargates is sorted by stargate ID and only kept when the stargates
+	// table is enabled.
+	Stargates []models.Stargate
+
+	// Station data is optional; older SDE builds may not include these files.
+	NPCStations       map[int64]SDENPCStation
+	StationOperations map[int64]SDEStationOperation
+	StationServices   map[int64]SDEStationService
+	NPCCorporations   map[int64]SDENPCCorporation
+
+	// Faction and race data is optional in the same way.
+	Factions map[int64]SDEFaction
+	Races    map[int64]SDERace
+
+	// MarketGroups is optional in the same way.
+	MarketGroups map[int64]SDEMarketGroup
+
+	// Dogma data is optional in the same way. TypeDogma is streamed,
+	// filtered by Config.DogmaTypes and flattened into rows while parsing.
+	DogmaAttributes map[int64]SDEDogmaAttribute
+	DogmaEffects    map[int64]SDEDogmaEffect
+	TypeDogma       *TypeDogma
 }
 
 // ParseAll parses all SDE files and returns the combined result.
@@ -40,110 +129,208 @@ func (p *Parser) ParseAll() (*ParseResult, error) {
 	result := &ParseResult{}
 
 	if p.config.Verbose {
-		fmt.Println("Parsing SDE files...")
+		p.config.Logf("Parsing SDE files...\n")
 	}
 
 	// Parse categories first (needed for filtering ships)
-	if p.config.Verbose {
-		fmt.Println("  Parsing categories...")
+	if p.needsFile("categories.yaml") {
+		if p.config.Verbose {
+			p.config.Logf("  Parsing categories...\n")
+		}
+		categories, err := p.ParseCategories()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse categories: %w", err)
+		}
+		result.Categories = categories
 	}
-	categories, err := p.ParseCategories()
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse categories: %w", err)
-	}
-	result.Categories = categories
 
 	// Parse groups (needed for filtering ships)
-	if p.config.Verbose {
-		fmt.Println("  Parsing groups...")
+	if p.needsFile("groups.yaml") {
+		if p.config.Verbose {
+			p.config.Logf("  Parsing groups...\n")
+		}
+		groups, err := p.ParseGroups()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse groups: %w", err)
+		}
+		result.Groups = groups
 	}
-	groups, err := p.ParseGroups()
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse groups: %w", err)
-	}
-	result.Groups = groups
 
 	// Parse types
-	if p.config.Verbose {
-		fmt.Println("  Parsing types...")
-	}
-	types, err := p.ParseTypes()
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse types: %w", err)
+	if p.needsFile("types.yaml") {
+		if p.config.Verbose {
+			p.config.Logf("  Parsing types...\n")
+		}
+		types, err := p.ParseTypes()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse types: %w", err)
+		}
+		result.Types = types
 	}
-	result.Types = types
 
 	// Parse regions
-	if p.config.Verbose {
-		fmt.Println("  Parsing regions...")
-	}
-	regions, err := p.ParseRegions()
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse regions: %w", err)
+	if p.needsFile("mapRegions.yaml") {
+		if p.config.Verbose {
+			p.config.Logf("  Parsing regions...\n")
+		}
+		regions, err := p.ParseRegions()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse regions: %w", err)
+		}
+		result.Regions = regions
 	}
-	result.Regions = regions
 
 	// Parse constellations
-	if p.config.Verbose {
-		fmt.Println("  Parsing constellations...")
-	}
-	constellations, err := p.ParseConstellations()
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse constellations: %w", err)
+	if p.needsFile("mapConstellations.yaml") {
+		if p.config.Verbose {
+			p.config.Logf("  Parsing constellations...\n")
+		}
+		constellations, err := p.ParseConstellations()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse constellations: %w", err)
+		}
+		result.Constellations = constellations
 	}
-	result.Constellations = constellations
 
 	// Parse stars (needed for solar system sun type resolution)
-	if p.config.Verbose {
-		fmt.Println("  Parsing stars...")
-	}
-	starTypeMap, err := p.ParseStars()
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse stars: %w", err)
+	var starTypeMap map[int64]int64
+	if p.needsFile("mapStars.yaml") {
+		if p.config.Verbose {
+			p.config.Logf("  Parsing stars...\n")
+		}
+		stars, err := p.ParseStars()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse stars: %w", err)
+		}
+		starTypeMap = stars
 	}
 
 	// Parse solar systems with star type lookup
-	if p.config.Verbose {
-		fmt.Println("  Parsing solar systems...")
+	if p.needsFile("mapSolarSystems.yaml") {
+		if p.config.Verbose {
+			p.config.Logf("  Parsing solar systems...\n")
+		}
+		systems, err := p.ParseSolarSystems(starTypeMap)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse solar systems: %w", err)
+		}
+		result.SolarSystems = systems
 	}
-	systems, err := p.ParseSolarSystems(starTypeMap)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse solar systems: %w", err)
-	}
-	result.SolarSystems = systems
 
 	// Parse stargates (system jumps)
-	if p.config.Verbose {
-		fmt.Println("  Parsing stargates...")
+	if p.needsFile("mapStargates.yaml") {
+		if p.config.Verbose {
+			p.config.Logf("  Parsing stargates...\n")
+		}
+		gates, err := p.ParseStargateRows()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse stargates: %w", err)
+		}
+		result.SystemJumps = StargateJumps(gates)
+		if p.config.TableEnabled(config.TableStargates) {
+			result.Stargates = gates
+		}
 	}
-	jumps, err := p.ParseStargates()
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse stargates: %w", err)
+
+	// Extract wormhole classes from regions, constellations, and systems.
+	// Solar systems need them to resolve their effective class.
+	if p.config.TableEnabled(config.TableWormholeClasses) || p.config.TableEnabled(config.TableSystems) ||
+		p.config.TableEnabled(config.TableSystemWormholeClasses) {
+		if p.config.Verbose {
+			p.config.Logf("  Extracting wormhole classes...\n")
+		}
+		wormholeClasses, err := p.ExtractAllWormholeClasses()
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract wormhole classes: %w", err)
+		}
+		result.WormholeClasses = wormholeClasses
 	}
-	result.SystemJumps = jumps
 
-	// Extract wormhole classes from regions, constellations, and systems
-	if p.config.Verbose {
-		fmt.Println("  Extracting wormhole classes...")
+	// Parse NPC stations with their operations, services and owning corporations
+	var err error
+	if result.NPCStations, err = parseOptional(p, "npcStations.yaml", "NPC stations", p.ParseNPCStations); err != nil {
+		return nil, err
 	}
-	wormholeClasses, err := p.ExtractAllWormholeClasses()
-	if err != nil {
-		return nil, fmt.Errorf("failed to extract wormhole classes: %w", err)
+	if result.StationOperations, err = parseOptional(p, "stationOperations.yaml", "station operations", p.ParseStationOperations); err != nil {
+		return nil, err
+	}
+	if result.StationServices, err = parseOptional(p, "stationServices.yaml", "station services", p.ParseStationServices); err != nil {
+		return nil, err
+	}
+	if result.NPCCorporations, err = parseOptional(p, "npcCorporations.yaml", "NPC corporations", p.ParseNPCCorporations); err != nil {
+		return nil, err
+	}
+
+	// Parse factions and races
+	if result.Factions, err = parseOptional(p, "factions.yaml", "factions", p.ParseFactions); err != nil {
+		return nil, err
+	}
+	if result.Races, err = parseOptional(p, "races.yaml", "races", p.ParseRaces); err != nil {
+		return nil, err
+	}
+
+	// Parse the market group hierarchy
+	if result.MarketGroups, err = parseOptional(p, "marketGroups.yaml", "market groups", p.ParseMarketGroups); err != nil {
+		return nil, err
+	}
+
+	// Stream dogma attributes, effects and per-type values
+	if result.DogmaAttributes, err = parseOptional(p, "dogmaAttributes.yaml", "dogma attributes", p.ParseDogmaAttributes); err != nil {
+		return nil, err
+	}
+	if result.DogmaEffects, err = parseOptional(p, "dogmaEffects.yaml", "dogma effects", p.ParseDogmaEffects); err != nil {
+		return nil, err
+	}
+	// Per-type rows are filtered while streaming so unwanted types are never held
+	allowed := p.dogmaTypeFilter(result.Types, result.Groups)
+	parseTypeDogma := func() (*TypeDogma, error) { return p.ParseTypeDogma(allowed) }
+	if result.TypeDogma, err = parseOptional(p, "typeDogma.yaml", "type dogma", parseTypeDogma); err != nil {
+		return nil, err
 	}
-	result.WormholeClasses = wormholeClasses
 
 	if p.config.Verbose {
-		fmt.Printf("Parsing complete:\n")
-		fmt.Printf("  Regions:        %d\n", len(result.Regions))
-		fmt.Printf("  Constellations: %d\n", len(result.Constellations))
-		fmt.Printf("  Solar Systems:  %d\n", len(result.SolarSystems))
-		fmt.Printf("  Types:          %d\n", len(result.Types))
-		fmt.Printf("  Groups:         %d\n", len(result.Groups))
-		fmt.Printf("  Categories:     %d\n", len(result.Categories))
-		fmt.Printf("  Wormhole Classes: %d\n", len(result.WormholeClasses))
-		fmt.Printf("  System Jumps:   %d\n", len(result.SystemJumps))
+		p.config.Logf("Parsing complete:\n")
+		p.config.Logf("  Regions:        %d\n", len(result.Regions))
+		p.config.Logf("  Constellations: %d\n", len(result.Constellations))
+		p.config.Logf("  Solar Systems:  %d\n", len(result.SolarSystems))
+		p.config.Logf("  Types:          %d\n", len(result.Types))
+		p.config.Logf("  Groups:         %d\n", len(result.Groups))
+		p.config.Logf("  Categories:     %d\n", len(result.Categories))
+		p.config.Logf("  Wormhole Classes: %d\n", len(result.WormholeClasses))
+		p.config.Logf("  System Jumps:   %d\n", len(result.SystemJumps))
+		p.config.Logf("  NPC Stations:   %d\n", len(result.NPCStations))
+		p.config.Logf("  Factions:       %d\n", len(result.Factions))
+		p.config.Logf("  Races:          %d\n", len(result.Races))
+		p.config.Logf("  Corporations:   %d\n", len(result.NPCCorporations))
+		p.config.Logf("  Market Groups:  %d\n", len(result.MarketGroups))
+		p.config.Logf("  Dogma Attributes: %d\n", len(result.DogmaAttributes))
+		p.config.Logf("  Dogma Effects:  %d\n", len(result.DogmaEffects))
+	}
+
+	return result, nil
+}
+
+// parseOptional runs parse when an enabled table needs filename and the file exists.
+// Missing optional files yield a nil result so older SDE builds still convert.
+func parseOptional[V any](p *Parser, filename, label string, parse func() (V, error)) (V, error) {
+	var zero V
+	if !p.needsFile(filename) {
+		return zero, nil
+	}
+	if _, err := os.Stat(p.filePath(filename)); os.IsNotExist(err) {
+		if p.config.Verbose {
+			p.config.Logf("  Skipping %s (%s not found)\n", label, filename)
+		}
+		return zero, nil
 	}
 
+	if p.config.Verbose {
+		p.config.Logf("  Parsing %s...\n", label)
+	}
+	result, err := parse()
+	if err != nil {
+		return zero, fmt.Errorf("failed to parse %s: %w", label, err)
+	}
 	return result, nil
 }
 
@@ -137,6 +137,10 @@ func createTestSDE(t *testing.T) string {
     solarSystemID: 30000001
     stargateID: 50000002
   typeID: 16
+  position:
+    x: 1.5e12
+    y: -2.0e10
+    z: 3.25e11
 50000002:
   solarSystemID: 30000001
   destination:
@@ -291,6 +295,11 @@ func TestParser_ParseRegions(t *testing.T) {
 	if regions[0].RegionName != "Derelik" {
 		t.Errorf("Expected first region name to be 'Derelik', got %q", regions[0].RegionName)
 	}
+
+	// Translations are kept for multi-language output
+	if regions[0].Names["en"] != "Derelik" {
+		t.Errorf("Expected English translation 'Derelik', got %q", regions[0].Names["en"])
+	}
 }
 
 func TestParser_ParseConstellations(t *testing.T) {
@@ -499,6 +508,40 @@ func TestParser_ParseStargates(t *testing.T) {
 	}
 }
 
+func TestParser_ParseStargateRows(t *testing.T) {
+	tmpDir := createTestSDE(t)
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	cfg := &config.Config{Verbose: false}
+	p := New(cfg, tmpDir)
+
+	gates, err := p.ParseStargateRows()
+	if err != nil {
+		t.Fatalf("ParseStargateRows failed: %v", err)
+	}
+
+	if len(gates) != 2 {
+		t.Fatalf("Expected 2 stargates, got %d", len(gates))
+	}
+
+	jita := gates[0]
+	if jita.StargateID != 50000001 || jita.SolarSystemID != 30000142 ||
+		jita.DestinationStargateID != 50000002 || jita.DestinationSolarSystemID != 30000001 || jita.TypeID != 16 {
+		t.Errorf("Unexpected Jita gate: %+v", jita)
+	}
+	if jita.X == nil || *jita.X != 1.5e12 || jita.Y == nil || *jita.Y != -2.0e10 || jita.Z == nil || *jita.Z != 3.25e11 {
+		t.Errorf("Expected Jita gate position (1.5e12, -2e10, 3.25e11), got (%v, %v, %v)", jita.X, jita.Y, jita.Z)
+	}
+
+	// The Tanoo gate has no position in the fixture
+	if gates[1].X != nil || gates[1].Y != nil || gates[1].Z != nil {
+		t.Errorf("Expected no position for gate without one, got %+v", gates[1])
+	}
+	if got := gates[1].ToCSVRow(); got[5] != "None" {
+		t.Errorf("Expected None for missing x, got %q", got[5])
+	}
+}
+
 func TestParser_ParseTypes(t *testing.T) {
 	tmpDir := createTestSDE(t)
 	defer func() { _ = os.RemoveAll(tmpDir) }()
@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/guarzo/wanderer-sde/pkg/yaml"
+)
+
+// SDENPCStation represents an NPC station from npcStations.yaml.
+type SDENPCStation struct {
+	SolarSystemID            int64        `yaml:"solarSystemID"`
+	OperationID              int64        `yaml:"operationID"`
+	OwnerID                  int64        `yaml:"ownerID"`
+	TypeID                   int64        `yaml:"typeID"`
+	OrbitID                  int64        `yaml:"orbitID,omitempty"`
+	CelestialIndex           int64        `yaml:"celestialIndex,omitempty"`
+	OrbitIndex               int64        `yaml:"orbitIndex,omitempty"`
+	UseOperationName         bool         `yaml:"useOperationName,omitempty"`
+	Position                 *SDEPosition `yaml:"position,omitempty"`
+	ReprocessingEfficiency   float64      `yaml:"reprocessingEfficiency,omitempty"`
+	ReprocessingStationsTake float64      `yaml:"reprocessingStationsTake,omitempty"`
+	ReprocessingHangarFlag   int64        `yaml:"reprocessingHangarFlag,omitempty"`
+}
+
+// SDEStationOperation represents a station operation from stationOperations.yaml.
+type SDEStationOperation struct {
+	OperationName map[string]string `yaml:"operationName"`
+	Description   map[string]string `yaml:"description,omitempty"`
+	ActivityID    int64             `yaml:"activityID,omitempty"`
+	Services      []int64           `yaml:"services,omitempty"`
+	StationTypes  map[int64]int64   `yaml:"stationTypes,omitempty"`
+}
+
+// SDEStationService represents a station service from stationServices.yaml.
+type SDEStationService struct {
+	ServiceName map[string]string `yaml:"serviceName"`
+	Description map[string]string `yaml:"description,omitempty"`
+}
+
+// ParseNPCStations parses the npcStations.yaml file.
+func (p *Parser) ParseNPCStations() (map[int64]SDENPCStation, error) {
+	path := p.filePath("npcStations.yaml")
+
+	// Parse the file as a map of station ID to station data
+	stations, err := yaml.ParseFileMap[int64, SDENPCStation](path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse NPC stations file: %w", err)
+	}
+
+	return stations, nil
+}
+
+// ParseStationOperations parses the stationOperations.yaml file.
+func (p *Parser) ParseStationOperations() (map[int64]SDEStationOperation, error) {
+	path := p.filePath("stationOperations.yaml")
+
+	operations, err := yaml.ParseFileMap[int64, SDEStationOperation](path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse station operations file: %w", err)
+	}
+
+	return operations, nil
+}
+
+// ParseStationServices parses the stationServices.yaml file.
+func (p *Parser) ParseStationServices() (map[int64]SDEStationService, error) {
+	path := p.filePath("stationServices.yaml")
+
+	services, err := yaml.ParseFileMap[int64, SDEStationService](path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse station services file: %w", err)
+	}
+
+	return services, nil
+}
@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+)
+
+// writeStationFiles adds the NPC station files to a test SDE directory.
+func writeStationFiles(t *testing.T, dir string) {
+	t.Helper()
+
+	files := map[string]string{
+		"npcStations.yaml": `60003760:
+  solarSystemID: 30000142
+  operationID: 26
+  ownerID: 1000035
+  typeID: 52678
+  orbitID: 40009081
+  celestialIndex: 4
+  orbitIndex: 4
+  useOperationName: true
+  position:
+    x: -107302625280
+    y: -18745221120
+    z: 436489789440
+  reprocessingEfficiency: 0.5
+  reprocessingStationsTake: 0.05
+  reprocessingHangarFlag: 4
+`,
+		"stationOperations.yaml": `26:
+  activityID: 3
+  operationName:
+    en: "Assembly Plant"
+  services: [5, 16]
+`,
+		"stationServices.yaml": `5:
+  serviceName:
+    en: "Reprocessing Plant"
+16:
+  serviceName:
+    en: "Market"
+`,
+		"npcCorporations.yaml": `1000035:
+  name:
+    en: "Caldari Navy"
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+	}
+}
+
+func TestParser_ParseNPCStations(t *testing.T) {
+	tmpDir := createTestSDE(t)
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	writeStationFiles(t, tmpDir)
+
+	p := New(&config.Config{}, tmpDir)
+	stations, err := p.ParseNPCStations()
+	if err != nil {
+		t.Fatalf("ParseNPCStations failed: %v", err)
+	}
+
+	station, ok := stations[60003760]
+	if !ok {
+		t.Fatal("station 60003760 not found")
+	}
+	if station.SolarSystemID != 30000142 || station.OwnerID != 1000035 || station.OperationID != 26 {
+		t.Errorf("unexpected station fields: %+v", station)
+	}
+	if station.CelestialIndex != 4 || station.OrbitIndex != 4 || !station.UseOperationName {
+		t.Errorf("unexpected orbit fields: %+v", station)
+	}
+	if station.Position == nil || station.Position.X != -107302625280 {
+		t.Errorf("unexpected position: %+v", station.Position)
+	}
+}
+
+func TestParser_ParseAllStations(t *testing.T) {
+	tmpDir := createTestSDE(t)
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	// Without station files the tables are skipped rather than failing
+	p := New(&config.Config{}, tmpDir)
+	result, err := p.ParseAll()
+	if err != nil {
+		t.Fatalf("ParseAll without station files failed: %v", err)
+	}
+	if result.NPCStations != nil {
+		t.Errorf("expected no stations, got %d", len(result.NPCStations))
+	}
+
+	writeStationFiles(t, tmpDir)
+	result, err = p.ParseAll()
+	if err != nil {
+		t.Fatalf("ParseAll failed: %v", err)
+	}
+	if len(result.NPCStations) != 1 {
+		t.Errorf("expected 1 station, got %d", len(result.NPCStations))
+	}
+	if got := result.StationOperations[26].Services; len(got) != 2 {
+		t.Errorf("expected 2 operation services, got %v", got)
+	}
+	if got := result.StationServices[16].ServiceName["en"]; got != "Market" {
+		t.Errorf("expected service name Market, got %q", got)
+	}
+	if got := result.NPCCorporations[1000035].Name["en"]; got != "Caldari Navy" {
+		t.Errorf("expected corporation name Caldari Navy, got %q", got)
+	}
+}
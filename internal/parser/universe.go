@@ -84,7 +84,8 @@ func (p *Parser) ParseRegions() ([]models.Region, error) {
 			RegionName: name,
 			FactionID:  models.Int64Ptr(data.FactionID),
 			Nebula:     data.NebulaID,
-			Radius:     0, // Not directly available in new SDE format
+			Radius:     0, // Not in the SDE, derived from the systems by the transformer
+			Names:      data.Name,
 		}
 
 		// Extract coordinates from position object
@@ -128,6 +129,7 @@ func (p *Parser) ParseConstellations() ([]models.Constellation, error) {
 			ConstellationName: name,
 			FactionID:         models.Int64Ptr(data.FactionID),
 			Radius:            data.Radius,
+			Names:             data.Name,
 		}
 
 		// Extract coordinates from position object
@@ -192,6 +194,7 @@ func (p *Parser) ParseSolarSystems(starTypeMap map[int64]int64) ([]models.SolarS
 			Radius:          data.Radius,
 			SunTypeID:       sunTypeID,
 			SecurityClass:   data.SecurityClass,
+			Names:           data.Name,
 		}
 
 		// Extract coordinates from position object
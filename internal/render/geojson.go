@@ -0,0 +1,79 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/guarzo/wanderer-sde/internal/transformer"
+)
+
+// featureCollection is a GeoJSON FeatureCollection.
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+// feature is a GeoJSON Feature.
+type feature struct {
+	Type       string         `json:"type"`
+	ID         int64          `json:"id,omitempty"`
+	Geometry   geometry       `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// geometry is a GeoJSON Point or LineString.
+type geometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+// WriteGeoJSON writes m as a GeoJSON FeatureCollection in projected
+// coordinates (light-years, x east and y north; not longitude and latitude).
+// Each system is a Point feature identified by its ID, and each gate a
+// LineString feature; the "kind" property tells them apart.
+func WriteGeoJSON(w io.Writer, m *Map) error {
+	collection := featureCollection{Type: "FeatureCollection", Features: []feature{}}
+
+	positions := make(map[int64][2]float64, len(m.Systems))
+	for _, s := range m.Systems {
+		x, y := projected(s)
+		positions[s.SolarSystemID] = [2]float64{x, y}
+		collection.Features = append(collection.Features, feature{
+			Type:     "Feature",
+			ID:       s.SolarSystemID,
+			Geometry: geometry{Type: "Point", Coordinates: [2]float64{x, y}},
+			Properties: map[string]any{
+				"kind":            "system",
+				"solarSystemID":   s.SolarSystemID,
+				"solarSystemName": s.SolarSystemName,
+				"regionID":        s.RegionID,
+				"constellationID": s.ConstellationID,
+				"security":        s.Security,
+				"displaySecurity": transformer.DisplaySecurity(s.Security),
+				"securityBand":    transformer.SecurityBand(s.SolarSystemID, s.RegionID, s.Security),
+				"color":           SecurityColor(s.Security),
+			},
+		})
+	}
+
+	for _, g := range m.Gates {
+		collection.Features = append(collection.Features, feature{
+			Type: "Feature",
+			Geometry: geometry{
+				Type:        "LineString",
+				Coordinates: [][2]float64{positions[g.FromSolarSystemID], positions[g.ToSolarSystemID]},
+			},
+			Properties: map[string]any{
+				"kind":              "gate",
+				"fromSolarSystemID": g.FromSolarSystemID,
+				"toSolarSystemID":   g.ToSolarSystemID,
+			},
+		})
+	}
+
+	if err := json.NewEncoder(w).Encode(collection); err != nil {
+		return fmt.Errorf("failed to encode GeoJSON: %w", err)
+	}
+	return nil
+}
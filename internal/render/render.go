@@ -0,0 +1,93 @@
+// Package render draws 2D maps of the universe from the converted map data,
+// projected onto the X/Z plane as in the in-game map.
+package render
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/transformer"
+)
+
+// Gate is an undirected stargate connection between two solar systems.
+type Gate struct {
+	FromSolarSystemID int64
+	ToSolarSystemID   int64
+}
+
+// Gates merges jumps into undirected gates, with the lower system ID first.
+func Gates(jumps []models.SystemJump) []Gate {
+	seen := make(map[Gate]bool)
+	var gates []Gate
+	for _, j := range jumps {
+		g := Gate{min(j.FromSolarSystemID, j.ToSolarSystemID), max(j.FromSolarSystemID, j.ToSolarSystemID)}
+		if g.FromSolarSystemID == g.ToSolarSystemID || seen[g] {
+			continue
+		}
+		seen[g] = true
+		gates = append(gates, g)
+	}
+	slices.SortFunc(gates, func(a, b Gate) int {
+		return cmp.Or(cmp.Compare(a.FromSolarSystemID, b.FromSolarSystemID), cmp.Compare(a.ToSolarSystemID, b.ToSolarSystemID))
+	})
+	return gates
+}
+
+// Map is a set of solar systems and the gates between them to draw.
+type Map struct {
+	Title   string
+	Systems []models.SolarSystem
+	Gates   []Gate
+	// Labels writes each system's name next to it.
+	Labels bool
+}
+
+// NewMap selects the systems for which keep returns true and the gates with
+// both ends among them.
+func NewMap(title string, systems []models.SolarSystem, gates []Gate, keep func(models.SolarSystem) bool) *Map {
+	m := &Map{Title: title}
+	ids := make(map[int64]bool)
+	for _, s := range systems {
+		if keep(s) {
+			m.Systems = append(m.Systems, s)
+			ids[s.SolarSystemID] = true
+		}
+	}
+	for _, g := range gates {
+		if ids[g.FromSolarSystemID] && ids[g.ToSolarSystemID] {
+			m.Gates = append(m.Gates, g)
+		}
+	}
+	return m
+}
+
+// KnownSpace reports whether a system lies in the connected known-space map,
+// rather than in wormhole or Abyssal space with coordinates of their own.
+func KnownSpace(s models.SolarSystem) bool {
+	return !transformer.IsWormholeSystemID(s.SolarSystemID) && !transformer.IsAbyssalSystemID(s.SolarSystemID)
+}
+
+// securityColors are the in-game map colours for display security 1.0 down to 0.1.
+var securityColors = []string{
+	"#2c74e0", "#3a9aeb", "#4ecef8", "#60dba3", "#71e554",
+	"#f3fd82", "#dc6d07", "#ce440f", "#bb1116", "#731f1f",
+}
+
+// nullsecColor is the in-game map colour for display security 0.0 and below.
+const nullsecColor = "#8d3163"
+
+// SecurityColor returns the in-game map colour for a raw security status.
+func SecurityColor(security float64) string {
+	display := transformer.DisplaySecurity(security)
+	if display <= 0 {
+		return nullsecColor
+	}
+	step := int(10 - display*10 + 0.5)
+	return securityColors[max(step, 0)]
+}
+
+// projected returns the projected position of a system.
+func projected(s models.SolarSystem) (float64, float64) {
+	return transformer.ProjectXZ(s.X, s.Z)
+}
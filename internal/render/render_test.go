@@ -0,0 +1,154 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/transformer"
+)
+
+// testSystems places 1 in the west, 2 in the east and 3 north of 2, ten
+// light-years apart, and 31000001 in wormhole space.
+func testSystems() []models.SolarSystem {
+	ly := transformer.LightYear
+	return []models.SolarSystem{
+		{RegionID: 1, SolarSystemID: 1, SolarSystemName: "West", Security: 0.9459, X: -10 * ly},
+		{RegionID: 1, SolarSystemID: 2, SolarSystemName: "East", Security: 0.45, X: 10 * ly, Y: 5 * ly},
+		{RegionID: 2, SolarSystemID: 3, SolarSystemName: "North & Co", Security: -0.2, X: 10 * ly, Z: 10 * ly},
+		{RegionID: 3, SolarSystemID: 31000001, SolarSystemName: "J100001", Security: -1},
+	}
+}
+
+func testJumps() []models.SystemJump {
+	return []models.SystemJump{
+		{FromSolarSystemID: 1, ToSolarSystemID: 2},
+		{FromSolarSystemID: 2, ToSolarSystemID: 1},
+		{FromSolarSystemID: 3, ToSolarSystemID: 2},
+		{FromSolarSystemID: 2, ToSolarSystemID: 3},
+		{FromSolarSystemID: 3, ToSolarSystemID: 999},
+	}
+}
+
+func TestGates(t *testing.T) {
+	gates := Gates(testJumps())
+	want := []Gate{{1, 2}, {2, 3}, {3, 999}}
+	if len(gates) != len(want) {
+		t.Fatalf("Gates = %v, want %v", gates, want)
+	}
+	for i := range want {
+		if gates[i] != want[i] {
+			t.Errorf("gate %d = %v, want %v", i, gates[i], want[i])
+		}
+	}
+}
+
+func TestNewMap(t *testing.T) {
+	m := NewMap("Known", testSystems(), Gates(testJumps()), KnownSpace)
+	if len(m.Systems) != 3 {
+		t.Errorf("expected 3 known-space systems, got %d", len(m.Systems))
+	}
+	// The gate to the unknown system 999 is dropped
+	if len(m.Gates) != 2 {
+		t.Errorf("expected 2 gates, got %v", m.Gates)
+	}
+
+	m = NewMap("Region 1", testSystems(), Gates(testJumps()), func(s models.SolarSystem) bool { return s.RegionID == 1 })
+	if len(m.Systems) != 2 || len(m.Gates) != 1 {
+		t.Errorf("expected 2 systems and 1 gate in region 1, got %d and %v", len(m.Systems), m.Gates)
+	}
+}
+
+func TestSecurityColor(t *testing.T) {
+	tests := []struct {
+		security float64
+		want     string
+	}{
+		{1.0, "#2c74e0"},
+		{0.9459, "#3a9aeb"},
+		{0.45, "#f3fd82"}, // shown as 0.5
+		{0.1, "#731f1f"},
+		{0.04, "#731f1f"}, // shown as 0.1
+		{0.0, nullsecColor},
+		{-0.7, nullsecColor},
+	}
+	for _, tt := range tests {
+		if got := SecurityColor(tt.security); got != tt.want {
+			t.Errorf("SecurityColor(%v) = %s, want %s", tt.security, got, tt.want)
+		}
+	}
+}
+
+func TestWriteSVG(t *testing.T) {
+	m := NewMap("Known & more", testSystems(), Gates(testJumps()), KnownSpace)
+	m.Labels = true
+
+	var buf bytes.Buffer
+	if err := WriteSVG(&buf, m, 240); err != nil {
+		t.Fatalf("WriteSVG failed: %v", err)
+	}
+	if err := xml.Unmarshal(buf.Bytes(), new(struct{})); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+
+	out := buf.String()
+	// 20 light-years across fit into 240-2*20 pixels, keeping the aspect; north (z) is up
+	for _, want := range []string{
+		`width="240" height="140"`,
+		`<circle id="s1" cx="20.0" cy="120.0" r="4" fill="#3a9aeb"><title>West (0.9)</title></circle>`,
+		`<circle id="s3" cx="220.0" cy="20.0" r="4" fill="#8d3163"><title>North &amp; Co (-0.2)</title></circle>`,
+		`<line x1="20.0" y1="120.0" x2="220.0" y2="120.0"/>`,
+		`<text x="226.0" y="23.0">North &amp; Co</text>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("SVG missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteGeoJSON(t *testing.T) {
+	m := NewMap("All", testSystems(), Gates(testJumps()), func(models.SolarSystem) bool { return true })
+
+	var buf bytes.Buffer
+	if err := WriteGeoJSON(&buf, m); err != nil {
+		t.Fatalf("WriteGeoJSON failed: %v", err)
+	}
+
+	var collection struct {
+		Type     string `json:"type"`
+		Features []struct {
+			ID       int64 `json:"id"`
+			Geometry struct {
+				Type        string          `json:"type"`
+				Coordinates json.RawMessage `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]any `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &collection); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if collection.Type != "FeatureCollection" || len(collection.Features) != 6 {
+		t.Fatalf("expected a FeatureCollection of 4 systems and 2 gates, got %s with %d features",
+			collection.Type, len(collection.Features))
+	}
+
+	north := collection.Features[2]
+	if north.ID != 3 || north.Geometry.Type != "Point" || string(north.Geometry.Coordinates) != "[10,10]" {
+		t.Errorf("unexpected system feature: %+v", north)
+	}
+	if north.Properties["securityBand"] != models.SecurityBandNullsec || north.Properties["displaySecurity"] != -0.2 {
+		t.Errorf("unexpected system properties: %v", north.Properties)
+	}
+
+	gate := collection.Features[5]
+	if gate.Geometry.Type != "LineString" || string(gate.Geometry.Coordinates) != "[[10,0],[10,10]]" {
+		t.Errorf("unexpected gate feature: %+v", gate)
+	}
+	if gate.Properties["kind"] != "gate" {
+		t.Errorf("unexpected gate properties: %v", gate.Properties)
+	}
+}
@@ -0,0 +1,116 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"math"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/transformer"
+)
+
+// svgMargin is the blank border around the drawing, in pixels.
+const svgMargin = 20
+
+// Map colours, close to the in-game star map.
+const (
+	svgBackground = "#0b0f14"
+	svgGateColor  = "#3c4650"
+	svgLabelColor = "#c8d0d8"
+)
+
+// viewport maps projected coordinates onto SVG pixels, with north up.
+type viewport struct {
+	minX, maxY    float64
+	scale         float64
+	width, height float64
+}
+
+// newViewport fits the systems into size pixels along the longer side.
+func newViewport(systems []models.SolarSystem, size int) viewport {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, s := range systems {
+		x, y := projected(s)
+		minX, maxX = min(minX, x), max(maxX, x)
+		minY, maxY = min(minY, y), max(maxY, y)
+	}
+	if len(systems) == 0 {
+		minX, maxX, minY, maxY = 0, 0, 0, 0
+	}
+
+	span := max(maxX-minX, maxY-minY)
+	scale := 1.0
+	if span > 0 {
+		scale = float64(size-2*svgMargin) / span
+	}
+	return viewport{
+		minX:   minX,
+		maxY:   maxY,
+		scale:  scale,
+		width:  (maxX-minX)*scale + 2*svgMargin,
+		height: (maxY-minY)*scale + 2*svgMargin,
+	}
+}
+
+// point returns the pixel position of a system.
+func (v viewport) point(s models.SolarSystem) (float64, float64) {
+	x, y := projected(s)
+	return svgMargin + (x-v.minX)*v.scale, svgMargin + (v.maxY-y)*v.scale
+}
+
+// WriteSVG draws m as an SVG image whose longer side is size pixels, with
+// systems coloured by security and gates as lines. Hovering a system shows its
+// name and security.
+func WriteSVG(w io.Writer, m *Map, size int) error {
+	v := newViewport(m.Systems, size)
+	radius := 2.0
+	if m.Labels {
+		radius = 4.0
+	}
+
+	positions := make(map[int64][2]float64, len(m.Systems))
+	for _, s := range m.Systems {
+		x, y := v.point(s)
+		positions[s.SolarSystemID] = [2]float64{x, y}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f">`+"\n",
+		v.width, v.height, v.width, v.height)
+	fmt.Fprintf(bw, "  <title>%s</title>\n", html.EscapeString(m.Title))
+	fmt.Fprintf(bw, `  <rect width="100%%" height="100%%" fill="%s"/>`+"\n", svgBackground)
+
+	fmt.Fprintf(bw, `  <g id="gates" stroke="%s" stroke-width="1">`+"\n", svgGateColor)
+	for _, g := range m.Gates {
+		from, to := positions[g.FromSolarSystemID], positions[g.ToSolarSystemID]
+		fmt.Fprintf(bw, `    <line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f"/>`+"\n", from[0], from[1], to[0], to[1])
+	}
+	fmt.Fprintln(bw, "  </g>")
+
+	fmt.Fprintln(bw, `  <g id="systems">`)
+	for _, s := range m.Systems {
+		p := positions[s.SolarSystemID]
+		fmt.Fprintf(bw, `    <circle id="s%d" cx="%.1f" cy="%.1f" r="%.0f" fill="%s"><title>%s (%s)</title></circle>`+"\n",
+			s.SolarSystemID, p[0], p[1], radius, SecurityColor(s.Security),
+			html.EscapeString(s.SolarSystemName), models.FormatSecurity(transformer.DisplaySecurity(s.Security)))
+	}
+	fmt.Fprintln(bw, "  </g>")
+
+	if m.Labels {
+		fmt.Fprintf(bw, `  <g id="labels" fill="%s" font-family="sans-serif" font-size="10">`+"\n", svgLabelColor)
+		for _, s := range m.Systems {
+			p := positions[s.SolarSystemID]
+			fmt.Fprintf(bw, `    <text x="%.1f" y="%.1f">%s</text>`+"\n", p[0]+radius+2, p[1]+3, html.EscapeString(s.SolarSystemName))
+		}
+		fmt.Fprintln(bw, "  </g>")
+	}
+
+	fmt.Fprintln(bw, "</svg>")
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write SVG: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package transformer
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// borderGates collects the gates crossing from one area into another.
+type borderGates struct {
+	count int64
+	from  []int64
+	to    []int64
+}
+
+// add records a gate from fromSystem to toSystem.
+func (b *borderGates) add(fromSystem, toSystem int64) {
+	b.count++
+	b.from = append(b.from, fromSystem)
+	b.to = append(b.to, toSystem)
+}
+
+// systems returns the sorted, distinct border systems on each side.
+func (b *borderGates) systems() (from, to []int64) {
+	slices.Sort(b.from)
+	slices.Sort(b.to)
+	return slices.Compact(b.from), slices.Compact(b.to)
+}
+
+// regionJumps aggregates enriched system jumps into directed region-to-region
+// connections, sorted by source then destination region. Jumps within a region
+// and jumps touching an unknown system are ignored.
+func regionJumps(jumps []models.SystemJump) []models.RegionJump {
+	type key struct{ from, to int64 }
+	gates := make(map[key]*borderGates)
+	for _, j := range jumps {
+		if j.FromRegionID == 0 || j.ToRegionID == 0 || j.FromRegionID == j.ToRegionID {
+			continue
+		}
+		k := key{j.FromRegionID, j.ToRegionID}
+		if gates[k] == nil {
+			gates[k] = &borderGates{}
+		}
+		gates[k].add(j.FromSolarSystemID, j.ToSolarSystemID)
+	}
+
+	result := make([]models.RegionJump, 0, len(gates))
+	for k, g := range gates {
+		from, to := g.systems()
+		result = append(result, models.RegionJump{
+			FromRegionID:       k.from,
+			ToRegionID:         k.to,
+			GateCount:          g.count,
+			FromSolarSystemIDs: from,
+			ToSolarSystemIDs:   to,
+		})
+	}
+	slices.SortFunc(result, func(a, b models.RegionJump) int {
+		return cmp.Or(cmp.Compare(a.FromRegionID, b.FromRegionID), cmp.Compare(a.ToRegionID, b.ToRegionID))
+	})
+	return result
+}
+
+// constellationJumps aggregates enriched system jumps into directed
+// constellation-to-constellation connections, sorted by source then destination
+// constellation. Jumps within a constellation and jumps touching an unknown
+// system are ignored.
+func constellationJumps(jumps []models.SystemJump) []models.ConstellationJump {
+	type key struct{ fromRegion, from, to, toRegion int64 }
+	gates := make(map[key]*borderGates)
+	for _, j := range jumps {
+		if j.FromConstellationID == 0 || j.ToConstellationID == 0 || j.FromConstellationID == j.ToConstellationID {
+			continue
+		}
+		k := key{j.FromRegionID, j.FromConstellationID, j.ToConstellationID, j.ToRegionID}
+		if gates[k] == nil {
+			gates[k] = &borderGates{}
+		}
+		gates[k].add(j.FromSolarSystemID, j.ToSolarSystemID)
+	}
+
+	result := make([]models.ConstellationJump, 0, len(gates))
+	for k, g := range gates {
+		from, to := g.systems()
+		result = append(result, models.ConstellationJump{
+			FromRegionID:        k.fromRegion,
+			FromConstellationID: k.from,
+			ToConstellationID:   k.to,
+			ToRegionID:          k.toRegion,
+			GateCount:           g.count,
+			FromSolarSystemIDs:  from,
+			ToSolarSystemIDs:    to,
+		})
+	}
+	slices.SortFunc(result, func(a, b models.ConstellationJump) int {
+		return cmp.Or(
+			cmp.Compare(a.FromConstellationID, b.FromConstellationID),
+			cmp.Compare(a.ToConstellationID, b.ToConstellationID),
+		)
+	})
+	return result
+}
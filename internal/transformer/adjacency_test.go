@@ -0,0 +1,105 @@
+package transformer
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/parser"
+)
+
+// adjacencyParseResult has two regions: region 1 with constellations 11 and 12,
+// region 2 with constellation 21. Gates run 101-102 (inside 11), 102-103
+// (11 to 12), 102-201 and 103-201 (region 1 to 2), plus a gate to an unknown system.
+func adjacencyParseResult() *parser.ParseResult {
+	return &parser.ParseResult{
+		SolarSystems: []models.SolarSystem{
+			{RegionID: 1, ConstellationID: 11, SolarSystemID: 101},
+			{RegionID: 1, ConstellationID: 11, SolarSystemID: 102},
+			{RegionID: 1, ConstellationID: 12, SolarSystemID: 103},
+			{RegionID: 2, ConstellationID: 21, SolarSystemID: 201},
+		},
+		SystemJumps: append(gateJumps([2]int64{101, 102}, [2]int64{102, 103}, [2]int64{102, 201}, [2]int64{103, 201}),
+			models.SystemJump{FromSolarSystemID: 101, ToSolarSystemID: unknownSystemID}),
+	}
+}
+
+func TestTransformer_RegionJumps(t *testing.T) {
+	tr := New(&config.Config{})
+
+	data, err := tr.Transform(adjacencyParseResult())
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+
+	want := []models.RegionJump{
+		{FromRegionID: 1, ToRegionID: 2, GateCount: 2, FromSolarSystemIDs: []int64{102, 103}, ToSolarSystemIDs: []int64{201}},
+		{FromRegionID: 2, ToRegionID: 1, GateCount: 2, FromSolarSystemIDs: []int64{201}, ToSolarSystemIDs: []int64{102, 103}},
+	}
+	if len(data.RegionJumps) != len(want) {
+		t.Fatalf("expected %d region jumps, got %+v", len(want), data.RegionJumps)
+	}
+	for i, w := range want {
+		got := data.RegionJumps[i]
+		if got.FromRegionID != w.FromRegionID || got.ToRegionID != w.ToRegionID || got.GateCount != w.GateCount ||
+			!slices.Equal(got.FromSolarSystemIDs, w.FromSolarSystemIDs) || !slices.Equal(got.ToSolarSystemIDs, w.ToSolarSystemIDs) {
+			t.Errorf("region jump %d = %+v, want %+v", i, got, w)
+		}
+	}
+
+	if got := data.RegionJumps[0].ToCSVRow(); got[3] != "102;103" || got[4] != "201" {
+		t.Errorf("expected ;-separated border systems, got %v", got)
+	}
+}
+
+func TestTransformer_ConstellationJumps(t *testing.T) {
+	tr := New(&config.Config{})
+
+	data, err := tr.Transform(adjacencyParseResult())
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+
+	type link struct {
+		fromRegion, from, to, toRegion, gates int64
+		fromSystems, toSystems                []int64
+	}
+	want := []link{
+		{1, 11, 12, 1, 1, []int64{102}, []int64{103}},
+		{1, 11, 21, 2, 1, []int64{102}, []int64{201}},
+		{1, 12, 11, 1, 1, []int64{103}, []int64{102}},
+		{1, 12, 21, 2, 1, []int64{103}, []int64{201}},
+		{2, 21, 11, 1, 1, []int64{201}, []int64{102}},
+		{2, 21, 12, 1, 1, []int64{201}, []int64{103}},
+	}
+	if len(data.ConstellationJumps) != len(want) {
+		t.Fatalf("expected %d constellation jumps, got %+v", len(want), data.ConstellationJumps)
+	}
+	for i, w := range want {
+		got := data.ConstellationJumps[i]
+		if got.FromRegionID != w.fromRegion || got.FromConstellationID != w.from || got.ToConstellationID != w.to ||
+			got.ToRegionID != w.toRegion || got.GateCount != w.gates ||
+			!slices.Equal(got.FromSolarSystemIDs, w.fromSystems) || !slices.Equal(got.ToSolarSystemIDs, w.toSystems) {
+			t.Errorf("constellation jump %d = %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+func TestTransformer_RegionJumps_WithoutSystemJumps(t *testing.T) {
+	tr := New(&config.Config{Tables: []string{config.TableRegionJumps}})
+
+	data, err := tr.Transform(adjacencyParseResult())
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+	if data.SystemJumps != nil {
+		t.Errorf("expected no system jumps when only regionJumps is enabled, got %d", len(data.SystemJumps))
+	}
+	if len(data.RegionJumps) != 2 {
+		t.Errorf("expected 2 region jumps, got %d", len(data.RegionJumps))
+	}
+	if data.ConstellationJumps != nil {
+		t.Errorf("expected no constellation jumps, got %d", len(data.ConstellationJumps))
+	}
+}
@@ -1,122 +1,129 @@
 package transformer
 
-import "github.com/guarzo/wanderer-sde/internal/models"
+import (
+	"iter"
+	"math"
+	"slices"
 
-// CalculateRegionBounds calculates min/max coordinates for regions
-// based on their constituent solar systems.
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// CalculateRegionBounds calculates min/max coordinates, centroid and bounding
+// sphere radius for regions based on their constituent solar systems.
 func CalculateRegionBounds(regions []models.Region, systems []models.SolarSystem) {
-	// Build map of regionID -> systems
-	regionSystems := make(map[int64][]models.SolarSystem)
-	for _, sys := range systems {
-		regionSystems[sys.RegionID] = append(regionSystems[sys.RegionID], sys)
-	}
+	regionBounds(universeIndex(regions, nil, systems))
+}
 
-	// Calculate bounds for each region
-	for i := range regions {
-		sysList := regionSystems[regions[i].RegionID]
-		if len(sysList) == 0 {
-			continue
-		}
+// CalculateConstellationBounds calculates min/max coordinates, centroid and
+// bounding sphere radius for constellations based on their constituent solar systems.
+func CalculateConstellationBounds(constellations []models.Constellation, systems []models.SolarSystem) {
+	constellationBounds(universeIndex(nil, constellations, systems))
+}
 
-		minX, maxX := sysList[0].X, sysList[0].X
-		minY, maxY := sysList[0].Y, sysList[0].Y
-		minZ, maxZ := sysList[0].Z, sysList[0].Z
+// universeIndex indexes the map tables.
+func universeIndex(regions []models.Region, constellations []models.Constellation, systems []models.SolarSystem) *models.Dataset {
+	return models.NewDataset(&models.ConvertedData{
+		Universe: &models.UniverseData{
+			Regions:        regions,
+			Constellations: constellations,
+			SolarSystems:   systems,
+		},
+	})
+}
 
-		for _, sys := range sysList {
-			if sys.X < minX {
-				minX = sys.X
-			}
-			if sys.X > maxX {
-				maxX = sys.X
-			}
-			if sys.Y < minY {
-				minY = sys.Y
-			}
-			if sys.Y > maxY {
-				maxY = sys.Y
-			}
-			if sys.Z < minZ {
-				minZ = sys.Z
-			}
-			if sys.Z > maxZ {
-				maxZ = sys.Z
-			}
+// regionBounds sets the bounds, centroid and radius of each indexed region
+// from its systems. Regions without systems keep their parsed values.
+func regionBounds(index *models.Dataset) {
+	regions := index.Data().Universe.Regions
+	for i := range regions {
+		e, ok := systemExtent(index.SystemsInRegion(regions[i].RegionID))
+		if !ok {
+			continue
 		}
-
-		regions[i].XMin = minX
-		regions[i].XMax = maxX
-		regions[i].YMin = minY
-		regions[i].YMax = maxY
-		regions[i].ZMin = minZ
-		regions[i].ZMax = maxZ
+		r := &regions[i]
+		r.XMin, r.XMax = e.xMin, e.xMax
+		r.YMin, r.YMax = e.yMin, e.yMax
+		r.ZMin, r.ZMax = e.zMin, e.zMax
+		r.CentroidX, r.CentroidY, r.CentroidZ = e.centroidX, e.centroidY, e.centroidZ
+		r.Radius = e.radius
 	}
 }
 
-// CalculateConstellationBounds calculates min/max coordinates for constellations
-// based on their constituent solar systems.
-func CalculateConstellationBounds(constellations []models.Constellation, systems []models.SolarSystem) {
-	// Build map of constellationID -> systems
-	constellationSystems := make(map[int64][]models.SolarSystem)
-	for _, sys := range systems {
-		constellationSystems[sys.ConstellationID] = append(constellationSystems[sys.ConstellationID], sys)
-	}
-
-	// Calculate bounds for each constellation
+// constellationBounds sets the bounds, centroid and radius of each indexed
+// constellation from its systems. Constellations without systems keep their parsed values.
+func constellationBounds(index *models.Dataset) {
+	constellations := index.Data().Universe.Constellations
 	for i := range constellations {
-		sysList := constellationSystems[constellations[i].ConstellationID]
-		if len(sysList) == 0 {
+		e, ok := systemExtent(index.SystemsInConstellation(constellations[i].ConstellationID))
+		if !ok {
 			continue
 		}
+		c := &constellations[i]
+		c.XMin, c.XMax = e.xMin, e.xMax
+		c.YMin, c.YMax = e.yMin, e.yMax
+		c.ZMin, c.ZMax = e.zMin, e.zMax
+		c.CentroidX, c.CentroidY, c.CentroidZ = e.centroidX, e.centroidY, e.centroidZ
+		c.Radius = e.radius
+	}
+}
 
-		minX, maxX := sysList[0].X, sysList[0].X
-		minY, maxY := sysList[0].Y, sysList[0].Y
-		minZ, maxZ := sysList[0].Z, sysList[0].Z
+// extent is the space taken by a group of solar systems: an axis-aligned box
+// and a bounding sphere around the systems' centroid. Both include each
+// system's own radius.
+type extent struct {
+	xMin, xMax float64
+	yMin, yMax float64
+	zMin, zMax float64
 
-		for _, sys := range sysList {
-			if sys.X < minX {
-				minX = sys.X
-			}
-			if sys.X > maxX {
-				maxX = sys.X
-			}
-			if sys.Y < minY {
-				minY = sys.Y
-			}
-			if sys.Y > maxY {
-				maxY = sys.Y
-			}
-			if sys.Z < minZ {
-				minZ = sys.Z
-			}
-			if sys.Z > maxZ {
-				maxZ = sys.Z
-			}
-		}
+	centroidX, centroidY, centroidZ float64
+	radius                          float64
+}
+
+// systemExtent returns the extent of the given systems, or false when there are none.
+func systemExtent(seq iter.Seq[models.SolarSystem]) (extent, bool) {
+	systems := slices.Collect(seq)
+	if len(systems) == 0 {
+		return extent{}, false
+	}
+
+	first := systems[0]
+	e := extent{
+		xMin: first.X - first.Radius, xMax: first.X + first.Radius,
+		yMin: first.Y - first.Radius, yMax: first.Y + first.Radius,
+		zMin: first.Z - first.Radius, zMax: first.Z + first.Radius,
+	}
+	for _, sys := range systems {
+		e.xMin, e.xMax = min(e.xMin, sys.X-sys.Radius), max(e.xMax, sys.X+sys.Radius)
+		e.yMin, e.yMax = min(e.yMin, sys.Y-sys.Radius), max(e.yMax, sys.Y+sys.Radius)
+		e.zMin, e.zMax = min(e.zMin, sys.Z-sys.Radius), max(e.zMax, sys.Z+sys.Radius)
+		e.centroidX += sys.X
+		e.centroidY += sys.Y
+		e.centroidZ += sys.Z
+	}
+	n := float64(len(systems))
+	e.centroidX, e.centroidY, e.centroidZ = e.centroidX/n, e.centroidY/n, e.centroidZ/n
 
-		constellations[i].XMin = minX
-		constellations[i].XMax = maxX
-		constellations[i].YMin = minY
-		constellations[i].YMax = maxY
-		constellations[i].ZMin = minZ
-		constellations[i].ZMax = maxZ
+	for _, sys := range systems {
+		dx, dy, dz := sys.X-e.centroidX, sys.Y-e.centroidY, sys.Z-e.centroidZ
+		e.radius = max(e.radius, math.Sqrt(dx*dx+dy*dy+dz*dz)+sys.Radius)
 	}
+	return e, true
 }
 
 // InheritFactionIDs sets factionID on solar systems that don't have one,
 // inheriting from their region.
 func InheritFactionIDs(systems []models.SolarSystem, regions []models.Region) {
-	// Build map of regionID -> factionID
-	regionFactions := make(map[int64]*int64)
-	for _, region := range regions {
-		regionFactions[region.RegionID] = region.FactionID
-	}
+	inheritFactionIDs(universeIndex(regions, nil, systems))
+}
 
-	// Inherit factionID from region if system doesn't have one
+// inheritFactionIDs sets factionID on indexed solar systems from their region.
+func inheritFactionIDs(index *models.Dataset) {
+	systems := index.Data().Universe.SolarSystems
 	for i := range systems {
 		if systems[i].FactionID == nil {
-			if factionID, ok := regionFactions[systems[i].RegionID]; ok && factionID != nil {
+			if region, ok := index.Region(systems[i].RegionID); ok && region.FactionID != nil {
 				// Copy the value to avoid pointer aliasing
-				val := *factionID
+				val := *region.FactionID
 				systems[i].FactionID = &val
 			}
 		}
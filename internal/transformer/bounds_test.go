@@ -1,9 +1,12 @@
 package transformer
 
 import (
+	"math"
 	"testing"
 
+	"github.com/guarzo/wanderer-sde/internal/config"
 	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/parser"
 )
 
 func TestCalculateRegionBounds(t *testing.T) {
@@ -115,6 +118,91 @@ func TestCalculateConstellationBounds(t *testing.T) {
 	}
 }
 
+func TestCalculateRegionBounds_SystemRadius(t *testing.T) {
+	regions := []models.Region{
+		{RegionID: 1, RegionName: "Region A", X: 7, Y: 8, Z: 9},
+	}
+
+	systems := []models.SolarSystem{
+		{SolarSystemID: 101, RegionID: 1, X: -100, Y: 0, Z: 0, Radius: 10},
+		{SolarSystemID: 102, RegionID: 1, X: 100, Y: 0, Z: 0, Radius: 30},
+		{SolarSystemID: 103, RegionID: 1, X: 0, Y: 60, Z: 0, Radius: 5},
+	}
+
+	CalculateRegionBounds(regions, systems)
+
+	r := regions[0]
+	if r.XMin != -110 || r.XMax != 130 {
+		t.Errorf("X bounds should include system radii: expected [-110, 130], got [%f, %f]", r.XMin, r.XMax)
+	}
+	if r.YMin != -30 || r.YMax != 65 {
+		t.Errorf("Y bounds should include system radii: expected [-30, 65], got [%f, %f]", r.YMin, r.YMax)
+	}
+	if r.ZMin != -30 || r.ZMax != 30 {
+		t.Errorf("Z bounds should include system radii: expected [-30, 30], got [%f, %f]", r.ZMin, r.ZMax)
+	}
+
+	// Centroid is the mean of the system positions
+	if r.CentroidX != 0 || r.CentroidY != 20 || r.CentroidZ != 0 {
+		t.Errorf("Expected centroid (0, 20, 0), got (%f, %f, %f)", r.CentroidX, r.CentroidY, r.CentroidZ)
+	}
+
+	// The furthest edge is system 102: sqrt(100^2 + 20^2) + 30
+	wantRadius := math.Sqrt(100*100+20*20) + 30
+	if math.Abs(r.Radius-wantRadius) > 1e-9 {
+		t.Errorf("Expected radius %f, got %f", wantRadius, r.Radius)
+	}
+
+	// The SDE position is kept
+	if r.X != 7 || r.Y != 8 || r.Z != 9 {
+		t.Errorf("SDE position should be kept, got (%f, %f, %f)", r.X, r.Y, r.Z)
+	}
+}
+
+func TestCalculateConstellationBounds_KeepsParsedRadiusWithoutSystems(t *testing.T) {
+	constellations := []models.Constellation{
+		{ConstellationID: 1, Radius: 42},
+		{ConstellationID: 2, Radius: 42},
+	}
+
+	systems := []models.SolarSystem{
+		{SolarSystemID: 101, ConstellationID: 1, X: 10, Y: 20, Z: 30, Radius: 2},
+	}
+
+	CalculateConstellationBounds(constellations, systems)
+
+	if c := constellations[0]; c.Radius != 2 || c.CentroidX != 10 || c.CentroidY != 20 || c.CentroidZ != 30 {
+		t.Errorf("Single system constellation: expected radius 2 at (10, 20, 30), got %f at (%f, %f, %f)",
+			c.Radius, c.CentroidX, c.CentroidY, c.CentroidZ)
+	}
+	if constellations[1].Radius != 42 {
+		t.Errorf("Empty constellation should keep its parsed radius, got %f", constellations[1].Radius)
+	}
+}
+
+func TestTransformer_KeepsSystemBounds(t *testing.T) {
+	tr := New(&config.Config{})
+	data, err := tr.Transform(&parser.ParseResult{
+		Regions: []models.Region{{RegionID: 1}},
+		SolarSystems: []models.SolarSystem{
+			{SolarSystemID: 101, RegionID: 1, X: 10, Y: -20, Z: 30, Radius: 5,
+				XMin: 1, XMax: 2, YMin: 3, YMax: 4, ZMin: 5, ZMax: 6},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+
+	s := data.Universe.SolarSystems[0]
+	if s.XMin != 1 || s.XMax != 2 || s.YMin != 3 || s.YMax != 4 || s.ZMin != 5 || s.ZMax != 6 {
+		t.Errorf("Expected the parsed box [1, 2] [3, 4] [5, 6], got [%f, %f] [%f, %f] [%f, %f]",
+			s.XMin, s.XMax, s.YMin, s.YMax, s.ZMin, s.ZMax)
+	}
+	if r := data.Universe.Regions[0]; r.XMin != 5 || r.XMax != 15 {
+		t.Errorf("Expected the region box to span the system radius, got x [%f, %f]", r.XMin, r.XMax)
+	}
+}
+
 func TestInheritFactionIDs(t *testing.T) {
 	faction1 := int64(500001)
 	faction2 := int64(500002)
@@ -0,0 +1,97 @@
+package transformer
+
+import (
+	"sort"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/parser"
+)
+
+// transformDogmaAttributes converts SDE dogma attributes to dgmAttributeTypes rows sorted by ID.
+func (t *Transformer) transformDogmaAttributes(attributes map[int64]parser.SDEDogmaAttribute) []models.DgmAttributeType {
+	result := make([]models.DgmAttributeType, 0, len(attributes))
+
+	for attributeID, attr := range attributes {
+		result = append(result, models.DgmAttributeType{
+			AttributeID:   attributeID,
+			AttributeName: attr.Name,
+			Description:   attr.Description[models.DefaultLanguage],
+			IconID:        models.Int64Ptr(attr.IconID),
+			DefaultValue:  attr.DefaultValue,
+			Published:     attr.Published,
+			DisplayName:   attr.DisplayName[models.DefaultLanguage],
+			UnitID:        models.Int64Ptr(attr.UnitID),
+			Stackable:     attr.Stackable,
+			HighIsGood:    attr.HighIsGood,
+			CategoryID:    models.Int64Ptr(attr.AttributeCategoryID),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].AttributeID < result[j].AttributeID
+	})
+
+	return result
+}
+
+// transformDogmaEffects converts SDE dogma effects to dgmEffects rows sorted by ID.
+func (t *Transformer) transformDogmaEffects(effects map[int64]parser.SDEDogmaEffect) []models.DgmEffect {
+	result := make([]models.DgmEffect, 0, len(effects))
+
+	for effectID, effect := range effects {
+		result = append(result, models.DgmEffect{
+			EffectID:                       effectID,
+			EffectName:                     effect.Name,
+			EffectCategory:                 effect.EffectCategoryID,
+			Description:                    effect.Description[models.DefaultLanguage],
+			GUID:                           effect.GUID,
+			IconID:                         models.Int64Ptr(effect.IconID),
+			IsOffensive:                    effect.IsOffensive,
+			IsAssistance:                   effect.IsAssistance,
+			DurationAttributeID:            models.Int64Ptr(effect.DurationAttributeID),
+			TrackingSpeedAttributeID:       models.Int64Ptr(effect.TrackingSpeedAttributeID),
+			DischargeAttributeID:           models.Int64Ptr(effect.DischargeAttributeID),
+			RangeAttributeID:               models.Int64Ptr(effect.RangeAttributeID),
+			FalloffAttributeID:             models.Int64Ptr(effect.FalloffAttributeID),
+			DisallowAutoRepeat:             effect.DisallowAutoRepeat,
+			Published:                      effect.Published,
+			DisplayName:                    effect.DisplayName[models.DefaultLanguage],
+			IsWarpSafe:                     effect.IsWarpSafe,
+			RangeChance:                    effect.RangeChance,
+			ElectronicChance:               effect.ElectronicChance,
+			PropulsionChance:               effect.PropulsionChance,
+			Distribution:                   models.Int64Ptr(effect.Distribution),
+			NPCUsageChanceAttributeID:      models.Int64Ptr(effect.NPCUsageChanceAttributeID),
+			NPCActivationChanceAttributeID: models.Int64Ptr(effect.NPCActivationChanceAttributeID),
+			FittingUsageChanceAttributeID:  models.Int64Ptr(effect.FittingUsageChanceAttributeID),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].EffectID < result[j].EffectID
+	})
+
+	return result
+}
+
+// sortTypeAttributes sorts attribute values by type ID then attribute ID.
+func sortTypeAttributes(rows []models.DgmTypeAttribute) []models.DgmTypeAttribute {
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].TypeID != rows[j].TypeID {
+			return rows[i].TypeID < rows[j].TypeID
+		}
+		return rows[i].AttributeID < rows[j].AttributeID
+	})
+	return rows
+}
+
+// sortTypeEffects sorts type effects by type ID then effect ID.
+func sortTypeEffects(rows []models.DgmTypeEffect) []models.DgmTypeEffect {
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].TypeID != rows[j].TypeID {
+			return rows[i].TypeID < rows[j].TypeID
+		}
+		return rows[i].EffectID < rows[j].EffectID
+	})
+	return rows
+}
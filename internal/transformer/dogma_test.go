@@ -0,0 +1,42 @@
+package transformer
+
+import (
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/parser"
+)
+
+func TestTransformer_TypeDogmaSorted(t *testing.T) {
+	parseResult := &parser.ParseResult{
+		TypeDogma: &parser.TypeDogma{
+			Attributes: []models.DgmTypeAttribute{
+				{TypeID: 588, AttributeID: 4},
+				{TypeID: 587, AttributeID: 552},
+				{TypeID: 587, AttributeID: 4},
+			},
+			Effects: []models.DgmTypeEffect{
+				{TypeID: 588, EffectID: 11},
+				{TypeID: 587, EffectID: 12},
+				{TypeID: 587, EffectID: 11},
+			},
+		},
+	}
+
+	data, err := New(&config.Config{}).Transform(parseResult)
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+
+	// Rows are sorted by type then attribute or effect ID
+	attrs := data.TypeAttributes
+	if len(attrs) != 3 || attrs[0] != (models.DgmTypeAttribute{TypeID: 587, AttributeID: 4}) ||
+		attrs[1].AttributeID != 552 || attrs[2].TypeID != 588 {
+		t.Errorf("attributes not sorted: %+v", attrs)
+	}
+	effects := data.TypeEffects
+	if len(effects) != 3 || effects[0].EffectID != 11 || effects[1].EffectID != 12 || effects[2].TypeID != 588 {
+		t.Errorf("effects not sorted: %+v", effects)
+	}
+}
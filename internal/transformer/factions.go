@@ -0,0 +1,207 @@
+package transformer
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/parser"
+)
+
+// stationCounts holds the number of stations and distinct station systems of an owner.
+type stationCounts struct {
+	stations int64
+	systems  map[int64]bool
+}
+
+// add records a station in the given solar system.
+func (c *stationCounts) add(solarSystemID int64) {
+	if c.systems == nil {
+		c.systems = make(map[int64]bool)
+	}
+	c.stations++
+	c.systems[solarSystemID] = true
+}
+
+// countStations tallies NPC stations by owning corporation and by that corporation's faction.
+func countStations(parseResult *parser.ParseResult) (byCorporation, byFaction map[int64]*stationCounts) {
+	byCorporation = make(map[int64]*stationCounts)
+	byFaction = make(map[int64]*stationCounts)
+
+	for _, station := range parseResult.NPCStations {
+		if byCorporation[station.OwnerID] == nil {
+			byCorporation[station.OwnerID] = &stationCounts{}
+		}
+		byCorporation[station.OwnerID].add(station.SolarSystemID)
+
+		factionID := parseResult.NPCCorporations[station.OwnerID].FactionID
+		if factionID == 0 {
+			continue
+		}
+		if byFaction[factionID] == nil {
+			byFaction[factionID] = &stationCounts{}
+		}
+		byFaction[factionID].add(station.SolarSystemID)
+	}
+
+	return byCorporation, byFaction
+}
+
+// transformFactions converts SDE factions to chrFactions rows sorted by ID.
+func (t *Transformer) transformFactions(parseResult *parser.ParseResult) []models.ChrFaction {
+	_, byFaction := countStations(parseResult)
+	result := make([]models.ChrFaction, 0, len(parseResult.Factions))
+
+	for factionID, faction := range parseResult.Factions {
+		raceIDs := append([]int64{}, faction.MemberRaces...)
+		slices.Sort(raceIDs)
+
+		chr := models.ChrFaction{
+			FactionID:            factionID,
+			FactionName:          faction.Name[models.DefaultLanguage],
+			Description:          faction.Description[models.DefaultLanguage],
+			RaceIDs:              raceIDs,
+			SolarSystemID:        models.Int64Ptr(faction.SolarSystemID),
+			CorporationID:        models.Int64Ptr(faction.CorporationID),
+			SizeFactor:           faction.SizeFactor,
+			MilitiaCorporationID: models.Int64Ptr(faction.MilitiaCorporationID),
+			IconID:               models.Int64Ptr(faction.IconID),
+			Names:                faction.Name,
+		}
+		if counts := byFaction[factionID]; counts != nil {
+			chr.StationCount = counts.stations
+			chr.StationSystemCount = int64(len(counts.systems))
+		}
+
+		result = append(result, chr)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].FactionID < result[j].FactionID
+	})
+
+	return result
+}
+
+// transformRaces converts SDE races to chrRaces rows sorted by ID.
+func (t *Transformer) transformRaces(races map[int64]parser.SDERace) []models.ChrRace {
+	result := make([]models.ChrRace, 0, len(races))
+
+	for raceID, race := range races {
+		result = append(result, models.ChrRace{
+			RaceID:      raceID,
+			RaceName:    race.Name[models.DefaultLanguage],
+			Description: race.Description[models.DefaultLanguage],
+			IconID:      models.Int64Ptr(race.IconID),
+			Names:       race.Name,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RaceID < result[j].RaceID
+	})
+
+	return result
+}
+
+// transformCorporations converts SDE NPC corporations to crpNPCCorporations rows
+// sorted by ID. Corporations flagged as deleted in the SDE are skipped.
+func (t *Transformer) transformCorporations(parseResult *parser.ParseResult) []models.CrpNPCCorporation {
+	byCorporation, _ := countStations(parseResult)
+	result := make([]models.CrpNPCCorporation, 0, len(parseResult.NPCCorporations))
+
+	for corporationID, corp := range parseResult.NPCCorporations {
+		if corp.Deleted {
+			continue
+		}
+
+		crp := models.CrpNPCCorporation{
+			CorporationID:   corporationID,
+			Size:            corp.Size,
+			Extent:          corp.Extent,
+			SolarSystemID:   models.Int64Ptr(corp.SolarSystemID),
+			FriendID:        models.Int64Ptr(corp.FriendID),
+			EnemyID:         models.Int64Ptr(corp.EnemyID),
+			PublicShares:    corp.PublicShares,
+			InitialPrice:    corp.InitialPrice,
+			MinSecurity:     corp.MinSecurity,
+			FactionID:       models.Int64Ptr(corp.FactionID),
+			SizeFactor:      corp.SizeFactor,
+			Description:     corp.Description[models.DefaultLanguage],
+			IconID:          models.Int64Ptr(corp.IconID),
+			CorporationName: corp.Name[models.DefaultLanguage],
+			TickerName:      corp.TickerName,
+			Names:           corp.Name,
+		}
+		if counts := byCorporation[corporationID]; counts != nil {
+			crp.StationCount = counts.stations
+			crp.StationSystemCount = int64(len(counts.systems))
+		}
+
+		result = append(result, crp)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CorporationID < result[j].CorporationID
+	})
+
+	return result
+}
+
+// validateFactionIDs warns about faction IDs on regions, constellations and
+// solar systems (including IDs inherited by InheritFactionIDs) that have no
+// chrFactions entry. One warning is reported per unknown faction.
+func validateFactionIDs(data *models.ConvertedData) []string {
+	if len(data.Factions) == 0 || data.Universe == nil {
+		return nil
+	}
+
+	known := make(map[int64]bool, len(data.Factions))
+	for _, f := range data.Factions {
+		known[f.FactionID] = true
+	}
+
+	type usage struct{ regions, constellations, systems int }
+	unknown := make(map[int64]*usage)
+	record := func(factionID *int64) *usage {
+		if factionID == nil || known[*factionID] {
+			return nil
+		}
+		if unknown[*factionID] == nil {
+			unknown[*factionID] = &usage{}
+		}
+		return unknown[*factionID]
+	}
+
+	for _, r := range data.Universe.Regions {
+		if u := record(r.FactionID); u != nil {
+			u.regions++
+		}
+	}
+	for _, c := range data.Universe.Constellations {
+		if u := record(c.FactionID); u != nil {
+			u.constellations++
+		}
+	}
+	for _, s := range data.Universe.SolarSystems {
+		if u := record(s.FactionID); u != nil {
+			u.systems++
+		}
+	}
+
+	ids := make([]int64, 0, len(unknown))
+	for id := range unknown {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	warnings := make([]string, 0, len(ids))
+	for _, id := range ids {
+		u := unknown[id]
+		warnings = append(warnings, fmt.Sprintf(
+			"Faction %d is not in chrFactions (used by %d regions, %d constellations, %d solar systems)",
+			id, u.regions, u.constellations, u.systems))
+	}
+	return warnings
+}
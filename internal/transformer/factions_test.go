@@ -0,0 +1,103 @@
+package transformer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/parser"
+)
+
+func TestTransformer_TransformFactions(t *testing.T) {
+	tr := New(&config.Config{})
+
+	parseResult := &parser.ParseResult{
+		Factions: map[int64]parser.SDEFaction{
+			500001: {
+				Name:          map[string]string{"en": "Caldari State"},
+				MemberRaces:   []int64{135, 1},
+				CorporationID: 1000035,
+			},
+			500010: {Name: map[string]string{"en": "Guristas Pirates"}},
+		},
+		NPCCorporations: map[int64]parser.SDENPCCorporation{
+			1000035: {Name: map[string]string{"en": "Caldari Navy"}, TickerName: "CN", FactionID: 500001},
+			1000127: {Name: map[string]string{"en": "Guristas"}, FactionID: 500010},
+			1000999: {Name: map[string]string{"en": "Closed Corp"}, Deleted: true},
+		},
+		NPCStations: map[int64]parser.SDENPCStation{
+			60003760: {SolarSystemID: 30000142, OwnerID: 1000035},
+			60003761: {SolarSystemID: 30000142, OwnerID: 1000035},
+			60003762: {SolarSystemID: 30000144, OwnerID: 1000035},
+		},
+	}
+
+	factions := tr.transformFactions(parseResult)
+	if len(factions) != 2 || factions[0].FactionID != 500001 {
+		t.Fatalf("unexpected factions: %+v", factions)
+	}
+	caldari := factions[0]
+	if caldari.FactionName != "Caldari State" || !slices.Equal(caldari.RaceIDs, []int64{1, 135}) {
+		t.Errorf("unexpected faction: %+v", caldari)
+	}
+	if caldari.StationCount != 3 || caldari.StationSystemCount != 2 {
+		t.Errorf("expected 3 stations in 2 systems, got %d in %d", caldari.StationCount, caldari.StationSystemCount)
+	}
+	if caldari.CorporationID == nil || *caldari.CorporationID != 1000035 || caldari.IconID != nil {
+		t.Errorf("unexpected nullable fields: %+v", caldari)
+	}
+
+	corporations := tr.transformCorporations(parseResult)
+	if len(corporations) != 2 {
+		t.Fatalf("expected deleted corporation to be skipped, got %d corporations", len(corporations))
+	}
+	navy := corporations[0]
+	if navy.CorporationName != "Caldari Navy" || navy.TickerName != "CN" || navy.StationCount != 3 {
+		t.Errorf("unexpected corporation: %+v", navy)
+	}
+	if navy.FactionID == nil || *navy.FactionID != 500001 {
+		t.Errorf("expected faction 500001, got %v", navy.FactionID)
+	}
+}
+
+func TestTransformer_ValidateFactionIDs(t *testing.T) {
+	tr := New(&config.Config{})
+
+	known := int64(500001)
+	unknown := int64(500099)
+	data := &models.ConvertedData{
+		Universe: &models.UniverseData{
+			Regions: []models.Region{
+				{RegionID: 10000001, FactionID: &unknown},
+				{RegionID: 10000002, FactionID: &known},
+			},
+			SolarSystems: []models.SolarSystem{
+				{SolarSystemID: 30000001, RegionID: 10000001},
+				{SolarSystemID: 30000142, RegionID: 10000002, FactionID: &known},
+			},
+		},
+		Factions: []models.ChrFaction{{FactionID: known}},
+	}
+
+	// Inherited faction IDs are validated as well
+	InheritFactionIDs(data.Universe.SolarSystems, data.Universe.Regions)
+
+	result := tr.Validate(data)
+	var found bool
+	for _, w := range result.Warnings {
+		if strings.Contains(w, "Faction 500099") {
+			found = true
+			if !strings.Contains(w, "1 regions") || !strings.Contains(w, "1 solar systems") {
+				t.Errorf("unexpected usage counts in warning: %s", w)
+			}
+		}
+		if strings.Contains(w, "Faction 500001") {
+			t.Errorf("known faction reported as unknown: %s", w)
+		}
+	}
+	if !found {
+		t.Errorf("expected warning for unknown faction, got %v", result.Warnings)
+	}
+}
@@ -0,0 +1,111 @@
+package transformer
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// minHubGates is the number of distinct neighbouring systems that makes a hub.
+const minHubGates = 3
+
+// gateFlag names one of the gate-derived solar system flags and how to reach it.
+type gateFlag struct {
+	name    string
+	sde     func(*models.SolarSystem) *bool
+	derived func(*models.GateFlags) bool
+}
+
+// gateFlagFields lists the flags derived from the stargate graph.
+var gateFlagFields = []gateFlag{
+	{"border", func(s *models.SolarSystem) *bool { return &s.Border }, func(f *models.GateFlags) bool { return f.Border }},
+	{"regional", func(s *models.SolarSystem) *bool { return &s.Regional }, func(f *models.GateFlags) bool { return f.Regional }},
+	{"international", func(s *models.SolarSystem) *bool { return &s.International }, func(f *models.GateFlags) bool { return f.International }},
+	{"hub", func(s *models.SolarSystem) *bool { return &s.Hub }, func(f *models.GateFlags) bool { return f.Hub }},
+}
+
+// deriveGateFlags sets GateFlags on each indexed solar system from the indexed
+// stargate jumps. Factions must already be inherited from regions, since a
+// system without a faction of its own is owned by its region's. Gates to
+// unknown systems are ignored.
+func deriveGateFlags(index *models.Dataset) {
+	systems := index.Data().Universe.SolarSystems
+	for i := range systems {
+		sys := &systems[i]
+		ids := index.Neighbours(sys.SolarSystemID)
+
+		flags := &models.GateFlags{}
+		known := 0
+		for _, id := range ids {
+			other, ok := index.SolarSystem(id)
+			if !ok {
+				continue
+			}
+			known++
+			flags.Border = flags.Border || other.ConstellationID != sys.ConstellationID
+			flags.Regional = flags.Regional || other.RegionID != sys.RegionID
+			flags.International = flags.International || !sameFaction(sys.FactionID, other.FactionID)
+		}
+		flags.Hub = known >= minHubGates
+		sys.GateFlags = flags
+	}
+}
+
+// sameFaction reports whether two optional faction IDs are equal, treating
+// systems without a faction as one owner.
+func sameFaction(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// fillGateFlags copies derived flags into systems for each flag the SDE sets on
+// no system at all, which is how builds that dropped the field parse. Flags the
+// SDE does provide are kept and checked by validateGateFlags.
+func fillGateFlags(systems []models.SolarSystem) {
+	for _, flag := range gateFlagFields {
+		provided := slices.ContainsFunc(systems, func(s models.SolarSystem) bool {
+			return *flag.sde(&s)
+		})
+		if provided {
+			continue
+		}
+		for i := range systems {
+			if systems[i].GateFlags != nil {
+				*flag.sde(&systems[i]) = flag.derived(systems[i].GateFlags)
+			}
+		}
+	}
+}
+
+// validateGateFlags reports solar systems whose border, regional, international
+// or hub flag disagrees with their stargates, one summarised warning per flag.
+func validateGateFlags(data *models.ConvertedData) []string {
+	if data.Universe == nil {
+		return nil
+	}
+	systems := data.Universe.SolarSystems
+
+	var warnings []string
+	for _, flag := range gateFlagFields {
+		var mismatched []string
+		for i := range systems {
+			sys := &systems[i]
+			if sys.GateFlags == nil {
+				continue
+			}
+			if *flag.sde(sys) != flag.derived(sys.GateFlags) {
+				mismatched = append(mismatched, strconv.FormatInt(sys.SolarSystemID, 10))
+			}
+		}
+		if len(mismatched) > 0 {
+			warnings = append(warnings, fmt.Sprintf("%d solar systems have a %s flag that disagrees with their stargates (e.g. %s)",
+				len(mismatched), flag.name, strings.Join(examples(mismatched), ", ")))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,113 @@
+package transformer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/parser"
+)
+
+// gateFlagParseResult has region 1 (faction 500001) with constellations 11
+// (101-104, where 104 belongs to faction 500003) and 12 (105), and region 2
+// (faction 500002) with 201. 101 is a hub, 102-105 crosses constellations and
+// 103-201 crosses regions.
+func gateFlagParseResult() *parser.ParseResult {
+	otherFaction := int64(500003)
+	jumps := gateJumps([2]int64{101, 102}, [2]int64{101, 103}, [2]int64{101, 104}, [2]int64{102, 105}, [2]int64{103, 201})
+	jumps = append(jumps, models.SystemJump{FromSolarSystemID: 105, ToSolarSystemID: unknownSystemID})
+
+	return &parser.ParseResult{
+		Regions: []models.Region{
+			{RegionID: 1, FactionID: models.Int64Ptr(500001)},
+			{RegionID: 2, FactionID: models.Int64Ptr(500002)},
+		},
+		SolarSystems: []models.SolarSystem{
+			{RegionID: 1, ConstellationID: 11, SolarSystemID: 101},
+			{RegionID: 1, ConstellationID: 11, SolarSystemID: 102},
+			{RegionID: 1, ConstellationID: 11, SolarSystemID: 103},
+			{RegionID: 1, ConstellationID: 11, SolarSystemID: 104, FactionID: &otherFaction},
+			{RegionID: 1, ConstellationID: 12, SolarSystemID: 105},
+			{RegionID: 2, ConstellationID: 21, SolarSystemID: 201},
+		},
+		SystemJumps: jumps,
+	}
+}
+
+func TestTransformer_GateFlags_FilledWhenMissing(t *testing.T) {
+	tr := New(&config.Config{Tables: []string{config.TableSystems}})
+
+	data, err := tr.Transform(gateFlagParseResult())
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+
+	want := map[int64]models.GateFlags{
+		101: {International: true, Hub: true},
+		102: {Border: true},
+		103: {Border: true, Regional: true, International: true},
+		104: {International: true},
+		105: {Border: true},
+		201: {Border: true, Regional: true, International: true},
+	}
+	for _, sys := range data.Universe.SolarSystems {
+		w := want[sys.SolarSystemID]
+		if sys.GateFlags == nil || *sys.GateFlags != w {
+			t.Errorf("system %d: GateFlags = %+v, want %+v", sys.SolarSystemID, sys.GateFlags, w)
+		}
+		got := models.GateFlags{Border: sys.Border, Regional: sys.Regional, International: sys.International, Hub: sys.Hub}
+		if got != w {
+			t.Errorf("system %d: flags = %+v, want filled %+v", sys.SolarSystemID, got, w)
+		}
+	}
+
+	if warnings := validateGateFlags(data); len(warnings) != 0 {
+		t.Errorf("expected no warnings for filled flags, got %v", warnings)
+	}
+}
+
+func TestTransformer_GateFlags_MismatchWarnings(t *testing.T) {
+	tr := New(&config.Config{Tables: []string{config.TableSystems}})
+
+	parsed := gateFlagParseResult()
+	// The SDE provides border (missing on 105 and 201) and hub (wrong on 101 and 102)
+	parsed.SolarSystems[1].Border = true
+	parsed.SolarSystems[2].Border = true
+	parsed.SolarSystems[1].Hub = true
+
+	data, err := tr.Transform(parsed)
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+
+	systems := data.Universe.SolarSystems
+	if systems[4].Border {
+		t.Error("SDE-provided border flag should be kept, not filled")
+	}
+	if !systems[2].Regional {
+		t.Error("missing regional flag should be filled")
+	}
+
+	warnings := validateGateFlags(data)
+	if len(warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0], "2 solar systems have a border flag") || !strings.Contains(warnings[0], "105, 201") {
+		t.Errorf("unexpected border warning: %s", warnings[0])
+	}
+	if !strings.Contains(warnings[1], "2 solar systems have a hub flag") || !strings.Contains(warnings[1], "101, 102") {
+		t.Errorf("unexpected hub warning: %s", warnings[1])
+	}
+}
+
+func TestValidateGateFlags_NoStargates(t *testing.T) {
+	data := &models.ConvertedData{
+		Universe: &models.UniverseData{
+			SolarSystems: []models.SolarSystem{{SolarSystemID: 101, Border: true}},
+		},
+	}
+	if warnings := validateGateFlags(data); len(warnings) != 0 {
+		t.Errorf("expected no warnings without derived flags, got %v", warnings)
+	}
+}
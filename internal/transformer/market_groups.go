@@ -0,0 +1,154 @@
+package transformer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/parser"
+)
+
+// transformMarketGroups converts SDE market groups to invMarketGroups rows sorted
+// by ID, deriving each group's materialised path and top-level group.
+func (t *Transformer) transformMarketGroups(groups map[int64]parser.SDEMarketGroup) []models.InvMarketGroup {
+	result := make([]models.InvMarketGroup, 0, len(groups))
+
+	for groupID, group := range groups {
+		path := marketGroupPath(groupID, groups)
+		ids := make([]string, len(path))
+		for i, id := range path {
+			ids[i] = strconv.FormatInt(id, 10)
+		}
+
+		result = append(result, models.InvMarketGroup{
+			MarketGroupID:     groupID,
+			ParentGroupID:     models.Int64Ptr(group.ParentGroupID),
+			MarketGroupName:   group.Name[models.DefaultLanguage],
+			Description:       group.Description[models.DefaultLanguage],
+			IconID:            models.Int64Ptr(group.IconID),
+			HasTypes:          group.HasTypes,
+			MarketGroupPath:   strings.Join(ids, "/"),
+			RootMarketGroupID: path[0],
+			Names:             group.Name,
+			Descriptions:      group.Description,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].MarketGroupID < result[j].MarketGroupID
+	})
+
+	return result
+}
+
+// marketGroupPath returns the group IDs from the top-level ancestor down to groupID.
+// The walk stops at a missing parent or when it would revisit a group, so broken
+// or cyclic hierarchies still produce a path (reported by validateMarketGroups).
+func marketGroupPath(groupID int64, groups map[int64]parser.SDEMarketGroup) []int64 {
+	path := []int64{groupID}
+	seen := map[int64]bool{groupID: true}
+
+	for id := groupID; ; {
+		parentID := groups[id].ParentGroupID
+		if _, ok := groups[parentID]; parentID == 0 || !ok || seen[parentID] {
+			break
+		}
+		path = append(path, parentID)
+		seen[parentID] = true
+		id = parentID
+	}
+
+	// Reverse so the path reads from the top-level group down
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// buildMarketGroupTree nests market groups under their parents. Groups without
+// a parent, or whose parent is missing, become top-level nodes. Children are
+// sorted by ID.
+func buildMarketGroupTree(groups []models.InvMarketGroup) []models.MarketGroupNode {
+	known := make(map[int64]bool, len(groups))
+	for _, g := range groups {
+		known[g.MarketGroupID] = true
+	}
+
+	children := make(map[int64][]models.InvMarketGroup)
+	var roots []models.InvMarketGroup
+	for _, g := range groups {
+		if g.ParentGroupID == nil || !known[*g.ParentGroupID] {
+			roots = append(roots, g)
+			continue
+		}
+		children[*g.ParentGroupID] = append(children[*g.ParentGroupID], g)
+	}
+
+	var build func(gs []models.InvMarketGroup) []models.MarketGroupNode
+	build = func(gs []models.InvMarketGroup) []models.MarketGroupNode {
+		sort.Slice(gs, func(i, j int) bool { return gs[i].MarketGroupID < gs[j].MarketGroupID })
+		nodes := make([]models.MarketGroupNode, len(gs))
+		for i, g := range gs {
+			nodes[i] = models.MarketGroupNode{
+				MarketGroupID:   g.MarketGroupID,
+				MarketGroupName: g.MarketGroupName,
+				IconID:          g.IconID,
+				HasTypes:        g.HasTypes,
+				Children:        build(children[g.MarketGroupID]),
+			}
+		}
+		return nodes
+	}
+
+	return build(roots)
+}
+
+// validateMarketGroups warns about market groups whose parent is missing or
+// whose ancestry loops back on itself, and about types pointing at unknown groups.
+func validateMarketGroups(data *models.ConvertedData) []string {
+	if len(data.MarketGroups) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]models.InvMarketGroup, len(data.MarketGroups))
+	for _, g := range data.MarketGroups {
+		byID[g.MarketGroupID] = g
+	}
+
+	var warnings []string
+	for _, g := range data.MarketGroups {
+		if g.ParentGroupID == nil {
+			continue
+		}
+		if _, ok := byID[*g.ParentGroupID]; !ok {
+			warnings = append(warnings,
+				fmt.Sprintf("Market group %d references unknown parent group %d", g.MarketGroupID, *g.ParentGroupID))
+			continue
+		}
+		// A group whose top-level ancestor still has a parent is part of a cycle
+		if root := byID[g.RootMarketGroupID]; root.ParentGroupID != nil {
+			if _, ok := byID[*root.ParentGroupID]; ok {
+				warnings = append(warnings,
+					fmt.Sprintf("Market group %d has a cyclic parent chain", g.MarketGroupID))
+			}
+		}
+	}
+
+	var unknownTypes int
+	for _, t := range data.InvTypes {
+		if t.MarketGroupID == nil {
+			continue
+		}
+		if _, ok := byID[*t.MarketGroupID]; !ok {
+			unknownTypes++
+		}
+	}
+	if unknownTypes > 0 {
+		warnings = append(warnings,
+			fmt.Sprintf("%d types reference market groups not in invMarketGroups", unknownTypes))
+	}
+
+	return warnings
+}
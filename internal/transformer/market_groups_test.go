@@ -0,0 +1,88 @@
+package transformer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/parser"
+)
+
+func TestTransformer_TransformMarketGroups(t *testing.T) {
+	tr := New(&config.Config{MarketGroupTree: true})
+
+	parseResult := &parser.ParseResult{
+		MarketGroups: map[int64]parser.SDEMarketGroup{
+			4:    {Name: map[string]string{"en": "Ships"}},
+			9:    {Name: map[string]string{"en": "Ship Equipment"}},
+			391:  {Name: map[string]string{"en": "Frigates"}, ParentGroupID: 4},
+			1374: {Name: map[string]string{"en": "Standard Frigates"}, ParentGroupID: 391, HasTypes: true},
+		},
+	}
+
+	data, err := tr.Transform(parseResult)
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+
+	groups := data.MarketGroups
+	if len(groups) != 4 {
+		t.Fatalf("expected 4 market groups, got %d", len(groups))
+	}
+	leaf := groups[3]
+	if leaf.MarketGroupID != 1374 || leaf.MarketGroupPath != "4/391/1374" || leaf.RootMarketGroupID != 4 {
+		t.Errorf("unexpected leaf group: %+v", leaf)
+	}
+	if leaf.ParentGroupID == nil || *leaf.ParentGroupID != 391 || !leaf.HasTypes {
+		t.Errorf("unexpected leaf parent/hasTypes: %+v", leaf)
+	}
+	if groups[0].MarketGroupPath != "4" || groups[0].ParentGroupID != nil {
+		t.Errorf("unexpected top-level group: %+v", groups[0])
+	}
+
+	tree := data.MarketGroupTree
+	if len(tree) != 2 || tree[0].MarketGroupID != 4 || tree[1].MarketGroupID != 9 {
+		t.Fatalf("unexpected tree roots: %+v", tree)
+	}
+	if len(tree[0].Children) != 1 || len(tree[0].Children[0].Children) != 1 ||
+		tree[0].Children[0].Children[0].MarketGroupName != "Standard Frigates" {
+		t.Errorf("unexpected tree nesting: %+v", tree[0])
+	}
+}
+
+func TestTransformer_ValidateMarketGroups(t *testing.T) {
+	tr := New(&config.Config{})
+
+	groups := map[int64]parser.SDEMarketGroup{
+		1: {ParentGroupID: 2},
+		2: {ParentGroupID: 1},
+		3: {ParentGroupID: 99},
+		4: {},
+	}
+	unknownGroup := int64(500)
+	knownGroup := int64(4)
+	data := &models.ConvertedData{
+		Universe:     &models.UniverseData{},
+		MarketGroups: tr.transformMarketGroups(groups),
+		InvTypes: []models.InvType{
+			{TypeID: 1, MarketGroupID: &knownGroup},
+			{TypeID: 2, MarketGroupID: &unknownGroup},
+		},
+	}
+
+	warnings := strings.Join(tr.Validate(data).Warnings, "\n")
+	for _, want := range []string{
+		"Market group 1 has a cyclic parent chain",
+		"Market group 2 has a cyclic parent chain",
+		"Market group 3 references unknown parent group 99",
+		"1 types reference market groups not in invMarketGroups",
+	} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("missing warning %q in:\n%s", want, warnings)
+		}
+	}
+	if strings.Contains(warnings, "Market group 4") {
+		t.Errorf("unexpected warning for valid group:\n%s", warnings)
+	}
+}
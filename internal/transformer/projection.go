@@ -0,0 +1,23 @@
+package transformer
+
+import "github.com/guarzo/wanderer-sde/internal/models"
+
+// LightYear is one light-year in metres, the unit of projected map coordinates.
+const LightYear = 9_460_730_472_580_800.0
+
+// ProjectXZ projects an SDE position onto the X/Z plane, viewed from above as
+// in the in-game map: x grows east and z grows north. Coordinates are returned
+// in light-years.
+func ProjectXZ(x, z float64) (float64, float64) {
+	return x / LightYear, z / LightYear
+}
+
+// addProjectionColumns sets the projected 2D map position of each solar system.
+func addProjectionColumns(systems []models.SolarSystem) {
+	for i := range systems {
+		sys := &systems[i]
+		px, py := ProjectXZ(sys.X, sys.Z)
+		sys.ProjectedX = &px
+		sys.ProjectedY = &py
+	}
+}
@@ -0,0 +1,36 @@
+package transformer
+
+import (
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+func TestProjectXZ(t *testing.T) {
+	x, y := ProjectXZ(-2*LightYear, 3.5*LightYear)
+	if x != -2 || y != 3.5 {
+		t.Errorf("ProjectXZ = (%v, %v), want (-2, 3.5)", x, y)
+	}
+}
+
+func TestTransformer_ProjectionColumns(t *testing.T) {
+	systems := []models.SolarSystem{
+		{SolarSystemID: 30000142, X: -1.29e17, Y: 6.07e16, Z: 1.17e17},
+	}
+
+	// Disabled by default
+	plain := New(&config.Config{}).transformSolarSystems(systems)
+	if plain[0].ProjectedX != nil || plain[0].ProjectedY != nil {
+		t.Error("projection columns should not be set unless enabled")
+	}
+
+	result := New(&config.Config{ProjectionColumns: true}).transformSolarSystems(systems)
+	sys := result[0]
+	if sys.ProjectedX == nil || sys.ProjectedY == nil {
+		t.Fatal("expected projected coordinates")
+	}
+	if *sys.ProjectedX != -1.29e17/LightYear || *sys.ProjectedY != 1.17e17/LightYear {
+		t.Errorf("projected = (%v, %v), want x and z in light-years", *sys.ProjectedX, *sys.ProjectedY)
+	}
+}
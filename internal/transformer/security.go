@@ -2,7 +2,19 @@
 // to Wanderer's format.
 package transformer
 
-import "math"
+import (
+	"math"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+const (
+	// PochvenRegionID is the region of the Triglavian-held Pochven systems.
+	PochvenRegionID = 10000070
+
+	// ZarzakhSystemID is the solar system ID of Zarzakh.
+	ZarzakhSystemID = 30100000
+)
 
 // TruncateToTwoDigits truncates a float to 2 decimal places.
 func TruncateToTwoDigits(value float64) float64 {
@@ -41,3 +53,64 @@ func GetTrueSecurity(security float64) float64 {
 func RoundSecurity(security float64) float64 {
 	return math.Round(security*10) / 10
 }
+
+// IsWormholeSystemID reports whether a solar system ID lies in the wormhole space
+// range (31000000-31999999), which includes Thera and the Drifter systems.
+func IsWormholeSystemID(systemID int64) bool {
+	return systemID >= 31000000 && systemID < 32000000
+}
+
+// IsAbyssalSystemID reports whether a solar system ID lies in the Abyssal
+// Deadspace range (32000000-32999999).
+func IsAbyssalSystemID(systemID int64) bool {
+	return systemID >= 32000000 && systemID < 33000000
+}
+
+// SecurityBand classifies a solar system. Abyssal, wormhole, Zarzakh and Pochven
+// systems are recognised by ID; the rest are banded by display security, so a
+// raw 0.45 counts as highsec just as the game shows it as 0.5.
+func SecurityBand(systemID, regionID int64, security float64) string {
+	switch {
+	case IsAbyssalSystemID(systemID):
+		return models.SecurityBandAbyssal
+	case IsWormholeSystemID(systemID):
+		return models.SecurityBandWormhole
+	case systemID == ZarzakhSystemID:
+		return models.SecurityBandZarzakh
+	case regionID == PochvenRegionID:
+		return models.SecurityBandPochven
+	}
+
+	display := DisplaySecurity(security)
+	switch {
+	case display >= 0.5:
+		return models.SecurityBandHighsec
+	case display > 0.0:
+		return models.SecurityBandLowsec
+	default:
+		return models.SecurityBandNullsec
+	}
+}
+
+// DisplaySecurity returns the security shown in game, without the float noise
+// of the rounding steps and with -0.0 normalised to 0.0.
+func DisplaySecurity(security float64) float64 {
+	display := RoundSecurity(GetTrueSecurity(security))
+	if display == 0 {
+		return 0
+	}
+	return display
+}
+
+// addSecurityColumns sets the derived display security, band and wormhole space
+// flag on each solar system.
+func addSecurityColumns(systems []models.SolarSystem) {
+	for i := range systems {
+		sys := &systems[i]
+		display := DisplaySecurity(sys.Security)
+		wormhole := IsWormholeSystemID(sys.SolarSystemID)
+		sys.DisplaySecurity = &display
+		sys.SecurityBand = SecurityBand(sys.SolarSystemID, sys.RegionID, sys.Security)
+		sys.WormholeSpace = &wormhole
+	}
+}
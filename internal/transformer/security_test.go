@@ -3,6 +3,9 @@ package transformer
 import (
 	"math"
 	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
 )
 
 func TestGetTrueSecurity(t *testing.T) {
@@ -92,3 +95,95 @@ func TestRoundSecurity(t *testing.T) {
 		}
 	}
 }
+
+func TestSecurityBand(t *testing.T) {
+	tests := []struct {
+		name     string
+		systemID int64
+		regionID int64
+		security float64
+		expected string
+	}{
+		{"Jita", 30000142, 10000002, 0.9459, models.SecurityBandHighsec},
+		{"Rounds up to highsec", 30000001, 10000001, 0.45, models.SecurityBandHighsec},
+		{"Lowsec", 30000002, 10000001, 0.44, models.SecurityBandLowsec},
+		{"Low positive shows as 0.1", 30000003, 10000001, 0.01, models.SecurityBandLowsec},
+		{"Zero is nullsec", 30000004, 10000001, 0.0, models.SecurityBandNullsec},
+		{"Slightly negative is nullsec", 30000005, 10000001, -0.04, models.SecurityBandNullsec},
+		{"Deep null", 30000006, 10000001, -0.99, models.SecurityBandNullsec},
+		{"Wormhole", 31000001, 11000001, -0.99, models.SecurityBandWormhole},
+		{"Thera", 31000005, 11000031, -0.99, models.SecurityBandWormhole},
+		{"Abyssal", 32000001, 12000001, -0.99, models.SecurityBandAbyssal},
+		{"Pochven", 30000157, PochvenRegionID, -1.0, models.SecurityBandPochven},
+		{"Zarzakh", ZarzakhSystemID, 10001000, -1.0, models.SecurityBandZarzakh},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SecurityBand(tt.systemID, tt.regionID, tt.security); got != tt.expected {
+				t.Errorf("SecurityBand(%d, %d, %v) = %q, want %q", tt.systemID, tt.regionID, tt.security, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsWormholeSystemID(t *testing.T) {
+	tests := []struct {
+		systemID int64
+		expected bool
+	}{
+		{30000142, false},
+		{30999999, false},
+		{31000000, true},
+		{31002604, true},
+		{31999999, true},
+		{32000001, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsWormholeSystemID(tt.systemID); got != tt.expected {
+			t.Errorf("IsWormholeSystemID(%d) = %v, want %v", tt.systemID, got, tt.expected)
+		}
+	}
+}
+
+func TestTransformer_SecurityColumns(t *testing.T) {
+	systems := []models.SolarSystem{
+		{RegionID: 10000001, SolarSystemID: 30000005, Security: -0.04},
+		{RegionID: 10000002, SolarSystemID: 30000142, Security: 0.9459},
+		{RegionID: 11000001, SolarSystemID: 31000001, Security: -0.99},
+	}
+
+	// Disabled by default
+	plain := New(&config.Config{}).transformSolarSystems(systems)
+	if plain[0].DisplaySecurity != nil || plain[0].SecurityBand != "" || plain[0].WormholeSpace != nil {
+		t.Errorf("expected no security columns by default, got %+v", plain[0])
+	}
+
+	result := New(&config.Config{SecurityColumns: true}).transformSolarSystems(systems)
+	expected := []struct {
+		display  float64
+		band     string
+		wormhole bool
+	}{
+		{0.0, models.SecurityBandNullsec, false},
+		{0.9, models.SecurityBandHighsec, false},
+		{-1.0, models.SecurityBandWormhole, true},
+	}
+	for i, want := range expected {
+		sys := result[i]
+		if sys.DisplaySecurity == nil || *sys.DisplaySecurity != want.display || (want.display == 0 && math.Signbit(*sys.DisplaySecurity)) {
+			t.Errorf("system %d: DisplaySecurity = %s, want %v", sys.SolarSystemID, sys.SecurityCSVRow()[0], want.display)
+		}
+		if sys.SecurityBand != want.band {
+			t.Errorf("system %d: SecurityBand = %q, want %q", sys.SolarSystemID, sys.SecurityBand, want.band)
+		}
+		if sys.WormholeSpace == nil || *sys.WormholeSpace != want.wormhole {
+			t.Errorf("system %d: WormholeSpace = %v, want %v", sys.SolarSystemID, sys.WormholeSpace, want.wormhole)
+		}
+		// The raw security is kept
+		if sys.Security != systems[i].Security {
+			t.Errorf("system %d: Security = %v, want raw %v", sys.SolarSystemID, sys.Security, systems[i].Security)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package transformer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// validateStargates checks that every stargate's destination gate exists and
+// leads back to it, reporting one summarised warning per kind of problem.
+func validateStargates(data *models.ConvertedData) []string {
+	byID := make(map[int64]*models.Stargate, len(data.Stargates))
+	for i := range data.Stargates {
+		byID[data.Stargates[i].StargateID] = &data.Stargates[i]
+	}
+
+	var missing, unreciprocated []string
+	for _, gate := range data.Stargates {
+		dest, ok := byID[gate.DestinationStargateID]
+		if !ok {
+			missing = append(missing, fmt.Sprintf("%d -> %d", gate.StargateID, gate.DestinationStargateID))
+			continue
+		}
+		if dest.DestinationStargateID != gate.StargateID || dest.SolarSystemID != gate.DestinationSolarSystemID ||
+			dest.DestinationSolarSystemID != gate.SolarSystemID {
+			unreciprocated = append(unreciprocated, fmt.Sprintf("%d -> %d -> %d",
+				gate.StargateID, dest.StargateID, dest.DestinationStargateID))
+		}
+	}
+
+	var warnings []string
+	if len(missing) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d stargates lead to unknown destination gates (e.g. %s)",
+			len(missing), strings.Join(examples(missing), ", ")))
+	}
+	if len(unreciprocated) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d stargates are not linked back by their destination gate (e.g. %s)",
+			len(unreciprocated), strings.Join(examples(unreciprocated), ", ")))
+	}
+	return warnings
+}
@@ -0,0 +1,65 @@
+package transformer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/parser"
+)
+
+func TestTransformer_Stargates(t *testing.T) {
+	parsed := &parser.ParseResult{
+		Stargates: []models.Stargate{
+			{StargateID: 50000001, SolarSystemID: 30000142, DestinationStargateID: 50000002, DestinationSolarSystemID: 30000144},
+			{StargateID: 50000002, SolarSystemID: 30000144, DestinationStargateID: 50000001, DestinationSolarSystemID: 30000142},
+		},
+	}
+
+	data, err := New(&config.Config{}).Transform(parsed)
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+	if len(data.Stargates) != 2 || data.Stargates[0].StargateID != 50000001 {
+		t.Errorf("expected the parsed stargates in ID order, got %+v", data.Stargates)
+	}
+	if warnings := validateStargates(data); len(warnings) != 0 {
+		t.Errorf("expected no warnings for reciprocal gates, got %v", warnings)
+	}
+
+	data, err = New(&config.Config{Tables: []string{config.TableJumps}}).Transform(parsed)
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+	if data.Stargates != nil {
+		t.Errorf("expected no stargates when the table is disabled, got %d", len(data.Stargates))
+	}
+}
+
+func TestValidateStargates(t *testing.T) {
+	data := &models.ConvertedData{
+		Stargates: []models.Stargate{
+			// Reciprocal pair
+			{StargateID: 1, SolarSystemID: 10, DestinationStargateID: 2, DestinationSolarSystemID: 20},
+			{StargateID: 2, SolarSystemID: 20, DestinationStargateID: 1, DestinationSolarSystemID: 10},
+			// 3 leads to 4, but 4 leads on to 1
+			{StargateID: 3, SolarSystemID: 10, DestinationStargateID: 4, DestinationSolarSystemID: 30},
+			{StargateID: 4, SolarSystemID: 30, DestinationStargateID: 1, DestinationSolarSystemID: 10},
+			// 5 leads to a gate that does not exist
+			{StargateID: 5, SolarSystemID: 10, DestinationStargateID: 99, DestinationSolarSystemID: 40},
+		},
+	}
+
+	warnings := validateStargates(data)
+	if len(warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0], "1 stargates lead to unknown destination gates (e.g. 5 -> 99)") {
+		t.Errorf("unexpected missing gate warning: %s", warnings[0])
+	}
+	if !strings.Contains(warnings[1], "2 stargates are not linked back") ||
+		!strings.Contains(warnings[1], "3 -> 4 -> 1") || !strings.Contains(warnings[1], "4 -> 1 -> 2") {
+		t.Errorf("unexpected reciprocity warning: %s", warnings[1])
+	}
+}
@@ -0,0 +1,158 @@
+package transformer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/parser"
+)
+
+// transformStations converts NPC stations to staStations rows, joining each
+// station to its solar system for security, constellation and region.
+func (t *Transformer) transformStations(parseResult *parser.ParseResult, index *models.Dataset) []models.StaStation {
+	result := make([]models.StaStation, 0, len(parseResult.NPCStations))
+
+	for stationID, station := range parseResult.NPCStations {
+		sys, _ := index.SolarSystem(station.SolarSystemID)
+
+		sta := models.StaStation{
+			StationID:                stationID,
+			Security:                 sys.Security,
+			OperationID:              station.OperationID,
+			StationTypeID:            station.TypeID,
+			CorporationID:            station.OwnerID,
+			SolarSystemID:            station.SolarSystemID,
+			ConstellationID:          sys.ConstellationID,
+			RegionID:                 sys.RegionID,
+			StationName:              stationName(station, sys, parseResult),
+			ReprocessingEfficiency:   station.ReprocessingEfficiency,
+			ReprocessingStationsTake: station.ReprocessingStationsTake,
+			ReprocessingHangarFlag:   station.ReprocessingHangarFlag,
+		}
+		if station.Position != nil {
+			sta.X = station.Position.X
+			sta.Y = station.Position.Y
+			sta.Z = station.Position.Z
+		}
+
+		result = append(result, sta)
+	}
+
+	// Sort by station ID for consistent output
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StationID < result[j].StationID
+	})
+
+	return result
+}
+
+// stationName generates the in-game station name from its orbit, owner and operation,
+// e.g. "Jita IV - Moon 4 - Caldari Navy Assembly Plant".
+func stationName(station parser.SDENPCStation, sys models.SolarSystem, parseResult *parser.ParseResult) string {
+	var b strings.Builder
+	b.WriteString(sys.SolarSystemName)
+	if station.CelestialIndex > 0 {
+		b.WriteString(" " + romanNumeral(station.CelestialIndex))
+	}
+	if station.OrbitIndex > 0 {
+		fmt.Fprintf(&b, " - Moon %d", station.OrbitIndex)
+	}
+
+	if corp, ok := parseResult.NPCCorporations[station.OwnerID]; ok {
+		if name := corp.Name[models.DefaultLanguage]; name != "" {
+			b.WriteString(" - " + name)
+		}
+	}
+	if station.UseOperationName {
+		if op, ok := parseResult.StationOperations[station.OperationID]; ok {
+			if name := op.OperationName[models.DefaultLanguage]; name != "" {
+				b.WriteString(" " + name)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// romanNumeral formats a celestial index as a roman numeral (1 -> I, 4 -> IV).
+func romanNumeral(n int64) string {
+	numerals := []struct {
+		value  int64
+		symbol string
+	}{
+		{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"},
+		{100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"},
+		{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+	}
+
+	var b strings.Builder
+	for _, num := range numerals {
+		for n >= num.value {
+			b.WriteString(num.symbol)
+			n -= num.value
+		}
+	}
+	return b.String()
+}
+
+// transformStationServices converts station services to staServices rows sorted by ID.
+func (t *Transformer) transformStationServices(services map[int64]parser.SDEStationService) []models.StaService {
+	result := make([]models.StaService, 0, len(services))
+
+	for serviceID, service := range services {
+		result = append(result, models.StaService{
+			ServiceID:   serviceID,
+			ServiceName: service.ServiceName[models.DefaultLanguage],
+			Description: service.Description[models.DefaultLanguage],
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ServiceID < result[j].ServiceID
+	})
+
+	return result
+}
+
+// transformOperationServices flattens the services offered by each station operation
+// into staOperationServices rows sorted by operation then service ID.
+func (t *Transformer) transformOperationServices(operations map[int64]parser.SDEStationOperation) []models.StaOperationService {
+	var result []models.StaOperationService
+
+	for operationID, op := range operations {
+		for _, serviceID := range op.Services {
+			result = append(result, models.StaOperationService{
+				OperationID: operationID,
+				ServiceID:   serviceID,
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].OperationID != result[j].OperationID {
+			return result[i].OperationID < result[j].OperationID
+		}
+		return result[i].ServiceID < result[j].ServiceID
+	})
+
+	return result
+}
+
+// validateStations warns about stations whose solar system is not in the output.
+func validateStations(data *models.ConvertedData) []string {
+	if data.Universe == nil || len(data.Universe.SolarSystems) == 0 {
+		return nil
+	}
+
+	index := universeIndex(nil, nil, data.Universe.SolarSystems)
+	var warnings []string
+	for _, sta := range data.Stations {
+		if _, ok := index.SolarSystem(sta.SolarSystemID); !ok {
+			warnings = append(warnings,
+				fmt.Sprintf("Station %d references unknown solar system %d", sta.StationID, sta.SolarSystemID))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,87 @@
+package transformer
+
+import (
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/parser"
+)
+
+func TestTransformer_TransformStations(t *testing.T) {
+	tr := New(&config.Config{})
+
+	systems := []models.SolarSystem{
+		{SolarSystemID: 30000142, SolarSystemName: "Jita", RegionID: 10000002, ConstellationID: 20000020, Security: 0.9459},
+	}
+	parseResult := &parser.ParseResult{
+		NPCStations: map[int64]parser.SDENPCStation{
+			60003760: {
+				SolarSystemID: 30000142, OperationID: 26, OwnerID: 1000035, TypeID: 52678,
+				CelestialIndex: 4, OrbitIndex: 4, UseOperationName: true,
+				Position: &parser.SDEPosition{X: 1, Y: 2, Z: 3},
+			},
+			60000001: {SolarSystemID: 30000142, OperationID: 27, OwnerID: 1000035, CelestialIndex: 9},
+		},
+		StationOperations: map[int64]parser.SDEStationOperation{
+			26: {OperationName: map[string]string{"en": "Assembly Plant"}},
+			27: {OperationName: map[string]string{"en": "Logistic Support"}},
+		},
+		NPCCorporations: map[int64]parser.SDENPCCorporation{
+			1000035: {Name: map[string]string{"en": "Caldari Navy"}},
+		},
+	}
+
+	stations := tr.transformStations(parseResult, universeIndex(nil, nil, systems))
+	if len(stations) != 2 {
+		t.Fatalf("expected 2 stations, got %d", len(stations))
+	}
+
+	// Sorted by station ID; the second station does not use its operation name
+	if stations[0].StationID != 60000001 || stations[0].StationName != "Jita IX - Caldari Navy" {
+		t.Errorf("unexpected first station: %d %q", stations[0].StationID, stations[0].StationName)
+	}
+
+	sta := stations[1]
+	if sta.StationName != "Jita IV - Moon 4 - Caldari Navy Assembly Plant" {
+		t.Errorf("unexpected station name %q", sta.StationName)
+	}
+	if sta.RegionID != 10000002 || sta.ConstellationID != 20000020 || sta.Security != 0.9459 {
+		t.Errorf("system join not applied: %+v", sta)
+	}
+	if sta.CorporationID != 1000035 || sta.StationTypeID != 52678 || sta.X != 1 || sta.Z != 3 {
+		t.Errorf("unexpected station fields: %+v", sta)
+	}
+}
+
+func TestTransformer_TransformOperationServices(t *testing.T) {
+	tr := New(&config.Config{})
+
+	links := tr.transformOperationServices(map[int64]parser.SDEStationOperation{
+		27: {Services: []int64{16, 5}},
+		26: {Services: []int64{5}},
+	})
+
+	want := []models.StaOperationService{
+		{OperationID: 26, ServiceID: 5},
+		{OperationID: 27, ServiceID: 5},
+		{OperationID: 27, ServiceID: 16},
+	}
+	if len(links) != len(want) {
+		t.Fatalf("expected %d links, got %d", len(want), len(links))
+	}
+	for i := range want {
+		if links[i] != want[i] {
+			t.Errorf("link %d = %+v, want %+v", i, links[i], want[i])
+		}
+	}
+}
+
+func TestRomanNumeral(t *testing.T) {
+	tests := map[int64]string{1: "I", 4: "IV", 9: "IX", 14: "XIV", 40: "XL"}
+	for n, want := range tests {
+		if got := romanNumeral(n); got != want {
+			t.Errorf("romanNumeral(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
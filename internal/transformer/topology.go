@@ -0,0 +1,333 @@
+package transformer
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// minPipeLength is the number of consecutive two-gate systems that makes a pipe.
+const minPipeLength = 2
+
+// topologyReportTop is the number of systems ranked by betweenness in the report.
+const topologyReportTop = 20
+
+// gateNetwork is the undirected stargate graph over the solar systems, with
+// systems referred to by their position in the systems slice.
+type gateNetwork struct {
+	systems []models.SolarSystem
+	adj     [][]int
+}
+
+// newGateNetwork builds the gate network from the indexed neighbours, merging
+// both directions of each gate. Gates to unknown systems are ignored.
+func newGateNetwork(index *models.Dataset) *gateNetwork {
+	systems := index.Data().Universe.SolarSystems
+	pos := make(map[int64]int, len(systems))
+	for i, s := range systems {
+		pos[s.SolarSystemID] = i
+	}
+
+	adj := make([][]int, len(systems))
+	for from, s := range systems {
+		for _, id := range index.Neighbours(s.SolarSystemID) {
+			to, ok := pos[id]
+			if !ok || from == to {
+				continue
+			}
+			adj[from] = append(adj[from], to)
+			adj[to] = append(adj[to], from)
+		}
+	}
+	for i := range adj {
+		slices.Sort(adj[i])
+		adj[i] = slices.Compact(adj[i])
+	}
+
+	return &gateNetwork{systems: systems, adj: adj}
+}
+
+// systemTopology analyses the gate network, returning one row per solar system
+// and the summary report.
+func systemTopology(index *models.Dataset) ([]models.SystemTopology, *models.TopologyReport) {
+	n := newGateNetwork(index)
+	cut, bridges := n.cutsAndBridges()
+	pipes := n.pipes()
+	betweenness := n.betweenness()
+
+	rows := make([]models.SystemTopology, len(n.systems))
+	report := &models.TopologyReport{
+		ArticulationPoints: []int64{},
+		Bridges:            []models.TopologyBridge{},
+		DeadEnds:           []int64{},
+		Pipes:              []models.TopologyPipe{},
+		MostCentral:        []models.TopologyRank{},
+	}
+	for i, s := range n.systems {
+		degree := len(n.adj[i])
+		rows[i] = models.SystemTopology{
+			SolarSystemID:     s.SolarSystemID,
+			RegionID:          s.RegionID,
+			GateCount:         int64(degree),
+			ArticulationPoint: cut[i],
+			DeadEnd:           degree == 1,
+			Betweenness:       betweenness[i],
+		}
+		if degree > 0 {
+			report.Systems++
+			report.Gates += degree
+		}
+		if cut[i] {
+			report.ArticulationPoints = append(report.ArticulationPoints, s.SolarSystemID)
+		}
+		if degree == 1 {
+			report.DeadEnds = append(report.DeadEnds, s.SolarSystemID)
+		}
+	}
+	report.Gates /= 2
+	report.Components = n.components()
+
+	for _, b := range bridges {
+		rows[b[0]].BridgeCount++
+		rows[b[1]].BridgeCount++
+		report.Bridges = append(report.Bridges, models.TopologyBridge{
+			FromSolarSystemID: n.systems[b[0]].SolarSystemID,
+			ToSolarSystemID:   n.systems[b[1]].SolarSystemID,
+		})
+	}
+	slices.SortFunc(report.Bridges, func(a, b models.TopologyBridge) int {
+		return cmp.Or(cmp.Compare(a.FromSolarSystemID, b.FromSolarSystemID), cmp.Compare(a.ToSolarSystemID, b.ToSolarSystemID))
+	})
+
+	for _, pipe := range pipes {
+		ids := make([]int64, len(pipe))
+		for k, i := range pipe {
+			ids[k] = n.systems[i].SolarSystemID
+		}
+		pipeID := slices.Min(ids)
+		for _, i := range pipe {
+			rows[i].PipeID = models.Int64PtrAlways(pipeID)
+			rows[i].PipeLength = int64(len(pipe))
+		}
+		report.Pipes = append(report.Pipes, models.TopologyPipe{PipeID: pipeID, SolarSystemIDs: ids})
+	}
+	slices.SortFunc(report.Pipes, func(a, b models.TopologyPipe) int {
+		return cmp.Compare(a.PipeID, b.PipeID)
+	})
+
+	ranked := make([]int, 0, len(n.systems))
+	for i := range n.systems {
+		if betweenness[i] > 0 {
+			ranked = append(ranked, i)
+		}
+	}
+	slices.SortFunc(ranked, func(a, b int) int {
+		return cmp.Or(cmp.Compare(betweenness[b], betweenness[a]),
+			cmp.Compare(n.systems[a].SolarSystemID, n.systems[b].SolarSystemID))
+	})
+	for _, i := range ranked[:min(len(ranked), topologyReportTop)] {
+		s := n.systems[i]
+		report.MostCentral = append(report.MostCentral, models.TopologyRank{
+			SolarSystemID:   s.SolarSystemID,
+			SolarSystemName: s.SolarSystemName,
+			RegionID:        s.RegionID,
+			Betweenness:     betweenness[i],
+		})
+	}
+
+	return rows, report
+}
+
+// cutsAndBridges finds the articulation points and bridges with Tarjan's
+// depth-first search, returning bridges as pairs of positions in ascending order.
+func (n *gateNetwork) cutsAndBridges() ([]bool, [][2]int) {
+	cut := make([]bool, len(n.systems))
+	disc := make([]int, len(n.systems)) // discovery time, 0 if unvisited
+	low := make([]int, len(n.systems))
+	var bridges [][2]int
+	timer := 0
+
+	var visit func(u, parent int)
+	visit = func(u, parent int) {
+		timer++
+		disc[u], low[u] = timer, timer
+		children := 0
+		for _, v := range n.adj[u] {
+			if disc[v] == 0 {
+				children++
+				visit(v, u)
+				low[u] = min(low[u], low[v])
+				if parent >= 0 && low[v] >= disc[u] {
+					cut[u] = true
+				}
+				if low[v] > disc[u] {
+					bridges = append(bridges, [2]int{min(u, v), max(u, v)})
+				}
+			} else if v != parent {
+				low[u] = min(low[u], disc[v])
+			}
+		}
+		if parent < 0 && children > 1 {
+			cut[u] = true
+		}
+	}
+
+	for i := range n.systems {
+		if disc[i] == 0 {
+			visit(i, -1)
+		}
+	}
+	return cut, bridges
+}
+
+// pipes returns the chains of at least minPipeLength connected systems that
+// each have exactly two gate neighbours, walked from the end with the lower
+// system ID (or from the lowest ID for a closed ring).
+func (n *gateNetwork) pipes() [][]int {
+	inPipe := func(i int) bool { return len(n.adj[i]) == 2 }
+	seen := make([]bool, len(n.systems))
+
+	var result [][]int
+	for start := range n.systems {
+		if seen[start] || !inPipe(start) {
+			continue
+		}
+
+		// Collect the chain around start
+		chain := []int{start}
+		seen[start] = true
+		for k := 0; k < len(chain); k++ {
+			for _, v := range n.adj[chain[k]] {
+				if !seen[v] && inPipe(v) {
+					seen[v] = true
+					chain = append(chain, v)
+				}
+			}
+		}
+		if len(chain) < minPipeLength {
+			continue
+		}
+
+		// Walk it in route order from its first end
+		first := slices.MinFunc(chain, func(a, b int) int {
+			return cmp.Or(cmp.Compare(n.chainLinks(a), n.chainLinks(b)),
+				cmp.Compare(n.systems[a].SolarSystemID, n.systems[b].SolarSystemID))
+		})
+		ordered := []int{first}
+		prev, cur := -1, first
+		for len(ordered) < len(chain) {
+			next := -1
+			for _, v := range n.adj[cur] {
+				if v != prev && inPipe(v) && !slices.Contains(ordered, v) {
+					next = v
+					break
+				}
+			}
+			if next < 0 {
+				break
+			}
+			ordered = append(ordered, next)
+			prev, cur = cur, next
+		}
+		result = append(result, ordered)
+	}
+	return result
+}
+
+// chainLinks counts the neighbours of i that also have two gate neighbours,
+// which is 1 at the ends of a pipe and 2 inside it.
+func (n *gateNetwork) chainLinks(i int) int {
+	links := 0
+	for _, v := range n.adj[i] {
+		if len(n.adj[v]) == 2 {
+			links++
+		}
+	}
+	return links
+}
+
+// components counts the connected parts of the network with at least one gate.
+func (n *gateNetwork) components() int {
+	seen := make([]bool, len(n.systems))
+	count := 0
+	for start := range n.systems {
+		if seen[start] || len(n.adj[start]) == 0 {
+			continue
+		}
+		count++
+		queue := []int{start}
+		seen[start] = true
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range n.adj[u] {
+				if !seen[v] {
+					seen[v] = true
+					queue = append(queue, v)
+				}
+			}
+		}
+	}
+	return count
+}
+
+// betweenness computes each system's betweenness centrality with Brandes'
+// algorithm, normalised by the number of pairs of other systems with gates.
+func (n *gateNetwork) betweenness() []float64 {
+	size := len(n.systems)
+	result := make([]float64, size)
+	sigma := make([]float64, size) // number of shortest routes from the source
+	dist := make([]int, size)
+	delta := make([]float64, size)
+	order := make([]int, 0, size)
+
+	connected := 0
+	for s := range n.systems {
+		if len(n.adj[s]) == 0 {
+			continue
+		}
+		connected++
+
+		for i := range dist {
+			dist[i] = -1
+			sigma[i] = 0
+			delta[i] = 0
+		}
+		dist[s], sigma[s] = 0, 1
+		order = append(order[:0], s)
+		for k := 0; k < len(order); k++ {
+			u := order[k]
+			for _, v := range n.adj[u] {
+				if dist[v] < 0 {
+					dist[v] = dist[u] + 1
+					order = append(order, v)
+				}
+				if dist[v] == dist[u]+1 {
+					sigma[v] += sigma[u]
+				}
+			}
+		}
+
+		// Accumulate dependencies from the farthest systems back to the source
+		for k := len(order) - 1; k > 0; k-- {
+			w := order[k]
+			for _, v := range n.adj[w] {
+				if dist[v] == dist[w]-1 {
+					delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
+				}
+			}
+			result[w] += delta[w]
+		}
+	}
+
+	// Each pair of systems was counted once from either end, matching the
+	// (connected-1)*(connected-2)/2 pairs an undirected system can lie between
+	if connected > 2 {
+		scale := 1 / float64((connected-1)*(connected-2))
+		for i := range result {
+			result[i] *= scale
+		}
+	}
+	return result
+}
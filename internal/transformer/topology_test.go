@@ -0,0 +1,134 @@
+package transformer
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/parser"
+)
+
+// topologyParseResult has a triangle 1-2-3 joined to 6 through the pipe 4-5,
+// with dead ends 7 and 8 hanging off 6, and 9 without gates.
+func topologyParseResult() *parser.ParseResult {
+	var systems []models.SolarSystem
+	for id := int64(1); id <= 9; id++ {
+		systems = append(systems, models.SolarSystem{RegionID: 10, ConstellationID: 20, SolarSystemID: id})
+	}
+	jumps := gateJumps([2]int64{1, 2}, [2]int64{2, 3}, [2]int64{3, 1}, [2]int64{3, 4},
+		[2]int64{4, 5}, [2]int64{5, 6}, [2]int64{6, 7}, [2]int64{6, 8})
+	jumps = append(jumps, models.SystemJump{FromSolarSystemID: 8, ToSolarSystemID: unknownSystemID})
+
+	return &parser.ParseResult{SolarSystems: systems, SystemJumps: jumps}
+}
+
+func TestTransformer_SystemTopology(t *testing.T) {
+	tr := New(&config.Config{Tables: []string{config.TableSystemTopology}})
+
+	data, err := tr.Transform(topologyParseResult())
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+	if len(data.SystemTopology) != 9 {
+		t.Fatalf("expected 9 rows, got %d", len(data.SystemTopology))
+	}
+
+	want := []struct {
+		gates   int64
+		cut     bool
+		bridges int64
+		deadEnd bool
+		pipeID  int64
+		pairs   float64 // pairs of other systems routed through this one
+	}{
+		{2, false, 0, false, 1, 0},
+		{2, false, 0, false, 1, 0},
+		{3, true, 1, false, 0, 10},
+		{2, true, 2, false, 4, 12},
+		{2, true, 2, false, 4, 12},
+		{3, true, 3, false, 0, 11},
+		{1, false, 1, true, 0, 0},
+		{1, false, 1, true, 0, 0},
+		{0, false, 0, false, 0, 0},
+	}
+	for i, w := range want {
+		row := data.SystemTopology[i]
+		if row.GateCount != w.gates || row.ArticulationPoint != w.cut || row.BridgeCount != w.bridges || row.DeadEnd != w.deadEnd {
+			t.Errorf("system %d: got %+v", row.SolarSystemID, row)
+		}
+		if w.pipeID == 0 {
+			if row.PipeID != nil || row.PipeLength != 0 {
+				t.Errorf("system %d: expected no pipe, got %v of length %d", row.SolarSystemID, row.PipeID, row.PipeLength)
+			}
+		} else if row.PipeID == nil || *row.PipeID != w.pipeID || row.PipeLength != 2 {
+			t.Errorf("system %d: expected pipe %d of length 2, got %v", row.SolarSystemID, w.pipeID, row.PipeID)
+		}
+		// 8 systems with gates have 21 pairs of others
+		if wantBetweenness := w.pairs / 21; math.Abs(row.Betweenness-wantBetweenness) > 1e-12 {
+			t.Errorf("system %d: betweenness = %v, want %v", row.SolarSystemID, row.Betweenness, wantBetweenness)
+		}
+	}
+
+	report := data.TopologyReport
+	if report == nil {
+		t.Fatal("expected a topology report")
+	}
+	if report.Systems != 8 || report.Gates != 8 || report.Components != 1 {
+		t.Errorf("report counts = %d systems, %d gates, %d components", report.Systems, report.Gates, report.Components)
+	}
+	if !slices.Equal(report.ArticulationPoints, []int64{3, 4, 5, 6}) {
+		t.Errorf("ArticulationPoints = %v", report.ArticulationPoints)
+	}
+	if !slices.Equal(report.DeadEnds, []int64{7, 8}) {
+		t.Errorf("DeadEnds = %v", report.DeadEnds)
+	}
+	var wantBridges []models.TopologyBridge
+	for _, b := range [][2]int64{{3, 4}, {4, 5}, {5, 6}, {6, 7}, {6, 8}} {
+		wantBridges = append(wantBridges, models.TopologyBridge{FromSolarSystemID: b[0], ToSolarSystemID: b[1]})
+	}
+	if !slices.Equal(report.Bridges, wantBridges) {
+		t.Errorf("Bridges = %v, want %v", report.Bridges, wantBridges)
+	}
+	if len(report.Pipes) != 2 || !slices.Equal(report.Pipes[1].SolarSystemIDs, []int64{4, 5}) {
+		t.Errorf("Pipes = %+v", report.Pipes)
+	}
+	var central []int64
+	for _, r := range report.MostCentral {
+		central = append(central, r.SolarSystemID)
+	}
+	if !slices.Equal(central, []int64{4, 5, 6, 3}) {
+		t.Errorf("MostCentral = %v, want [4 5 6 3]", central)
+	}
+}
+
+func TestTransformer_SystemTopology_TableDisabled(t *testing.T) {
+	tr := New(&config.Config{Tables: []string{config.TableSystems}})
+
+	data, err := tr.Transform(topologyParseResult())
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+	if data.SystemTopology != nil || data.TopologyReport != nil {
+		t.Error("expected no topology when the table is disabled")
+	}
+}
+
+func TestGateNetwork_RingPipe(t *testing.T) {
+	systems := []models.SolarSystem{{SolarSystemID: 1}, {SolarSystemID: 2}, {SolarSystemID: 3}, {SolarSystemID: 4}}
+	n := newGateNetwork(models.NewDataset(&models.ConvertedData{
+		Universe:    &models.UniverseData{SolarSystems: systems},
+		SystemJumps: gateJumps([2]int64{1, 2}, [2]int64{2, 3}, [2]int64{3, 4}, [2]int64{4, 1}),
+	}))
+
+	pipes := n.pipes()
+	if len(pipes) != 1 || !slices.Equal(pipes[0], []int{0, 1, 2, 3}) {
+		t.Errorf("pipes = %v, want the whole ring from the lowest ID", pipes)
+	}
+
+	cut, bridges := n.cutsAndBridges()
+	if slices.Contains(cut, true) || len(bridges) != 0 {
+		t.Errorf("a ring has no chokepoints, got %v and bridges %v", cut, bridges)
+	}
+}
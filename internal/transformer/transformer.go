@@ -24,89 +24,268 @@ func New(cfg *config.Config) *Transformer {
 // Transform converts parsed SDE data into Wanderer's output format.
 func (t *Transformer) Transform(parseResult *parser.ParseResult) (*models.ConvertedData, error) {
 	if t.config.Verbose {
-		fmt.Println("Transforming SDE data...")
+		t.config.Logf("Transforming SDE data...\n")
 	}
 
 	// Transform solar systems with security calculation
 	if t.config.Verbose {
-		fmt.Println("  Transforming solar systems...")
+		t.config.Logf("  Transforming solar systems...\n")
 	}
 	systems := t.transformSolarSystems(parseResult.SolarSystems)
 
 	// Sort regions for consistent output
 	if t.config.Verbose {
-		fmt.Println("  Sorting regions...")
+		t.config.Logf("  Sorting regions...\n")
 	}
 	regions := t.sortRegions(parseResult.Regions)
 
 	// Sort constellations for consistent output
 	if t.config.Verbose {
-		fmt.Println("  Sorting constellations...")
+		t.config.Logf("  Sorting constellations...\n")
 	}
 	constellations := t.sortConstellations(parseResult.Constellations)
 
 	// Transform all types
-	if t.config.Verbose {
-		fmt.Println("  Transforming types...")
+	var invTypes []models.InvType
+	if t.config.TableEnabled(config.TableTypes) {
+		if t.config.Verbose {
+			t.config.Logf("  Transforming types...\n")
+		}
+		invTypes = t.transformTypes(parseResult.Types)
 	}
-	invTypes := t.transformTypes(parseResult.Types)
 
 	// Transform all groups
-	if t.config.Verbose {
-		fmt.Println("  Transforming groups...")
+	var invGroups []models.InvGroup
+	if t.config.TableEnabled(config.TableGroups) {
+		if t.config.Verbose {
+			t.config.Logf("  Transforming groups...\n")
+		}
+		invGroups = t.transformGroups(parseResult.Groups)
+	}
+
+	// Categories are parsed alongside types and groups
+	var invCategories []models.InvCategory
+	if parseResult.Categories != nil {
+		invCategories = t.transformCategories(parseResult.Categories)
 	}
-	invGroups := t.transformGroups(parseResult.Groups)
 
-	// Sort wormhole classes for consistent output
+	// Sort wormhole classes for consistent output. They are parsed whenever
+	// solar systems need them, even if the table itself is not written.
 	if t.config.Verbose {
-		fmt.Println("  Sorting wormhole classes...")
+		t.config.Logf("  Sorting wormhole classes...\n")
 	}
 	wormholeClasses := t.sortWormholeClasses(parseResult.WormholeClasses)
 
-	// Transform system jumps with region/constellation lookup
+	// Index the map tables and gate jumps for the joins, bounds and gate graph
+	// below, then fill in each jump's region and constellation through it
+	universe := &models.UniverseData{
+		Regions:        regions,
+		Constellations: constellations,
+		SolarSystems:   systems,
+	}
+	index := models.NewDataset(&models.ConvertedData{
+		Universe:        universe,
+		WormholeClasses: wormholeClasses,
+		SystemJumps:     t.sortSystemJumps(parseResult.SystemJumps),
+	})
+	if t.config.Verbose {
+		t.config.Logf("  Transforming system jumps...\n")
+	}
+	enrichSystemJumps(index)
+	enrichedJumps := index.Data().SystemJumps
+
+	// Resolve each system's effective wormhole class through its constellation and region
 	if t.config.Verbose {
-		fmt.Println("  Transforming system jumps...")
+		t.config.Logf("  Resolving system wormhole classes...\n")
+	}
+	resolvedClasses := resolveWormholeClasses(index)
+
+	var systemWormholeClasses []models.SystemWormholeClass
+	if t.config.TableEnabled(config.TableSystemWormholeClasses) {
+		systemWormholeClasses = resolvedClasses
+	}
+
+	// Aggregate system jumps into region and constellation connections
+	var systemJumps []models.SystemJump
+	if t.config.TableEnabled(config.TableJumps) {
+		systemJumps = enrichedJumps
+	}
+
+	var regionJumpRows []models.RegionJump
+	if t.config.TableEnabled(config.TableRegionJumps) {
+		regionJumpRows = regionJumps(enrichedJumps)
+	}
+
+	var constellationJumpRows []models.ConstellationJump
+	if t.config.TableEnabled(config.TableConstellationJumps) {
+		constellationJumpRows = constellationJumps(enrichedJumps)
+	}
+
+	// Stargates arrive sorted by ID from the parser
+	var stargates []models.Stargate
+	if t.config.TableEnabled(config.TableStargates) {
+		stargates = parseResult.Stargates
+	}
+
+	// Find chokepoints, bridges, dead ends, pipes and central systems
+	var topology []models.SystemTopology
+	var topologyReport *models.TopologyReport
+	if t.config.TableEnabled(config.TableSystemTopology) {
+		if t.config.Verbose {
+			t.config.Logf("  Analysing gate network topology...\n")
+		}
+		topology, topologyReport = systemTopology(index)
+	}
+
+	// Transform NPC stations with system lookup and generated names
+	var stations []models.StaStation
+	if t.config.TableEnabled(config.TableStations) {
+		if t.config.Verbose {
+			t.config.Logf("  Transforming NPC stations...\n")
+		}
+		stations = t.transformStations(parseResult, index)
+	}
+
+	var stationServices []models.StaService
+	if t.config.TableEnabled(config.TableStationServices) {
+		stationServices = t.transformStationServices(parseResult.StationServices)
+	}
+
+	var operationServices []models.StaOperationService
+	if t.config.TableEnabled(config.TableOperationServices) {
+		operationServices = t.transformOperationServices(parseResult.StationOperations)
+	}
+
+	// Transform factions, races and NPC corporations
+	var factions []models.ChrFaction
+	if t.config.TableEnabled(config.TableFactions) {
+		if t.config.Verbose {
+			t.config.Logf("  Transforming factions...\n")
+		}
+		factions = t.transformFactions(parseResult)
+	}
+
+	var races []models.ChrRace
+	if t.config.TableEnabled(config.TableRaces) {
+		races = t.transformRaces(parseResult.Races)
+	}
+
+	var corporations []models.CrpNPCCorporation
+	if t.config.TableEnabled(config.TableCorporations) {
+		if t.config.Verbose {
+			t.config.Logf("  Transforming NPC corporations...\n")
+		}
+		corporations = t.transformCorporations(parseResult)
+	}
+
+	// Transform market groups with derived paths and optional tree
+	var marketGroups []models.InvMarketGroup
+	var marketGroupTree []models.MarketGroupNode
+	if t.config.TableEnabled(config.TableMarketGroups) {
+		if t.config.Verbose {
+			t.config.Logf("  Transforming market groups...\n")
+		}
+		marketGroups = t.transformMarketGroups(parseResult.MarketGroups)
+		if t.config.MarketGroupTree {
+			marketGroupTree = buildMarketGroupTree(marketGroups)
+		}
+	}
+
+	// Transform dogma definitions and per-type values, limited to the selected types
+	var dogmaAttributes []models.DgmAttributeType
+	if t.config.TableEnabled(config.TableDogmaAttributes) {
+		dogmaAttributes = t.transformDogmaAttributes(parseResult.DogmaAttributes)
 	}
-	systemJumps := t.transformSystemJumps(parseResult.SystemJumps, systems)
 
-	// Calculate bounds for regions and constellations from constituent systems
+	var dogmaEffects []models.DgmEffect
+	if t.config.TableEnabled(config.TableDogmaEffects) {
+		dogmaEffects = t.transformDogmaEffects(parseResult.DogmaEffects)
+	}
+
+	var typeAttributes []models.DgmTypeAttribute
+	var typeEffects []models.DgmTypeEffect
+	if parseResult.TypeDogma != nil {
+		if t.config.Verbose {
+			t.config.Logf("  Transforming type dogma...\n")
+		}
+		// Rows were already limited to Config.DogmaTypes while parsing
+		if t.config.TableEnabled(config.TableTypeAttributes) {
+			typeAttributes = sortTypeAttributes(parseResult.TypeDogma.Attributes)
+		}
+		if t.config.TableEnabled(config.TableTypeEffects) {
+			typeEffects = sortTypeEffects(parseResult.TypeDogma.Effects)
+		}
+	}
+
+	// Calculate bounds for regions and constellations from their constituent
+	// systems. Systems keep the bounds given in the SDE.
 	if t.config.Verbose {
-		fmt.Println("  Calculating region bounds...")
+		t.config.Logf("  Calculating region bounds...\n")
 	}
-	CalculateRegionBounds(regions, systems)
+	regionBounds(index)
 
 	if t.config.Verbose {
-		fmt.Println("  Calculating constellation bounds...")
+		t.config.Logf("  Calculating constellation bounds...\n")
 	}
-	CalculateConstellationBounds(constellations, systems)
+	constellationBounds(index)
 
 	// Inherit factionID from region for systems that don't have one
 	if t.config.Verbose {
-		fmt.Println("  Inheriting faction IDs...")
+		t.config.Logf("  Inheriting faction IDs...\n")
+	}
+	inheritFactionIDs(index)
+
+	// Derive the border, regional, international and hub flags from the stargates,
+	// filling them in where the SDE no longer provides them
+	if t.config.TableEnabled(config.TableSystems) {
+		if t.config.Verbose {
+			t.config.Logf("  Deriving gate flags...\n")
+		}
+		deriveGateFlags(index)
+		fillGateFlags(systems)
 	}
-	InheritFactionIDs(systems, regions)
 
 	result := &models.ConvertedData{
-		Universe: &models.UniverseData{
-			Regions:        regions,
-			Constellations: constellations,
-			SolarSystems:   systems,
-		},
-		InvTypes:        invTypes,
-		InvGroups:       invGroups,
-		WormholeClasses: wormholeClasses,
-		SystemJumps:     systemJumps,
+		Universe:              universe,
+		InvTypes:              invTypes,
+		InvGroups:             invGroups,
+		InvCategories:         invCategories,
+		WormholeClasses:       wormholeClasses,
+		SystemJumps:           systemJumps,
+		SystemWormholeClasses: systemWormholeClasses,
+		RegionJumps:           regionJumpRows,
+		ConstellationJumps:    constellationJumpRows,
+		Stargates:             stargates,
+		SystemTopology:        topology,
+		TopologyReport:        topologyReport,
+		Stations:              stations,
+		StationServices:       stationServices,
+		OperationServices:     operationServices,
+		Factions:              factions,
+		Races:                 races,
+		Corporations:          corporations,
+		MarketGroups:          marketGroups,
+		MarketGroupTree:       marketGroupTree,
+		DogmaAttributes:       dogmaAttributes,
+		DogmaEffects:          dogmaEffects,
+		TypeAttributes:        typeAttributes,
+		TypeEffects:           typeEffects,
 	}
 
 	if t.config.Verbose {
-		fmt.Printf("Transformation complete:\n")
-		fmt.Printf("  Regions:         %d\n", len(result.Universe.Regions))
-		fmt.Printf("  Constellations:  %d\n", len(result.Universe.Constellations))
-		fmt.Printf("  Solar Systems:   %d\n", len(result.Universe.SolarSystems))
-		fmt.Printf("  Types:           %d\n", len(result.InvTypes))
-		fmt.Printf("  Groups:          %d\n", len(result.InvGroups))
-		fmt.Printf("  Wormhole Classes: %d\n", len(result.WormholeClasses))
-		fmt.Printf("  System Jumps:    %d\n", len(result.SystemJumps))
+		t.config.Logf("Transformation complete:\n")
+		t.config.Logf("  Regions:         %d\n", len(result.Universe.Regions))
+		t.config.Logf("  Constellations:  %d\n", len(result.Universe.Constellations))
+		t.config.Logf("  Solar Systems:   %d\n", len(result.Universe.SolarSystems))
+		t.config.Logf("  Types:           %d\n", len(result.InvTypes))
+		t.config.Logf("  Groups:          %d\n", len(result.InvGroups))
+		t.config.Logf("  Wormhole Classes: %d\n", len(result.WormholeClasses))
+		t.config.Logf("  System Jumps:    %d\n", len(result.SystemJumps))
+		t.config.Logf("  Stations:        %d\n", len(result.Stations))
+		t.config.Logf("  Factions:        %d\n", len(result.Factions))
+		t.config.Logf("  Corporations:    %d\n", len(result.Corporations))
+		t.config.Logf("  Market Groups:   %d\n", len(result.MarketGroups))
+		t.config.Logf("  Type Attributes: %d\n", len(result.TypeAttributes))
 	}
 
 	return result, nil
@@ -114,7 +293,8 @@ func (t *Transformer) Transform(parseResult *parser.ParseResult) (*models.Conver
 
 // transformSolarSystems sorts solar systems while preserving all fields.
 // Note: We output raw security values (not rounded) because Wanderer calculates
-// true security itself from the raw value.
+// true security itself from the raw value. The rounded display security is only
+// added as a separate column when security columns are enabled.
 func (t *Transformer) transformSolarSystems(systems []models.SolarSystem) []models.SolarSystem {
 	result := make([]models.SolarSystem, len(systems))
 	copy(result, systems)
@@ -124,6 +304,13 @@ func (t *Transformer) transformSolarSystems(systems []models.SolarSystem) []mode
 		return result[i].SolarSystemID < result[j].SolarSystemID
 	})
 
+	if t.config.SecurityColumns {
+		addSecurityColumns(result)
+	}
+	if t.config.ProjectionColumns {
+		addProjectionColumns(result)
+	}
+
 	return result
 }
 
@@ -184,6 +371,8 @@ func (t *Transformer) transformTypes(types map[int64]models.SDEType) []models.In
 			IconID:        models.Int64Ptr(sdeType.IconID),
 			SoundID:       models.Int64Ptr(sdeType.SoundID),
 			GraphicID:     models.Int64Ptr(sdeType.GraphicID),
+			Names:         sdeType.Name,
+			Descriptions:  sdeType.Description,
 		}
 		result = append(result, invType)
 	}
@@ -211,6 +400,7 @@ func (t *Transformer) transformGroups(groups map[int64]models.SDEGroup) []models
 			Anchorable:           sdeGroup.Anchorable,
 			FittableNonSingleton: sdeGroup.FittableNonSingleton,
 			Published:            sdeGroup.Published,
+			Names:                sdeGroup.Name,
 		}
 		result = append(result, invGroup)
 	}
@@ -223,35 +413,37 @@ func (t *Transformer) transformGroups(groups map[int64]models.SDEGroup) []models
 	return result
 }
 
-// transformSystemJumps enriches system jumps with region and constellation IDs.
-func (t *Transformer) transformSystemJumps(jumps []models.SystemJump, systems []models.SolarSystem) []models.SystemJump {
-	// Build lookup map for system -> region/constellation
-	systemLookup := make(map[int64]models.SolarSystem, len(systems))
-	for _, sys := range systems {
-		systemLookup[sys.SolarSystemID] = sys
+// transformCategories converts SDE categories to InvCategory format.
+func (t *Transformer) transformCategories(categories map[int64]models.SDECategory) []models.InvCategory {
+	result := make([]models.InvCategory, 0, len(categories))
+
+	for categoryID, sdeCategory := range categories {
+		result = append(result, models.InvCategory{
+			CategoryID:   categoryID,
+			CategoryName: sdeCategory.Name["en"],
+			IconID:       models.Int64Ptr(sdeCategory.IconID),
+			Published:    sdeCategory.Published,
+			Names:        sdeCategory.Name,
+		})
 	}
 
-	result := make([]models.SystemJump, 0, len(jumps))
-	for _, jump := range jumps {
-		fromSys, fromOK := systemLookup[jump.FromSolarSystemID]
-		toSys, toOK := systemLookup[jump.ToSolarSystemID]
+	// Sort by category ID for consistent output
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CategoryID < result[j].CategoryID
+	})
+
+	return result
+}
 
-		enrichedJump := models.SystemJump{
+// sortSystemJumps returns the stargate connections sorted by from system ID,
+// then to system ID, keeping only the system IDs.
+func (t *Transformer) sortSystemJumps(jumps []models.SystemJump) []models.SystemJump {
+	result := make([]models.SystemJump, len(jumps))
+	for i, jump := range jumps {
+		result[i] = models.SystemJump{
 			FromSolarSystemID: jump.FromSolarSystemID,
 			ToSolarSystemID:   jump.ToSolarSystemID,
 		}
-
-		if fromOK {
-			enrichedJump.FromRegionID = fromSys.RegionID
-			enrichedJump.FromConstellationID = fromSys.ConstellationID
-		}
-
-		if toOK {
-			enrichedJump.ToRegionID = toSys.RegionID
-			enrichedJump.ToConstellationID = toSys.ConstellationID
-		}
-
-		result = append(result, enrichedJump)
 	}
 
 	// Sort by from system ID, then to system ID for consistent output
@@ -265,6 +457,23 @@ func (t *Transformer) transformSystemJumps(jumps []models.SystemJump, systems []
 	return result
 }
 
+// enrichSystemJumps fills in the region and constellation of both ends of each
+// indexed system jump. Ends in unknown systems are left at zero.
+func enrichSystemJumps(index *models.Dataset) {
+	jumps := index.Data().SystemJumps
+	for i := range jumps {
+		jump := &jumps[i]
+		if fromSys, ok := index.SolarSystem(jump.FromSolarSystemID); ok {
+			jump.FromRegionID = fromSys.RegionID
+			jump.FromConstellationID = fromSys.ConstellationID
+		}
+		if toSys, ok := index.SolarSystem(jump.ToSolarSystemID); ok {
+			jump.ToRegionID = toSys.RegionID
+			jump.ToConstellationID = toSys.ConstellationID
+		}
+	}
+}
+
 // Validate performs validation checks on the converted data.
 func (t *Transformer) Validate(data *models.ConvertedData) *models.ValidationResult {
 	result := &models.ValidationResult{
@@ -275,66 +484,78 @@ func (t *Transformer) Validate(data *models.ConvertedData) *models.ValidationRes
 		InvGroups:       len(data.InvGroups),
 		SystemJumps:     len(data.SystemJumps),
 		WormholeClasses: len(data.WormholeClasses),
+		Stations:        len(data.Stations),
+		Factions:        len(data.Factions),
+		Corporations:    len(data.Corporations),
+		MarketGroups:    len(data.MarketGroups),
+		TypeAttributes:  len(data.TypeAttributes),
 	}
 
-	// Validation thresholds based on known EVE universe size
-	const (
-		minSolarSystems    = 8000
-		minRegions         = 100
-		minConstellations  = 1000
-		minTypes           = 30000 // All types, not just ships
-		minSystemJumps     = 13000 // Bidirectional jumps (A→B and B→A), expected ~13,776
-		minWormholeClasses = 750   // Regions + constellations + systems, expected ~803
-	)
+	// Validation thresholds based on known EVE universe size, overridable via config
+	thresholds := t.config.Thresholds
+
+	// Check minimum counts, ignoring tables that were not generated
+	checks := []struct {
+		table string
+		label string
+		count int
+		min   int
+	}{
+		{config.TableSystems, "Solar system", result.SolarSystems, thresholds.MinSolarSystems},
+		{config.TableRegions, "Region", result.Regions, thresholds.MinRegions},
+		{config.TableConstellations, "Constellation", result.Constellations, thresholds.MinConstellations},
+		{config.TableTypes, "Type", result.InvTypes, thresholds.MinTypes},
+		{config.TableJumps, "System jump", result.SystemJumps, thresholds.MinSystemJumps},
+		{config.TableWormholeClasses, "Wormhole class", result.WormholeClasses, thresholds.MinWormholeClasses},
+	}
+	for _, c := range checks {
+		if t.config.TableEnabled(c.table) && c.count < c.min {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("%s count (%d) is below expected minimum (%d)", c.label, c.count, c.min))
+		}
+	}
 
-	// Check minimum counts
-	if result.SolarSystems < minSolarSystems {
-		result.Warnings = append(result.Warnings,
-			fmt.Sprintf("Solar system count (%d) is below expected minimum (%d)",
-				result.SolarSystems, minSolarSystems))
+	// Check for empty required data
+	if t.config.TableEnabled(config.TableSystems) && result.SolarSystems == 0 {
+		result.Errors = append(result.Errors, "No solar systems found")
 	}
 
-	if result.Regions < minRegions {
-		result.Warnings = append(result.Warnings,
-			fmt.Sprintf("Region count (%d) is below expected minimum (%d)",
-				result.Regions, minRegions))
+	if t.config.TableEnabled(config.TableRegions) && result.Regions == 0 {
+		result.Errors = append(result.Errors, "No regions found")
 	}
 
-	if result.Constellations < minConstellations {
-		result.Warnings = append(result.Warnings,
-			fmt.Sprintf("Constellation count (%d) is below expected minimum (%d)",
-				result.Constellations, minConstellations))
+	if t.config.TableEnabled(config.TableConstellations) && result.Constellations == 0 {
+		result.Errors = append(result.Errors, "No constellations found")
 	}
 
-	if result.InvTypes < minTypes {
-		result.Warnings = append(result.Warnings,
-			fmt.Sprintf("Type count (%d) is below expected minimum (%d)",
-				result.InvTypes, minTypes))
+	// Stations are optional in older SDE builds; only check the system join
+	if t.config.TableEnabled(config.TableStations) {
+		result.Warnings = append(result.Warnings, validateStations(data)...)
 	}
 
-	if result.SystemJumps < minSystemJumps {
-		result.Warnings = append(result.Warnings,
-			fmt.Sprintf("System jump count (%d) is below expected minimum (%d)",
-				result.SystemJumps, minSystemJumps))
+	// Every faction referenced by the map should resolve to a chrFactions row
+	if t.config.TableEnabled(config.TableFactions) {
+		result.Warnings = append(result.Warnings, validateFactionIDs(data)...)
 	}
 
-	if result.WormholeClasses < minWormholeClasses {
-		result.Warnings = append(result.Warnings,
-			fmt.Sprintf("Wormhole class count (%d) is below expected minimum (%d)",
-				result.WormholeClasses, minWormholeClasses))
+	// Market groups should form a tree that every type's market group resolves into
+	if t.config.TableEnabled(config.TableMarketGroups) {
+		result.Warnings = append(result.Warnings, validateMarketGroups(data)...)
 	}
 
-	// Check for empty required data
-	if result.SolarSystems == 0 {
-		result.Errors = append(result.Errors, "No solar systems found")
+	// Every solar system should resolve to one wormhole class
+	if t.config.TableEnabled(config.TableSystems) || t.config.TableEnabled(config.TableSystemWormholeClasses) {
+		result.Warnings = append(result.Warnings, validateWormholeClasses(data)...)
 	}
 
-	if result.Regions == 0 {
-		result.Errors = append(result.Errors, "No regions found")
+	// Every stargate should be linked back by its destination gate
+	if t.config.TableEnabled(config.TableStargates) {
+		result.Warnings = append(result.Warnings, validateStargates(data)...)
 	}
 
-	if result.Constellations == 0 {
-		result.Errors = append(result.Errors, "No constellations found")
+	// SDE flags should agree with the stargates
+	if t.config.TableEnabled(config.TableSystems) {
+		result.Warnings = append(result.Warnings, validateGateFlags(data)...)
 	}
 
 	return result
@@ -8,6 +8,20 @@ import (
 	"github.com/guarzo/wanderer-sde/internal/parser"
 )
 
+// unknownSystemID is a gate destination missing from the test universes.
+const unknownSystemID = 999
+
+// gateJumps expands undirected gates into one jump per direction.
+func gateJumps(gates ...[2]int64) []models.SystemJump {
+	jumps := make([]models.SystemJump, 0, 2*len(gates))
+	for _, gate := range gates {
+		jumps = append(jumps,
+			models.SystemJump{FromSolarSystemID: gate[0], ToSolarSystemID: gate[1]},
+			models.SystemJump{FromSolarSystemID: gate[1], ToSolarSystemID: gate[0]})
+	}
+	return jumps
+}
+
 func TestTransformer_Transform(t *testing.T) {
 	cfg := &config.Config{Verbose: false}
 	tr := New(cfg)
@@ -48,7 +62,7 @@ func TestTransformer_Transform(t *testing.T) {
 			2456: {GroupID: 18, Name: map[string]string{"en": "Hobgoblin I"}, Mass: 2500, Volume: 5, Published: true},
 		},
 		Groups: map[int64]models.SDEGroup{
-			25: {CategoryID: ShipCategoryID, Name: map[string]string{"en": "Frigate"}, Published: true},
+			25: {CategoryID: models.ShipCategoryID, Name: map[string]string{"en": "Frigate"}, Published: true},
 			18: {CategoryID: 7, Name: map[string]string{"en": "Drone"}, Published: true},
 		},
 		Categories: map[int64]models.SDECategory{
@@ -113,6 +127,11 @@ func TestTransformer_Transform(t *testing.T) {
 		t.Errorf("Expected 2 groups, got %d", len(result.InvGroups))
 	}
 
+	// Check categories are converted in ID order
+	if len(result.InvCategories) != 2 || result.InvCategories[0].CategoryName != "Ship" {
+		t.Errorf("Expected categories Ship and Drone, got %+v", result.InvCategories)
+	}
+
 	// Check wormhole classes
 	if len(result.WormholeClasses) != 1 {
 		t.Errorf("Expected 1 wormhole class, got %d", len(result.WormholeClasses))
@@ -125,7 +144,7 @@ func TestTransformer_Transform(t *testing.T) {
 }
 
 func TestTransformer_Validate(t *testing.T) {
-	cfg := &config.Config{Verbose: false}
+	cfg := &config.Config{Verbose: false, Thresholds: config.DefaultThresholds()}
 	tr := New(cfg)
 
 	tests := []struct {
@@ -200,6 +219,21 @@ func TestTransformer_Validate(t *testing.T) {
 	}
 }
 
+func TestTransformer_ValidateZeroThresholds(t *testing.T) {
+	tr := New(&config.Config{})
+	data := &models.ConvertedData{
+		Universe: &models.UniverseData{
+			Regions:        make([]models.Region, 1),
+			Constellations: make([]models.Constellation, 1),
+			SolarSystems:   make([]models.SolarSystem, 1),
+		},
+	}
+
+	if result := tr.Validate(data); len(result.Warnings) > 0 {
+		t.Errorf("Expected zero thresholds to disable count warnings, got %v", result.Warnings)
+	}
+}
+
 func TestTransformer_SortFunctions(t *testing.T) {
 	cfg := &config.Config{Verbose: false}
 	tr := New(cfg)
@@ -252,8 +286,8 @@ func TestTransformer_SortFunctions(t *testing.T) {
 		}
 	})
 
-	// Test transformSystemJumps (renamed from sortSystemJumps)
-	t.Run("transformSystemJumps", func(t *testing.T) {
+	// Test sortSystemJumps and enrichSystemJumps
+	t.Run("sortSystemJumps", func(t *testing.T) {
 		systems := []models.SolarSystem{
 			{SolarSystemID: 1, RegionID: 100, ConstellationID: 1000},
 			{SolarSystemID: 2, RegionID: 100, ConstellationID: 1000},
@@ -264,7 +298,12 @@ func TestTransformer_SortFunctions(t *testing.T) {
 			{FromSolarSystemID: 1, ToSolarSystemID: 2},
 			{FromSolarSystemID: 1, ToSolarSystemID: 1},
 		}
-		sorted := tr.transformSystemJumps(jumps, systems)
+		index := models.NewDataset(&models.ConvertedData{
+			Universe:    &models.UniverseData{SolarSystems: systems},
+			SystemJumps: tr.sortSystemJumps(jumps),
+		})
+		enrichSystemJumps(index)
+		sorted := index.Data().SystemJumps
 
 		// Verify sorting by FromSolarSystemID then ToSolarSystemID
 		for i := 1; i < len(sorted); i++ {
@@ -0,0 +1,116 @@
+package transformer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// maxExampleIDs limits how many IDs a summarised validation warning lists.
+const maxExampleIDs = 5
+
+// wormholeClassLevel is a location a solar system can take its wormhole class from.
+type wormholeClassLevel struct {
+	locationID int64
+	source     string
+}
+
+// wormholeClassLevels returns the system's own location, its constellation and
+// its region, in inheritance order.
+func wormholeClassLevels(sys models.SolarSystem) []wormholeClassLevel {
+	return []wormholeClassLevel{
+		{sys.SolarSystemID, models.WormholeClassFromSystem},
+		{sys.ConstellationID, models.WormholeClassFromConstellation},
+		{sys.RegionID, models.WormholeClassFromRegion},
+	}
+}
+
+// effectiveWormholeClass returns the first class set on the system, its
+// constellation or its region, and the level it came from.
+func effectiveWormholeClass(index *models.Dataset, sys models.SolarSystem) (int64, string, bool) {
+	for _, level := range wormholeClassLevels(sys) {
+		if class, ok := index.LocationWormholeClass(level.locationID); ok {
+			return class, level.source, true
+		}
+	}
+	return 0, "", false
+}
+
+// resolveWormholeClasses sets WormholeClassID on each indexed solar system and
+// returns one systemWormholeClasses row per system with a resolvable class,
+// in system ID order.
+func resolveWormholeClasses(index *models.Dataset) []models.SystemWormholeClass {
+	systems := index.Data().Universe.SolarSystems
+	result := make([]models.SystemWormholeClass, 0, len(systems))
+
+	for i := range systems {
+		class, source, ok := effectiveWormholeClass(index, systems[i])
+		if !ok {
+			continue
+		}
+		systems[i].WormholeClassID = models.Int64PtrAlways(class)
+		result = append(result, models.SystemWormholeClass{
+			SolarSystemID:   systems[i].SolarSystemID,
+			WormholeClassID: class,
+			Source:          source,
+		})
+	}
+
+	return result
+}
+
+// validateWormholeClasses warns about solar systems with no resolvable wormhole
+// class and about systems whose own, constellation and region classes disagree.
+// It is skipped when no wormhole classes were loaded.
+func validateWormholeClasses(data *models.ConvertedData) []string {
+	if data.Universe == nil || len(data.Universe.SolarSystems) == 0 || len(data.WormholeClasses) == 0 {
+		return nil
+	}
+
+	index := models.NewDataset(&models.ConvertedData{
+		Universe:        data.Universe,
+		WormholeClasses: data.WormholeClasses,
+	})
+
+	var unresolved []string
+	var conflicts []string
+	for _, sys := range data.Universe.SolarSystems {
+		if _, _, ok := effectiveWormholeClass(index, sys); !ok {
+			unresolved = append(unresolved, strconv.FormatInt(sys.SolarSystemID, 10))
+			continue
+		}
+
+		var set []string
+		distinct := make(map[int64]bool)
+		for _, level := range wormholeClassLevels(sys) {
+			if class, ok := index.LocationWormholeClass(level.locationID); ok {
+				set = append(set, fmt.Sprintf("%s %d", level.source, class))
+				distinct[class] = true
+			}
+		}
+		if len(distinct) > 1 {
+			conflicts = append(conflicts, fmt.Sprintf("%d: %s", sys.SolarSystemID, strings.Join(set, ", ")))
+		}
+	}
+
+	var warnings []string
+	if len(unresolved) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d solar systems have no wormhole class (e.g. %s)",
+			len(unresolved), strings.Join(examples(unresolved), ", ")))
+	}
+	if len(conflicts) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d solar systems have conflicting wormhole classes across levels (e.g. %s)",
+			len(conflicts), strings.Join(examples(conflicts), "; ")))
+	}
+	return warnings
+}
+
+// examples returns at most maxExampleIDs items.
+func examples(items []string) []string {
+	if len(items) > maxExampleIDs {
+		return items[:maxExampleIDs]
+	}
+	return items
+}
@@ -0,0 +1,111 @@
+package transformer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+	"github.com/guarzo/wanderer-sde/internal/parser"
+)
+
+func wormholeClassParseResult() *parser.ParseResult {
+	return &parser.ParseResult{
+		Regions: []models.Region{{RegionID: 10000002}, {RegionID: 11000001}},
+		Constellations: []models.Constellation{
+			{RegionID: 10000002, ConstellationID: 20000020},
+			{RegionID: 11000001, ConstellationID: 21000001},
+		},
+		SolarSystems: []models.SolarSystem{
+			{RegionID: 10000002, ConstellationID: 20000020, SolarSystemID: 30000142},
+			{RegionID: 10000002, ConstellationID: 20000020, SolarSystemID: 30000145},
+			{RegionID: 11000001, ConstellationID: 21000001, SolarSystemID: 31000001},
+			{RegionID: 11000001, ConstellationID: 21000001, SolarSystemID: 31000002},
+			{RegionID: 99, ConstellationID: 98, SolarSystemID: 32000001},
+		},
+		WormholeClasses: []models.WormholeClassLocation{
+			{LocationID: 10000002, WormholeClassID: 7},
+			{LocationID: 30000145, WormholeClassID: 8},
+			{LocationID: 21000001, WormholeClassID: 3},
+			{LocationID: 11000001, WormholeClassID: 3},
+		},
+	}
+}
+
+func TestTransformer_ResolveWormholeClasses(t *testing.T) {
+	tr := New(&config.Config{})
+
+	data, err := tr.Transform(wormholeClassParseResult())
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+
+	want := []models.SystemWormholeClass{
+		{SolarSystemID: 30000142, WormholeClassID: 7, Source: models.WormholeClassFromRegion},
+		{SolarSystemID: 30000145, WormholeClassID: 8, Source: models.WormholeClassFromSystem},
+		{SolarSystemID: 31000001, WormholeClassID: 3, Source: models.WormholeClassFromConstellation},
+		{SolarSystemID: 31000002, WormholeClassID: 3, Source: models.WormholeClassFromConstellation},
+	}
+	if len(data.SystemWormholeClasses) != len(want) {
+		t.Fatalf("expected %d system wormhole classes, got %+v", len(want), data.SystemWormholeClasses)
+	}
+	for i, w := range want {
+		if data.SystemWormholeClasses[i] != w {
+			t.Errorf("row %d = %+v, want %+v", i, data.SystemWormholeClasses[i], w)
+		}
+	}
+
+	systems := data.Universe.SolarSystems
+	if systems[0].WormholeClassID == nil || *systems[0].WormholeClassID != 7 {
+		t.Errorf("expected Jita to inherit class 7, got %v", systems[0].WormholeClassID)
+	}
+	if systems[4].WormholeClassID != nil {
+		t.Errorf("expected no class for system without any, got %d", *systems[4].WormholeClassID)
+	}
+	if got := systems[4].ToCSVRow(); got[len(got)-1] != "None" {
+		t.Errorf("expected None in wormholeClassID column, got %q", got[len(got)-1])
+	}
+}
+
+func TestTransformer_ResolveWormholeClasses_TableDisabled(t *testing.T) {
+	tr := New(&config.Config{Tables: []string{config.TableSystems}})
+
+	data, err := tr.Transform(wormholeClassParseResult())
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+	if data.SystemWormholeClasses != nil {
+		t.Errorf("expected no systemWormholeClasses rows, got %d", len(data.SystemWormholeClasses))
+	}
+	if data.Universe.SolarSystems[0].WormholeClassID == nil {
+		t.Error("expected the column to be resolved when only systems are enabled")
+	}
+}
+
+func TestTransformer_ValidateWormholeClasses(t *testing.T) {
+	tr := New(&config.Config{})
+
+	data, err := tr.Transform(wormholeClassParseResult())
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+
+	warnings := validateWormholeClasses(data)
+	if len(warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0], "1 solar systems have no wormhole class (e.g. 32000001)") {
+		t.Errorf("unexpected unresolved warning: %s", warnings[0])
+	}
+	if !strings.Contains(warnings[1], "30000145: system 8, region 7") {
+		t.Errorf("unexpected conflict warning: %s", warnings[1])
+	}
+	// Agreeing constellation and region classes are not a conflict
+	if strings.Contains(warnings[1], "31000001") {
+		t.Errorf("agreeing levels reported as conflict: %s", warnings[1])
+	}
+
+	if got := validateWormholeClasses(&models.ConvertedData{Universe: data.Universe}); got != nil {
+		t.Errorf("expected no warnings without wormhole class data, got %v", got)
+	}
+}
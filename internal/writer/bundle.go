@@ -0,0 +1,75 @@
+package writer
+
+import (
+	"fmt"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// FileBundle is the single JSON file written by WriteBundle.
+const FileBundle = "universe.json"
+
+// SchemaVersion is the version of the FileBundle layout. It is increased
+// whenever the bundle or one of its tables changes incompatibly.
+const SchemaVersion = 1
+
+// bundle is the content of FileBundle. Tables are keyed by table name
+// (config.TableSystems, ...) in output order and laid out as in the per-table
+// JSON files.
+type bundle struct {
+	SchemaVersion   int                 `json:"schemaVersion"`
+	SDE             *models.SDEMetadata `json:"sde"`
+	Layout          config.JSONLayout   `json:"layout"`
+	Tables          *jsonObject         `json:"tables"`
+	MarketGroupTree interface{}         `json:"marketGroupTree,omitempty"`
+	TopologyReport  interface{}         `json:"topologyReport,omitempty"`
+}
+
+// add stores the content of a JSON file in the bundle.
+func (b *bundle) add(filename string, data interface{}) error {
+	switch filename {
+	case FileMarketGroupTree:
+		b.MarketGroupTree = data
+	case FileTopologyReport:
+		b.TopologyReport = data
+	default:
+		for _, table := range config.AllTables {
+			if jsonTableFiles[table] == filename {
+				b.Tables.add(table, data)
+				return nil
+			}
+		}
+		return fmt.Errorf("no bundle entry for %s", filename)
+	}
+	return nil
+}
+
+// WriteBundle writes all converted data to a single universe.json holding
+// every enabled table, the SDE metadata (null when unknown) and the schema
+// version, so clients can fetch one file.
+func (w *JSONWriter) WriteBundle(data *models.ConvertedData, metadata *models.SDEMetadata) error {
+	layout := w.config.JSONLayout
+	if layout == "" {
+		layout = config.JSONLayoutArray
+	}
+	w.bundle = &bundle{
+		SchemaVersion: SchemaVersion,
+		SDE:           metadata,
+		Layout:        layout,
+		Tables:        &jsonObject{},
+	}
+
+	// WriteAll collects the tables into the bundle instead of writing files
+	err := w.WriteAll(data)
+	b := w.bundle
+	w.bundle = nil
+	if err != nil {
+		return err
+	}
+
+	if err := w.writeJSON(FileBundle, b); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package writer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+func TestJSONWriter_WriteBundle(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:       tmpDir,
+		OutputFormat:    config.FormatJSON,
+		Bundle:          true,
+		MarketGroupTree: true,
+		Tables:          []string{config.TableSystems, config.TableJumps, config.TableMarketGroups},
+	}
+
+	data := &models.ConvertedData{
+		Universe: &models.UniverseData{
+			SolarSystems: []models.SolarSystem{{SolarSystemID: 30000142, SolarSystemName: "Jita"}},
+		},
+		SystemJumps:     []models.SystemJump{{FromSolarSystemID: 30000142, ToSolarSystemID: 30000144}},
+		MarketGroups:    []models.InvMarketGroup{{MarketGroupID: 4, MarketGroupName: "Ships"}},
+		MarketGroupTree: []models.MarketGroupNode{{MarketGroupID: 4, MarketGroupName: "Ships"}},
+	}
+	metadata := &models.SDEMetadata{SDEVersion: "3142455", ReleaseDate: "2025-11-06"}
+
+	if err := New(cfg).WriteBundle(data, metadata); err != nil {
+		t.Fatalf("WriteBundle failed: %v", err)
+	}
+
+	// Only the bundle is written
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to list output: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != FileBundle {
+		t.Errorf("expected only %s, got %v", FileBundle, entries)
+	}
+
+	var parsed struct {
+		SchemaVersion   int                        `json:"schemaVersion"`
+		SDE             models.SDEMetadata         `json:"sde"`
+		Layout          string                     `json:"layout"`
+		Tables          map[string]json.RawMessage `json:"tables"`
+		MarketGroupTree []models.MarketGroupNode   `json:"marketGroupTree"`
+		TopologyReport  *models.TopologyReport     `json:"topologyReport"`
+	}
+	readJSON(t, filepath.Join(tmpDir, FileBundle), &parsed)
+
+	if parsed.SchemaVersion != SchemaVersion {
+		t.Errorf("expected schema version %d, got %d", SchemaVersion, parsed.SchemaVersion)
+	}
+	if parsed.SDE.SDEVersion != "3142455" {
+		t.Errorf("unexpected SDE metadata: %+v", parsed.SDE)
+	}
+	if parsed.Layout != string(config.JSONLayoutArray) {
+		t.Errorf("expected array layout, got %q", parsed.Layout)
+	}
+	if len(parsed.Tables) != 3 {
+		t.Errorf("expected 3 tables, got %d", len(parsed.Tables))
+	}
+	var systems []models.SolarSystem
+	if err := json.Unmarshal(parsed.Tables[config.TableSystems], &systems); err != nil {
+		t.Fatalf("failed to decode systems: %v", err)
+	}
+	if len(systems) != 1 || systems[0].SolarSystemName != "Jita" {
+		t.Errorf("unexpected systems: %+v", systems)
+	}
+	if len(parsed.MarketGroupTree) != 1 {
+		t.Errorf("expected the market group tree, got %v", parsed.MarketGroupTree)
+	}
+	if parsed.TopologyReport != nil {
+		t.Errorf("expected no topology report, got %+v", parsed.TopologyReport)
+	}
+}
+
+func TestJSONWriter_WriteBundleKeyed(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:  tmpDir,
+		JSONLayout: config.JSONLayoutKeyed,
+		Tables:     []string{config.TableTypes},
+	}
+
+	data := &models.ConvertedData{
+		Universe: &models.UniverseData{},
+		InvTypes: []models.InvType{{TypeID: 587, TypeName: "Rifter"}},
+	}
+	if err := New(cfg).WriteBundle(data, nil); err != nil {
+		t.Fatalf("WriteBundle failed: %v", err)
+	}
+
+	var parsed struct {
+		SDE    *models.SDEMetadata                  `json:"sde"`
+		Layout string                               `json:"layout"`
+		Tables map[string]map[string]models.InvType `json:"tables"`
+	}
+	readJSON(t, filepath.Join(tmpDir, FileBundle), &parsed)
+
+	if parsed.SDE != nil {
+		t.Errorf("expected null metadata, got %+v", parsed.SDE)
+	}
+	if parsed.Layout != string(config.JSONLayoutKeyed) {
+		t.Errorf("expected keyed layout, got %q", parsed.Layout)
+	}
+	if parsed.Tables[config.TableTypes]["587"].TypeName != "Rifter" {
+		t.Errorf("unexpected types: %v", parsed.Tables[config.TableTypes])
+	}
+}
@@ -0,0 +1,63 @@
+package writer
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+)
+
+// compressedFile writes through an optional compressor into a file.
+type compressedFile struct {
+	io.Writer
+	compressor io.WriteCloser
+	file       *os.File
+}
+
+// CreateFile creates the file at path with the given permissions and returns a
+// writer that compresses into it. The caller adds the compression's extension
+// to path. Close must be called, and its error checked, to finish the
+// compressed stream; calling it again does nothing.
+func CreateFile(path string, perm os.FileMode, compression config.Compression) (io.WriteCloser, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &compressedFile{Writer: file, file: file}
+	switch compression {
+	case config.CompressionGzip:
+		f.compressor = gzip.NewWriter(file)
+	case config.CompressionZstd:
+		f.compressor, err = zstd.NewWriter(file)
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("failed to start zstd encoder: %w", err)
+		}
+	}
+	if f.compressor != nil {
+		f.Writer = f.compressor
+	}
+	return f, nil
+}
+
+// Close finishes the compressed stream and closes the file.
+func (f *compressedFile) Close() error {
+	if f.file == nil {
+		return nil
+	}
+	file := f.file
+	f.file = nil
+
+	if f.compressor != nil {
+		if err := f.compressor.Close(); err != nil {
+			_ = file.Close()
+			return err
+		}
+	}
+	return file.Close()
+}
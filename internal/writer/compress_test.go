@@ -0,0 +1,136 @@
+package writer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// readCompressed returns the decompressed content of a file.
+func readCompressed(t *testing.T, path string, compression config.Compression) []byte {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var r io.Reader = f
+	switch compression {
+	case config.CompressionGzip:
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatalf("%s is not gzipped: %v", path, err)
+		}
+		r = gz
+	case config.CompressionZstd:
+		zr, err := zstd.NewReader(f)
+		if err != nil {
+			t.Fatalf("%s is not zstd compressed: %v", path, err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to decompress %s: %v", path, err)
+	}
+	return content
+}
+
+func TestCreateFile(t *testing.T) {
+	for _, compression := range []config.Compression{config.CompressionNone, config.CompressionGzip, config.CompressionZstd} {
+		path := filepath.Join(t.TempDir(), "data.txt"+compression.Extension())
+		f, err := CreateFile(path, 0644, compression)
+		if err != nil {
+			t.Fatalf("%s: CreateFile failed: %v", compression, err)
+		}
+		if _, err := io.WriteString(f, "hello"); err != nil {
+			t.Fatalf("%s: write failed: %v", compression, err)
+		}
+		if err := f.Close(); err != nil {
+			t.Fatalf("%s: Close failed: %v", compression, err)
+		}
+		// A deferred second Close is harmless
+		if err := f.Close(); err != nil {
+			t.Errorf("%s: second Close failed: %v", compression, err)
+		}
+
+		if got := readCompressed(t, path, compression); string(got) != "hello" {
+			t.Errorf("%s: got %q, want %q", compression, got, "hello")
+		}
+	}
+}
+
+func TestGetOutputFiles_Compression(t *testing.T) {
+	files := GetOutputFiles(config.FormatCSV, config.CompressionZstd)
+	if files[0] != "mapSolarSystems.csv.zst" {
+		t.Errorf("expected mapSolarSystems.csv.zst, got %s", files[0])
+	}
+	files = GetOutputFiles(config.FormatJSON, config.CompressionGzip)
+	if files[0] != "mapSolarSystems.json.gz" {
+		t.Errorf("expected mapSolarSystems.json.gz, got %s", files[0])
+	}
+}
+
+func TestCSVWriter_Compression(t *testing.T) {
+	srcDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(srcDir, "wormholes.json"), []byte(`{"test": true}`), 0644); err != nil {
+		t.Fatalf("failed to create passthrough file: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:   tmpDir,
+		Compression: config.CompressionZstd,
+		Tables:      []string{config.TableRegions, config.TableMarketGroups},
+	}
+	w := NewCSVWriter(cfg)
+
+	data := &models.ConvertedData{
+		Universe: &models.UniverseData{
+			Regions: []models.Region{{RegionID: 10000002, RegionName: "The Forge"}},
+		},
+		MarketGroupTree: []models.MarketGroupNode{{MarketGroupID: 4, MarketGroupName: "Ships"}},
+	}
+	if err := w.WriteAll(data); err != nil {
+		t.Fatalf("WriteAll failed: %v", err)
+	}
+	if err := w.CopyPassthroughFiles(srcDir); err != nil {
+		t.Fatalf("CopyPassthroughFiles failed: %v", err)
+	}
+
+	for _, name := range []string{CSVFileRegions, FileMarketGroupTree, "wormholes.json"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); !os.IsNotExist(err) {
+			t.Errorf("%s should only be written compressed", name)
+		}
+	}
+
+	regions := readCompressed(t, filepath.Join(tmpDir, OutputFile(config.FormatCSV, cfg.Compression, config.TableRegions)), cfg.Compression)
+	records, err := csv.NewReader(bytes.NewReader(regions)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 2 || records[1][1] != "The Forge" {
+		t.Errorf("unexpected regions CSV: %v", records)
+	}
+
+	var tree []map[string]interface{}
+	if err := json.Unmarshal(readCompressed(t, filepath.Join(tmpDir, FileMarketGroupTree+".zst"), cfg.Compression), &tree); err != nil || len(tree) != 1 {
+		t.Errorf("expected a compressed market group tree, got %v (%v)", tree, err)
+	}
+
+	if got := readCompressed(t, filepath.Join(tmpDir, "wormholes.json.zst"), cfg.Compression); string(got) != `{"test": true}` {
+		t.Errorf("unexpected passthrough content %q", got)
+	}
+}
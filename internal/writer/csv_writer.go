@@ -20,12 +20,35 @@ const (
 	CSVFileTypes           = "invTypes.csv"
 	CSVFileGroups          = "invGroups.csv"
 	CSVFileSystemJumps     = "mapSolarSystemJumps.csv"
+
+	CSVFileSystemWormholeClasses = "mapSolarSystemWormholeClasses.csv"
+	CSVFileRegionJumps           = "mapRegionJumps.csv"
+	CSVFileConstellationJumps    = "mapConstellationJumps.csv"
+	CSVFileStargates             = "mapStargates.csv"
+	CSVFileSystemTopology        = "mapSystemTopology.csv"
+
+	CSVFileStations          = "staStations.csv"
+	CSVFileStationServices   = "staServices.csv"
+	CSVFileOperationServices = "staOperationServices.csv"
+
+	CSVFileFactions     = "chrFactions.csv"
+	CSVFileRaces        = "chrRaces.csv"
+	CSVFileCorporations = "crpNPCCorporations.csv"
+	CSVFileMarketGroups = "invMarketGroups.csv"
+
+	CSVFileDogmaAttributes = "dgmAttributeTypes.csv"
+	CSVFileDogmaEffects    = "dgmEffects.csv"
+	CSVFileTypeAttributes  = "dgmTypeAttributes.csv"
+	CSVFileTypeEffects     = "dgmTypeEffects.csv"
 )
 
 // CSVWriter handles writing converted data to CSV files.
 type CSVWriter struct {
 	config    *config.Config
 	outputDir string
+	// tableNames names files after their table (systems.csv, ...), as in
+	// region directories.
+	tableNames bool
 }
 
 // NewCSVWriter creates a new CSVWriter with the given configuration.
@@ -44,36 +67,58 @@ func (w *CSVWriter) WriteAll(data *models.ConvertedData) error {
 	}
 
 	if w.config.Verbose {
-		fmt.Printf("Writing CSV files to: %s\n", w.outputDir)
-	}
-
-	// Write all data files
-	if err := w.WriteSolarSystems(data.Universe.SolarSystems); err != nil {
-		return fmt.Errorf("failed to write solar systems: %w", err)
-	}
-
-	if err := w.WriteRegions(data.Universe.Regions); err != nil {
-		return fmt.Errorf("failed to write regions: %w", err)
-	}
-
-	if err := w.WriteConstellations(data.Universe.Constellations); err != nil {
-		return fmt.Errorf("failed to write constellations: %w", err)
-	}
-
-	if err := w.WriteWormholeClasses(data.WormholeClasses); err != nil {
-		return fmt.Errorf("failed to write wormhole classes: %w", err)
+		w.config.Logf("Writing CSV files to: %s\n", w.outputDir)
 	}
 
-	if err := w.WriteTypes(data.InvTypes); err != nil {
-		return fmt.Errorf("failed to write types: %w", err)
+	// Write all enabled data files
+	tables := []struct {
+		name  string
+		label string
+		write func() error
+	}{
+		{config.TableSystems, "solar systems", func() error { return w.WriteSolarSystems(data.Universe.SolarSystems) }},
+		{config.TableRegions, "regions", func() error { return w.WriteRegions(data.Universe.Regions) }},
+		{config.TableConstellations, "constellations", func() error { return w.WriteConstellations(data.Universe.Constellations) }},
+		{config.TableWormholeClasses, "wormhole classes", func() error { return w.WriteWormholeClasses(data.WormholeClasses) }},
+		{config.TableSystemWormholeClasses, "system wormhole classes", func() error { return w.WriteSystemWormholeClasses(data.SystemWormholeClasses) }},
+		{config.TableTypes, "types", func() error { return w.WriteTypes(data.InvTypes) }},
+		{config.TableGroups, "groups", func() error { return w.WriteGroups(data.InvGroups) }},
+		{config.TableJumps, "system jumps", func() error { return w.WriteSystemJumps(data.SystemJumps) }},
+		{config.TableRegionJumps, "region jumps", func() error { return w.WriteRegionJumps(data.RegionJumps) }},
+		{config.TableConstellationJumps, "constellation jumps", func() error { return w.WriteConstellationJumps(data.ConstellationJumps) }},
+		{config.TableStargates, "stargates", func() error { return w.WriteStargates(data.Stargates) }},
+		{config.TableSystemTopology, "system topology", func() error { return w.WriteSystemTopology(data.SystemTopology, data.TopologyReport) }},
+		{config.TableStations, "stations", func() error { return w.WriteStations(data.Stations) }},
+		{config.TableStationServices, "station services", func() error { return w.WriteStationServices(data.StationServices) }},
+		{config.TableOperationServices, "operation services", func() error { return w.WriteOperationServices(data.OperationServices) }},
+		{config.TableFactions, "factions", func() error { return w.WriteFactions(data.Factions) }},
+		{config.TableRaces, "races", func() error { return w.WriteRaces(data.Races) }},
+		{config.TableCorporations, "corporations", func() error { return w.WriteCorporations(data.Corporations) }},
+		{config.TableMarketGroups, "market groups", func() error { return w.WriteMarketGroups(data.MarketGroups, data.MarketGroupTree) }},
+		{config.TableDogmaAttributes, "dogma attributes", func() error { return w.WriteDogmaAttributes(data.DogmaAttributes) }},
+		{config.TableDogmaEffects, "dogma effects", func() error { return w.WriteDogmaEffects(data.DogmaEffects) }},
+		{config.TableTypeAttributes, "type attributes", func() error { return w.WriteTypeAttributes(data.TypeAttributes) }},
+		{config.TableTypeEffects, "type effects", func() error { return w.WriteTypeEffects(data.TypeEffects) }},
 	}
 
-	if err := w.WriteGroups(data.InvGroups); err != nil {
-		return fmt.Errorf("failed to write groups: %w", err)
+	for _, table := range tables {
+		if !w.config.TableEnabled(table.name) {
+			continue
+		}
+		if err := table.write(); err != nil {
+			return fmt.Errorf("failed to write %s: %w", table.label, err)
+		}
 	}
 
-	if err := w.WriteSystemJumps(data.SystemJumps); err != nil {
-		return fmt.Errorf("failed to write system jumps: %w", err)
+	if w.config.SplitBy == config.SplitByRegion {
+		err := writeRegionSplit(w.config, data, ".csv", func(cfg *config.Config) regionTableWriter {
+			rw := NewCSVWriter(cfg)
+			rw.tableNames = true
+			return rw
+		})
+		if err != nil {
+			return fmt.Errorf("failed to split by region: %w", err)
+		}
 	}
 
 	return nil
@@ -81,18 +126,32 @@ func (w *CSVWriter) WriteAll(data *models.ConvertedData) error {
 
 // WriteSolarSystems writes solar system data to CSV.
 func (w *CSVWriter) WriteSolarSystems(systems []models.SolarSystem) error {
+	var extraHeaders []string
+	if w.config.SecurityColumns {
+		extraHeaders = append(extraHeaders, models.SecurityColumns...)
+	}
+	if w.config.ProjectionColumns {
+		extraHeaders = append(extraHeaders, models.ProjectionColumns...)
+	}
 	rows := make([][]string, len(systems))
 	for i, s := range systems {
 		rows[i] = s.ToCSVRow()
+		if w.config.SecurityColumns {
+			rows[i] = append(rows[i], s.SecurityCSVRow()...)
+		}
+		if w.config.ProjectionColumns {
+			rows[i] = append(rows[i], s.ProjectionCSVRow()...)
+		}
+		rows[i] = append(rows[i], s.LocalizedCSVRow(w.config.Languages)...)
 	}
-	return w.writeCSV(CSVFileSolarSystems, "mapSolarSystems", rows)
+	return w.writeCSV(CSVFileSolarSystems, "mapSolarSystems", rows, extraHeaders...)
 }
 
 // WriteRegions writes region data to CSV.
 func (w *CSVWriter) WriteRegions(regions []models.Region) error {
 	rows := make([][]string, len(regions))
 	for i, r := range regions {
-		rows[i] = r.ToCSVRow()
+		rows[i] = append(r.ToCSVRow(), r.LocalizedCSVRow(w.config.Languages)...)
 	}
 	return w.writeCSV(CSVFileRegions, "mapRegions", rows)
 }
@@ -101,7 +160,7 @@ func (w *CSVWriter) WriteRegions(regions []models.Region) error {
 func (w *CSVWriter) WriteConstellations(constellations []models.Constellation) error {
 	rows := make([][]string, len(constellations))
 	for i, c := range constellations {
-		rows[i] = c.ToCSVRow()
+		rows[i] = append(c.ToCSVRow(), c.LocalizedCSVRow(w.config.Languages)...)
 	}
 	return w.writeCSV(CSVFileConstellations, "mapConstellations", rows)
 }
@@ -115,11 +174,20 @@ func (w *CSVWriter) WriteWormholeClasses(classes []models.WormholeClassLocation)
 	return w.writeCSV(CSVFileWormholeClasses, "mapLocationWormholeClasses", rows)
 }
 
+// WriteSystemWormholeClasses writes the effective wormhole class of each system to CSV.
+func (w *CSVWriter) WriteSystemWormholeClasses(classes []models.SystemWormholeClass) error {
+	rows := make([][]string, len(classes))
+	for i, c := range classes {
+		rows[i] = c.ToCSVRow()
+	}
+	return w.writeCSV(CSVFileSystemWormholeClasses, "mapSolarSystemWormholeClasses", rows)
+}
+
 // WriteTypes writes type data to CSV.
 func (w *CSVWriter) WriteTypes(types []models.InvType) error {
 	rows := make([][]string, len(types))
 	for i, t := range types {
-		rows[i] = t.ToCSVRow()
+		rows[i] = append(t.ToCSVRow(), t.LocalizedCSVRow(w.config.Languages)...)
 	}
 	return w.writeCSV(CSVFileTypes, "invTypes", rows)
 }
@@ -128,7 +196,7 @@ func (w *CSVWriter) WriteTypes(types []models.InvType) error {
 func (w *CSVWriter) WriteGroups(groups []models.InvGroup) error {
 	rows := make([][]string, len(groups))
 	for i, g := range groups {
-		rows[i] = g.ToCSVRow()
+		rows[i] = append(g.ToCSVRow(), g.LocalizedCSVRow(w.config.Languages)...)
 	}
 	return w.writeCSV(CSVFileGroups, "invGroups", rows)
 }
@@ -142,6 +210,155 @@ func (w *CSVWriter) WriteSystemJumps(jumps []models.SystemJump) error {
 	return w.writeCSV(CSVFileSystemJumps, "mapSolarSystemJumps", rows)
 }
 
+// WriteRegionJumps writes region connections to CSV.
+func (w *CSVWriter) WriteRegionJumps(jumps []models.RegionJump) error {
+	rows := make([][]string, len(jumps))
+	for i, j := range jumps {
+		rows[i] = j.ToCSVRow()
+	}
+	return w.writeCSV(CSVFileRegionJumps, "mapRegionJumps", rows)
+}
+
+// WriteConstellationJumps writes constellation connections to CSV.
+func (w *CSVWriter) WriteConstellationJumps(jumps []models.ConstellationJump) error {
+	rows := make([][]string, len(jumps))
+	for i, j := range jumps {
+		rows[i] = j.ToCSVRow()
+	}
+	return w.writeCSV(CSVFileConstellationJumps, "mapConstellationJumps", rows)
+}
+
+// WriteStargates writes stargate data to CSV.
+func (w *CSVWriter) WriteStargates(gates []models.Stargate) error {
+	rows := make([][]string, len(gates))
+	for i, g := range gates {
+		rows[i] = g.ToCSVRow()
+	}
+	return w.writeCSV(CSVFileStargates, "mapStargates", rows)
+}
+
+// WriteSystemTopology writes per-system topology metrics to CSV. The report is
+// written as mapSystemTopologyReport.json, since its lists have no CSV form.
+func (w *CSVWriter) WriteSystemTopology(rows []models.SystemTopology, report *models.TopologyReport) error {
+	records := make([][]string, len(rows))
+	for i, r := range rows {
+		records[i] = r.ToCSVRow()
+	}
+	if err := w.writeCSV(CSVFileSystemTopology, "mapSystemTopology", records); err != nil {
+		return err
+	}
+	if report == nil {
+		return nil
+	}
+	return New(w.config).writeJSON(FileTopologyReport, report)
+}
+
+// WriteStations writes NPC station data to CSV.
+func (w *CSVWriter) WriteStations(stations []models.StaStation) error {
+	rows := make([][]string, len(stations))
+	for i, s := range stations {
+		rows[i] = s.ToCSVRow()
+	}
+	return w.writeCSV(CSVFileStations, "staStations", rows)
+}
+
+// WriteStationServices writes station service data to CSV.
+func (w *CSVWriter) WriteStationServices(services []models.StaService) error {
+	rows := make([][]string, len(services))
+	for i, s := range services {
+		rows[i] = s.ToCSVRow()
+	}
+	return w.writeCSV(CSVFileStationServices, "staServices", rows)
+}
+
+// WriteOperationServices writes station operation service links to CSV.
+func (w *CSVWriter) WriteOperationServices(links []models.StaOperationService) error {
+	rows := make([][]string, len(links))
+	for i, l := range links {
+		rows[i] = l.ToCSVRow()
+	}
+	return w.writeCSV(CSVFileOperationServices, "staOperationServices", rows)
+}
+
+// WriteFactions writes faction data to CSV.
+func (w *CSVWriter) WriteFactions(factions []models.ChrFaction) error {
+	rows := make([][]string, len(factions))
+	for i, f := range factions {
+		rows[i] = append(f.ToCSVRow(), f.LocalizedCSVRow(w.config.Languages)...)
+	}
+	return w.writeCSV(CSVFileFactions, "chrFactions", rows)
+}
+
+// WriteRaces writes race data to CSV.
+func (w *CSVWriter) WriteRaces(races []models.ChrRace) error {
+	rows := make([][]string, len(races))
+	for i, r := range races {
+		rows[i] = append(r.ToCSVRow(), r.LocalizedCSVRow(w.config.Languages)...)
+	}
+	return w.writeCSV(CSVFileRaces, "chrRaces", rows)
+}
+
+// WriteCorporations writes NPC corporation data to CSV.
+func (w *CSVWriter) WriteCorporations(corporations []models.CrpNPCCorporation) error {
+	rows := make([][]string, len(corporations))
+	for i, c := range corporations {
+		rows[i] = append(c.ToCSVRow(), c.LocalizedCSVRow(w.config.Languages)...)
+	}
+	return w.writeCSV(CSVFileCorporations, "crpNPCCorporations", rows)
+}
+
+// WriteMarketGroups writes market group data to CSV. When a tree is given it is
+// also written as invMarketGroupsTree.json, since nesting has no CSV form.
+func (w *CSVWriter) WriteMarketGroups(groups []models.InvMarketGroup, tree []models.MarketGroupNode) error {
+	rows := make([][]string, len(groups))
+	for i, g := range groups {
+		rows[i] = append(g.ToCSVRow(), g.LocalizedCSVRow(w.config.Languages)...)
+	}
+	if err := w.writeCSV(CSVFileMarketGroups, "invMarketGroups", rows); err != nil {
+		return err
+	}
+	if tree == nil {
+		return nil
+	}
+	return New(w.config).writeJSON(FileMarketGroupTree, tree)
+}
+
+// WriteDogmaAttributes writes dogma attribute definitions to CSV.
+func (w *CSVWriter) WriteDogmaAttributes(attributes []models.DgmAttributeType) error {
+	rows := make([][]string, len(attributes))
+	for i, a := range attributes {
+		rows[i] = a.ToCSVRow()
+	}
+	return w.writeCSV(CSVFileDogmaAttributes, "dgmAttributeTypes", rows)
+}
+
+// WriteDogmaEffects writes dogma effect definitions to CSV.
+func (w *CSVWriter) WriteDogmaEffects(effects []models.DgmEffect) error {
+	rows := make([][]string, len(effects))
+	for i, e := range effects {
+		rows[i] = e.ToCSVRow()
+	}
+	return w.writeCSV(CSVFileDogmaEffects, "dgmEffects", rows)
+}
+
+// WriteTypeAttributes writes per-type dogma attribute values to CSV.
+func (w *CSVWriter) WriteTypeAttributes(values []models.DgmTypeAttribute) error {
+	rows := make([][]string, len(values))
+	for i, v := range values {
+		rows[i] = v.ToCSVRow()
+	}
+	return w.writeCSV(CSVFileTypeAttributes, "dgmTypeAttributes", rows)
+}
+
+// WriteTypeEffects writes per-type dogma effects to CSV.
+func (w *CSVWriter) WriteTypeEffects(effects []models.DgmTypeEffect) error {
+	rows := make([][]string, len(effects))
+	for i, e := range effects {
+		rows[i] = e.ToCSVRow()
+	}
+	return w.writeCSV(CSVFileTypeEffects, "dgmTypeEffects", rows)
+}
+
 // CopyPassthroughFiles copies community-maintained JSON files from the source directory.
 // For CSV output, we still want to copy these JSON files as they're used by Wanderer.
 func (w *CSVWriter) CopyPassthroughFiles(sourceDir string) error {
@@ -150,58 +367,65 @@ func (w *CSVWriter) CopyPassthroughFiles(sourceDir string) error {
 	}
 
 	if w.config.Verbose {
-		fmt.Printf("Copying passthrough files from: %s\n", sourceDir)
+		w.config.Logf("Copying passthrough files from: %s\n", sourceDir)
 	}
 
 	var copied, skipped int
 	for _, filename := range PassthroughFiles {
 		srcPath := filepath.Join(sourceDir, filename)
-		dstPath := filepath.Join(w.outputDir, filename)
+		dstPath := filepath.Join(w.outputDir, filename+w.config.Compression.Extension())
 
 		// Check if source file exists
 		if _, err := os.Stat(srcPath); os.IsNotExist(err) {
 			if w.config.Verbose {
-				fmt.Printf("  Skipping %s (not found)\n", filename)
+				w.config.Logf("  Skipping %s (not found)\n", filename)
 			}
 			skipped++
 			continue
 		}
 
-		if err := copyFile(srcPath, dstPath); err != nil {
+		if err := copyFile(srcPath, dstPath, w.config.Compression); err != nil {
 			return fmt.Errorf("failed to copy %s: %w", filename, err)
 		}
 
 		if w.config.Verbose {
-			fmt.Printf("  Copied %s\n", filename)
+			w.config.Logf("  Copied %s\n", filename)
 		}
 		copied++
 	}
 
 	if w.config.Verbose {
-		fmt.Printf("Passthrough complete: %d copied, %d skipped\n", copied, skipped)
+		w.config.Logf("Passthrough complete: %d copied, %d skipped\n", copied, skipped)
 	}
 
 	return nil
 }
 
 // writeCSV writes data rows to a CSV file with the appropriate headers.
-func (w *CSVWriter) writeCSV(filename, headerKey string, rows [][]string) error {
+// extraHeaders name optional columns that follow the table's own columns and
+// precede the translated ones.
+func (w *CSVWriter) writeCSV(filename, headerKey string, rows [][]string, extraHeaders ...string) error {
+	if w.tableNames {
+		filename = regionFile(csvTableFiles, filename)
+	}
+	filename += w.config.Compression.Extension()
 	path := filepath.Join(w.outputDir, filename)
 
-	file, err := os.Create(path)
+	file, err := CreateFile(path, 0644, w.config.Compression)
 	if err != nil {
 		return fmt.Errorf("failed to create file %s: %w", path, err)
 	}
 	defer func() { _ = file.Close() }()
 
 	csvWriter := csv.NewWriter(file)
-	defer csvWriter.Flush()
 
 	// Write header row
 	headers, ok := models.CSVHeaders[headerKey]
 	if !ok {
 		return fmt.Errorf("no headers defined for %s", headerKey)
 	}
+	headers = append(append([]string{}, headers...), extraHeaders...)
+	headers = append(headers, models.LocalizedHeaders(headerKey, w.config.Languages)...)
 	if err := csvWriter.Write(headers); err != nil {
 		return fmt.Errorf("failed to write headers to %s: %w", path, err)
 	}
@@ -213,8 +437,16 @@ func (w *CSVWriter) writeCSV(filename, headerKey string, rows [][]string) error
 		}
 	}
 
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", path, err)
+	}
+
 	if w.config.Verbose {
-		fmt.Printf("  Wrote %s (%d rows)\n", filename, len(rows))
+		w.config.Logf("  Wrote %s (%d rows)\n", filename, len(rows))
 	}
 
 	return nil
@@ -474,6 +474,7 @@ func TestNewWriter(t *testing.T) {
 	}{
 		{config.FormatCSV, false, "*writer.CSVWriter"},
 		{config.FormatJSON, false, "*writer.JSONWriter"},
+		{config.FormatNDJSON, false, "*writer.JSONWriter"},
 		{config.OutputFormat("invalid"), true, ""},
 	}
 
@@ -501,9 +502,9 @@ func TestNewWriter(t *testing.T) {
 }
 
 func TestGetOutputFiles(t *testing.T) {
-	csvFiles := GetOutputFiles(config.FormatCSV)
-	if len(csvFiles) != 7 {
-		t.Errorf("expected 7 CSV files, got %d", len(csvFiles))
+	csvFiles := GetOutputFiles(config.FormatCSV, config.CompressionNone)
+	if len(csvFiles) != len(config.AllTables) {
+		t.Errorf("expected %d CSV files, got %d", len(config.AllTables), len(csvFiles))
 	}
 
 	// Check that all CSV files have .csv extension
@@ -513,9 +514,9 @@ func TestGetOutputFiles(t *testing.T) {
 		}
 	}
 
-	jsonFiles := GetOutputFiles(config.FormatJSON)
-	if len(jsonFiles) != 7 {
-		t.Errorf("expected 7 JSON files, got %d", len(jsonFiles))
+	jsonFiles := GetOutputFiles(config.FormatJSON, config.CompressionNone)
+	if len(jsonFiles) != len(config.AllTables) {
+		t.Errorf("expected %d JSON files, got %d", len(config.AllTables), len(jsonFiles))
 	}
 
 	// Check that all JSON files have .json extension
@@ -524,6 +525,12 @@ func TestGetOutputFiles(t *testing.T) {
 			t.Errorf("expected .json extension, got %s", f)
 		}
 	}
+
+	for _, f := range GetOutputFiles(config.FormatNDJSON, config.CompressionNone) {
+		if filepath.Ext(f) != ".ndjson" {
+			t.Errorf("expected .ndjson extension, got %s", f)
+		}
+	}
 }
 
 func TestCSVWriter_CoordinateFormatting(t *testing.T) {
@@ -680,6 +687,9 @@ func TestCSVWriter_ConstellationRow(t *testing.T) {
 			ZMax:              120625394659753984,
 			FactionID:         &factionID,
 			Radius:            6500000000000,
+			CentroidX:         -90032979768340480,
+			CentroidY:         -1.5,
+			CentroidZ:         0,
 		},
 	}
 
@@ -714,6 +724,9 @@ func TestCSVWriter_ConstellationRow(t *testing.T) {
 		{2, "constellationName", "Kimotoro"},
 		{12, "factionID", "500001"},
 		{13, "radius", "6500000000000"},
+		{14, "centroidX", "-90032979768340480"},
+		{15, "centroidY", "-1.5"},
+		{16, "centroidZ", "0"},
 	}
 
 	for _, tt := range tests {
@@ -1002,3 +1015,219 @@ func TestCSVWriter_WormholeClassRow(t *testing.T) {
 		t.Errorf("unexpected second row: %v", records[2])
 	}
 }
+
+func TestCSVWriter_Languages(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{
+		OutputDir:    tmpDir,
+		OutputFormat: config.FormatCSV,
+		Languages:    []string{"en", "de", "ru"},
+	}
+	w := NewCSVWriter(cfg)
+
+	types := []models.InvType{
+		{
+			TypeID:       587,
+			GroupID:      25,
+			TypeName:     "Rifter",
+			Description:  "A frigate.",
+			Names:        models.LocalizedText{"en": "Rifter", "de": "Rifter DE"},
+			Descriptions: models.LocalizedText{"en": "A frigate.", "de": "Eine Fregatte.", "ru": "Фрегат."},
+		},
+	}
+	if err := w.WriteTypes(types); err != nil {
+		t.Fatalf("WriteTypes failed: %v", err)
+	}
+
+	file, err := os.Open(filepath.Join(tmpDir, CSVFileTypes))
+	if err != nil {
+		t.Fatalf("failed to open types CSV: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read types CSV: %v", err)
+	}
+
+	baseColumns := len(models.CSVHeaders["invTypes"])
+	wantHeaders := []string{"typeName_de", "description_de", "typeName_ru", "description_ru"}
+	gotHeaders := records[0][baseColumns:]
+	if len(gotHeaders) != len(wantHeaders) {
+		t.Fatalf("Expected extra headers %v, got %v", wantHeaders, gotHeaders)
+	}
+	for i, want := range wantHeaders {
+		if gotHeaders[i] != want {
+			t.Errorf("Header %d: expected %q, got %q", i, want, gotHeaders[i])
+		}
+	}
+
+	// Missing Russian name falls back to English
+	wantValues := []string{"Rifter DE", "Eine Fregatte.", "Rifter", "Фрегат."}
+	gotValues := records[1][baseColumns:]
+	for i, want := range wantValues {
+		if gotValues[i] != want {
+			t.Errorf("Value %d: expected %q, got %q", i, want, gotValues[i])
+		}
+	}
+
+	// Base headers must not be modified by appending localized columns
+	if len(models.CSVHeaders["invTypes"]) != baseColumns {
+		t.Error("CSVHeaders was modified")
+	}
+}
+
+func TestCSVWriter_SecurityColumns(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	cfg := &config.Config{
+		OutputDir:       tmpDir,
+		OutputFormat:    config.FormatCSV,
+		SecurityColumns: true,
+		Languages:       []string{"de"},
+
+		ProjectionColumns: true,
+	}
+	w := NewCSVWriter(cfg)
+
+	display := 0.5
+	wormhole := false
+	projectedX, projectedY := -13.642, 12.4166
+	systems := []models.SolarSystem{
+		{
+			RegionID:        10000002,
+			ConstellationID: 20000020,
+			SolarSystemID:   30000142,
+			SolarSystemName: "Jita",
+			Security:        0.45,
+			DisplaySecurity: &display,
+			SecurityBand:    models.SecurityBandHighsec,
+			WormholeSpace:   &wormhole,
+			ProjectedX:      &projectedX,
+			ProjectedY:      &projectedY,
+			Names:           models.LocalizedText{"de": "Jita DE"},
+		},
+	}
+	if err := w.WriteSolarSystems(systems); err != nil {
+		t.Fatalf("WriteSolarSystems failed: %v", err)
+	}
+
+	file, err := os.Open(filepath.Join(tmpDir, CSVFileSolarSystems))
+	if err != nil {
+		t.Fatalf("failed to open systems CSV: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read systems CSV: %v", err)
+	}
+
+	// Security and then projection columns come before the translated ones
+	baseColumns := len(models.CSVHeaders["mapSolarSystems"])
+	wantHeaders := []string{"displaySecurity", "securityBand", "wormholeSpace", "projectedX", "projectedY", "solarSystemName_de"}
+	wantValues := []string{"0.5", "highsec", "0", "-13.642", "12.4166", "Jita DE"}
+	for i := range wantHeaders {
+		if got := records[0][baseColumns+i]; got != wantHeaders[i] {
+			t.Errorf("Header %d: expected %q, got %q", i, wantHeaders[i], got)
+		}
+		if got := records[1][baseColumns+i]; got != wantValues[i] {
+			t.Errorf("Value %d: expected %q, got %q", i, wantValues[i], got)
+		}
+	}
+	if len(records[0]) != baseColumns+len(wantHeaders) {
+		t.Errorf("Expected %d columns, got %d", baseColumns+len(wantHeaders), len(records[0]))
+	}
+}
+
+func TestCSVWriter_MarketGroups(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "csv_market_groups_test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	w := NewCSVWriter(&config.Config{OutputDir: tmpDir})
+
+	parentID := int64(4)
+	groups := []models.InvMarketGroup{
+		{MarketGroupID: 4, MarketGroupName: "Ships", MarketGroupPath: "4", RootMarketGroupID: 4},
+		{MarketGroupID: 391, ParentGroupID: &parentID, MarketGroupName: "Frigates", HasTypes: true, MarketGroupPath: "4/391", RootMarketGroupID: 4},
+	}
+	tree := []models.MarketGroupNode{
+		{MarketGroupID: 4, MarketGroupName: "Ships", Children: []models.MarketGroupNode{{MarketGroupID: 391, MarketGroupName: "Frigates"}}},
+	}
+
+	if err := w.WriteMarketGroups(groups, tree); err != nil {
+		t.Fatalf("WriteMarketGroups failed: %v", err)
+	}
+
+	file, err := os.Open(filepath.Join(tmpDir, CSVFileMarketGroups))
+	if err != nil {
+		t.Fatalf("failed to open CSV: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+	want := []string{"391", "4", "Frigates", "", "None", "1", "4/391", "4"}
+	for i, v := range want {
+		if records[2][i] != v {
+			t.Errorf("column %s = %q, want %q", records[0][i], records[2][i], v)
+		}
+	}
+
+	// The tree is written as JSON even for CSV output
+	if _, err := os.Stat(filepath.Join(tmpDir, FileMarketGroupTree)); err != nil {
+		t.Errorf("expected %s to be written: %v", FileMarketGroupTree, err)
+	}
+}
+
+func TestCSVWriter_SystemTopology(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "csv_topology_test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	w := NewCSVWriter(&config.Config{OutputDir: tmpDir})
+
+	rows := []models.SystemTopology{
+		{SolarSystemID: 30000142, RegionID: 10000002, GateCount: 2, ArticulationPoint: true, BridgeCount: 1,
+			PipeID: models.Int64PtrAlways(30000142), PipeLength: 3, Betweenness: 0.25},
+		{SolarSystemID: 30000144, RegionID: 10000002, GateCount: 1, DeadEnd: true, BridgeCount: 1},
+	}
+	report := &models.TopologyReport{Systems: 2, Gates: 1, Components: 1}
+
+	if err := w.WriteSystemTopology(rows, report); err != nil {
+		t.Fatalf("WriteSystemTopology failed: %v", err)
+	}
+
+	file, err := os.Open(filepath.Join(tmpDir, CSVFileSystemTopology))
+	if err != nil {
+		t.Fatalf("failed to open CSV: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+	want := [][]string{
+		{"30000142", "10000002", "2", "1", "1", "0", "30000142", "3", "0.25"},
+		{"30000144", "10000002", "1", "0", "1", "1", "None", "0", "0"},
+	}
+	for r, row := range want {
+		for i, v := range row {
+			if records[r+1][i] != v {
+				t.Errorf("row %d column %s = %q, want %q", r, records[0][i], records[r+1][i], v)
+			}
+		}
+	}
+
+	// The report is written as JSON even for CSV output
+	if _, err := os.Stat(filepath.Join(tmpDir, FileTopologyReport)); err != nil {
+		t.Errorf("expected %s to be written: %v", FileTopologyReport, err)
+	}
+}
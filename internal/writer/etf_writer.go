@@ -0,0 +1,106 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/etf"
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// ETFWriter handles writing converted data in the Erlang External Term Format.
+// Each table becomes one file holding a single term, a list of maps keyed by
+// the JSON field names, that :erlang.binary_to_term decodes directly.
+// Integers stay integers and floats stay floats; nulls are the atom nil.
+// Translated names are not written.
+type ETFWriter struct {
+	config    *config.Config
+	outputDir string
+}
+
+// NewETFWriter creates a new ETFWriter with the given configuration.
+func NewETFWriter(cfg *config.Config) *ETFWriter {
+	return &ETFWriter{
+		config:    cfg,
+		outputDir: cfg.OutputDir,
+	}
+}
+
+// etfFile returns the ETF file name for a JSON file name.
+func etfFile(jsonFile string) string {
+	return strings.TrimSuffix(jsonFile, ".json") + ".etf"
+}
+
+// WriteAll writes all converted data to ETF files.
+func (w *ETFWriter) WriteAll(data *models.ConvertedData) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(w.outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if w.config.Verbose {
+		w.config.Logf("Writing ETF files to: %s\n", w.outputDir)
+	}
+
+	// Write all enabled data files
+	for _, table := range tableRecords(data) {
+		if !w.config.TableEnabled(table.name) {
+			continue
+		}
+		if err := w.writeETF(OutputFile(config.FormatETF, w.config.Compression, table.name), table.records); err != nil {
+			return fmt.Errorf("failed to write %s: %w", table.label, err)
+		}
+	}
+
+	return writeJSONSideFiles(w.config, data)
+}
+
+// CopyPassthroughFiles copies community-maintained JSON files from the source directory.
+// For ETF output, we still want to copy these JSON files as they're used by Wanderer.
+func (w *ETFWriter) CopyPassthroughFiles(sourceDir string) error {
+	return New(w.config).CopyPassthroughFiles(sourceDir)
+}
+
+// writeETF streams the elements of the records slice to a file as one list term.
+func (w *ETFWriter) writeETF(filename string, records interface{}) error {
+	rows := reflect.ValueOf(records)
+	if rows.Kind() != reflect.Slice {
+		return fmt.Errorf("cannot write %T as ETF: not a slice", records)
+	}
+
+	path := filepath.Join(w.outputDir, filename)
+	file, err := CreateFile(path, 0644, w.config.Compression)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	encoder := etf.NewEncoder(file)
+	encoder.AtomKeys = w.config.ETFKeys != config.ETFKeysBinary
+	encoder.Omit = omittedColumns(w.config, rows.Type().Elem())
+
+	encoder.List(rows.Len())
+	for i := range rows.Len() {
+		if err := encoder.Element(rows.Index(i).Addr().Interface()); err != nil {
+			return fmt.Errorf("failed to encode record %d to %s: %w", i, path, err)
+		}
+	}
+	encoder.End()
+
+	if err := encoder.Flush(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", path, err)
+	}
+
+	if w.config.Verbose {
+		w.config.Logf("  Wrote %s (%d records)\n", filename, rows.Len())
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package writer
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+func TestETFWriter_WriteAll(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:   tmpDir,
+		Compression: config.CompressionGzip,
+		Tables:      []string{config.TableWormholeClasses},
+	}
+
+	data := &models.ConvertedData{
+		Universe: &models.UniverseData{},
+		WormholeClasses: []models.WormholeClassLocation{
+			{LocationID: 10000001, WormholeClassID: 7},
+		},
+	}
+
+	w, err := NewWriter(&config.Config{OutputDir: tmpDir, OutputFormat: config.FormatETF})
+	if err != nil {
+		t.Fatalf("NewWriter failed: %v", err)
+	}
+	if _, ok := w.(*ETFWriter); !ok {
+		t.Fatalf("expected *ETFWriter, got %T", w)
+	}
+
+	if err := NewETFWriter(cfg).WriteAll(data); err != nil {
+		t.Fatalf("WriteAll failed: %v", err)
+	}
+
+	name := OutputFile(config.FormatETF, cfg.Compression, config.TableWormholeClasses)
+	if name != "mapLocationWormholeClasses.etf.gz" {
+		t.Errorf("unexpected file name %s", name)
+	}
+
+	// [%{locationID: 10000001, wormholeClassID: 7}]
+	want := []byte{131, 108, 0, 0, 0, 1, 116, 0, 0, 0, 2}
+	want = append(want, 119, 10)
+	want = append(want, "locationID"...)
+	want = append(want, 98, 0, 0x98, 0x96, 0x81)
+	want = append(want, 119, 15)
+	want = append(want, "wormholeClassID"...)
+	want = append(want, 97, 7, 106)
+
+	got := readCompressed(t, filepath.Join(tmpDir, name), cfg.Compression)
+	if !bytes.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestETFWriter_BinaryKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir: tmpDir,
+		ETFKeys:   config.ETFKeysBinary,
+		Tables:    []string{config.TableWormholeClasses},
+	}
+
+	data := &models.ConvertedData{
+		Universe:        &models.UniverseData{},
+		WormholeClasses: []models.WormholeClassLocation{{LocationID: 1, WormholeClassID: 2}},
+	}
+	if err := NewETFWriter(cfg).WriteAll(data); err != nil {
+		t.Fatalf("WriteAll failed: %v", err)
+	}
+
+	got := readCompressed(t, filepath.Join(tmpDir, "mapLocationWormholeClasses.etf"), config.CompressionNone)
+	key := append([]byte{109, 0, 0, 0, 10}, "locationID"...)
+	if !bytes.Contains(got, key) {
+		t.Errorf("expected binary key in %v", got)
+	}
+}
@@ -21,6 +21,31 @@ const (
 	FileShipTypes       = "invTypes.json"
 	FileItemGroups      = "invGroups.json"
 	FileSystemJumps     = "mapSolarSystemJumps.json"
+
+	FileSystemWormholeClasses = "mapSolarSystemWormholeClasses.json"
+	FileRegionJumps           = "mapRegionJumps.json"
+	FileConstellationJumps    = "mapConstellationJumps.json"
+	FileStargates             = "mapStargates.json"
+	FileSystemTopology        = "mapSystemTopology.json"
+
+	FileStations          = "staStations.json"
+	FileStationServices   = "staServices.json"
+	FileOperationServices = "staOperationServices.json"
+
+	FileFactions     = "chrFactions.json"
+	FileRaces        = "chrRaces.json"
+	FileCorporations = "crpNPCCorporations.json"
+	FileMarketGroups = "invMarketGroups.json"
+
+	FileDogmaAttributes = "dgmAttributeTypes.json"
+	FileDogmaEffects    = "dgmEffects.json"
+	FileTypeAttributes  = "dgmTypeAttributes.json"
+	FileTypeEffects     = "dgmTypeEffects.json"
+
+	// FileMarketGroupTree is written in every format when Config.MarketGroupTree is set.
+	FileMarketGroupTree = "invMarketGroupsTree.json"
+	// FileTopologyReport is written in every format with the systemTopology table.
+	FileTopologyReport = "mapSystemTopologyReport.json"
 )
 
 // PassthroughFiles lists the community-maintained JSON files to copy.
@@ -41,6 +66,13 @@ type JSONWriter struct {
 	config    *config.Config
 	outputDir string
 	pretty    bool
+	// ndjson writes tables one record per line instead of as arrays.
+	ndjson bool
+	// bundle collects the JSON files into one during WriteBundle.
+	bundle *bundle
+	// tableNames names files after their table (systems.json, ...), as in
+	// region directories.
+	tableNames bool
 }
 
 // New creates a new JSONWriter with the given configuration.
@@ -60,36 +92,58 @@ func (w *JSONWriter) WriteAll(data *models.ConvertedData) error {
 	}
 
 	if w.config.Verbose {
-		fmt.Printf("Writing JSON files to: %s\n", w.outputDir)
-	}
-
-	// Write all data files
-	if err := w.WriteSolarSystems(data.Universe.SolarSystems); err != nil {
-		return fmt.Errorf("failed to write solar systems: %w", err)
-	}
-
-	if err := w.WriteRegions(data.Universe.Regions); err != nil {
-		return fmt.Errorf("failed to write regions: %w", err)
+		w.config.Logf("Writing JSON files to: %s\n", w.outputDir)
 	}
 
-	if err := w.WriteConstellations(data.Universe.Constellations); err != nil {
-		return fmt.Errorf("failed to write constellations: %w", err)
+	// Write all enabled data files
+	tables := []struct {
+		name  string
+		label string
+		write func() error
+	}{
+		{config.TableSystems, "solar systems", func() error { return w.WriteSolarSystems(data.Universe.SolarSystems) }},
+		{config.TableRegions, "regions", func() error { return w.WriteRegions(data.Universe.Regions) }},
+		{config.TableConstellations, "constellations", func() error { return w.WriteConstellations(data.Universe.Constellations) }},
+		{config.TableWormholeClasses, "wormhole classes", func() error { return w.WriteWormholeClasses(data.WormholeClasses) }},
+		{config.TableSystemWormholeClasses, "system wormhole classes", func() error { return w.WriteSystemWormholeClasses(data.SystemWormholeClasses) }},
+		{config.TableTypes, "types", func() error { return w.WriteTypes(data.InvTypes) }},
+		{config.TableGroups, "groups", func() error { return w.WriteGroups(data.InvGroups) }},
+		{config.TableJumps, "system jumps", func() error { return w.WriteSystemJumps(data.SystemJumps) }},
+		{config.TableRegionJumps, "region jumps", func() error { return w.WriteRegionJumps(data.RegionJumps) }},
+		{config.TableConstellationJumps, "constellation jumps", func() error { return w.WriteConstellationJumps(data.ConstellationJumps) }},
+		{config.TableStargates, "stargates", func() error { return w.WriteStargates(data.Stargates) }},
+		{config.TableSystemTopology, "system topology", func() error { return w.WriteSystemTopology(data.SystemTopology, data.TopologyReport) }},
+		{config.TableStations, "stations", func() error { return w.WriteStations(data.Stations) }},
+		{config.TableStationServices, "station services", func() error { return w.WriteStationServices(data.StationServices) }},
+		{config.TableOperationServices, "operation services", func() error { return w.WriteOperationServices(data.OperationServices) }},
+		{config.TableFactions, "factions", func() error { return w.WriteFactions(data.Factions) }},
+		{config.TableRaces, "races", func() error { return w.WriteRaces(data.Races) }},
+		{config.TableCorporations, "corporations", func() error { return w.WriteCorporations(data.Corporations) }},
+		{config.TableMarketGroups, "market groups", func() error { return w.WriteMarketGroups(data.MarketGroups, data.MarketGroupTree) }},
+		{config.TableDogmaAttributes, "dogma attributes", func() error { return w.WriteDogmaAttributes(data.DogmaAttributes) }},
+		{config.TableDogmaEffects, "dogma effects", func() error { return w.WriteDogmaEffects(data.DogmaEffects) }},
+		{config.TableTypeAttributes, "type attributes", func() error { return w.WriteTypeAttributes(data.TypeAttributes) }},
+		{config.TableTypeEffects, "type effects", func() error { return w.WriteTypeEffects(data.TypeEffects) }},
 	}
 
-	if err := w.WriteWormholeClasses(data.WormholeClasses); err != nil {
-		return fmt.Errorf("failed to write wormhole classes: %w", err)
-	}
-
-	if err := w.WriteTypes(data.InvTypes); err != nil {
-		return fmt.Errorf("failed to write types: %w", err)
-	}
-
-	if err := w.WriteGroups(data.InvGroups); err != nil {
-		return fmt.Errorf("failed to write groups: %w", err)
+	for _, table := range tables {
+		if !w.config.TableEnabled(table.name) {
+			continue
+		}
+		if err := table.write(); err != nil {
+			return fmt.Errorf("failed to write %s: %w", table.label, err)
+		}
 	}
 
-	if err := w.WriteSystemJumps(data.SystemJumps); err != nil {
-		return fmt.Errorf("failed to write system jumps: %w", err)
+	if w.config.SplitBy == config.SplitByRegion {
+		err := writeRegionSplit(w.config, data, ".json", func(cfg *config.Config) regionTableWriter {
+			rw := New(cfg)
+			rw.tableNames = true
+			return rw
+		})
+		if err != nil {
+			return fmt.Errorf("failed to split by region: %w", err)
+		}
 	}
 
 	return nil
@@ -97,37 +151,203 @@ func (w *JSONWriter) WriteAll(data *models.ConvertedData) error {
 
 // WriteSolarSystems writes solar system data to JSON.
 func (w *JSONWriter) WriteSolarSystems(systems []models.SolarSystem) error {
-	return w.writeJSON(FileSolarSystems, systems)
+	if !w.localized() {
+		return w.writeTable(FileSolarSystems, systems)
+	}
+	records := make([]localizedRecord, len(systems))
+	for i := range systems {
+		s := &systems[i]
+		records[i] = w.localize(s, localizedField{"solarSystemName", s.SolarSystemName, s.Names})
+	}
+	return w.writeTable(FileSolarSystems, records)
 }
 
 // WriteRegions writes region data to JSON.
 func (w *JSONWriter) WriteRegions(regions []models.Region) error {
-	return w.writeJSON(FileRegions, regions)
+	if !w.localized() {
+		return w.writeTable(FileRegions, regions)
+	}
+	records := make([]localizedRecord, len(regions))
+	for i := range regions {
+		r := &regions[i]
+		records[i] = w.localize(r, localizedField{"regionName", r.RegionName, r.Names})
+	}
+	return w.writeTable(FileRegions, records)
 }
 
 // WriteConstellations writes constellation data to JSON.
 func (w *JSONWriter) WriteConstellations(constellations []models.Constellation) error {
-	return w.writeJSON(FileConstellations, constellations)
+	if !w.localized() {
+		return w.writeTable(FileConstellations, constellations)
+	}
+	records := make([]localizedRecord, len(constellations))
+	for i := range constellations {
+		c := &constellations[i]
+		records[i] = w.localize(c, localizedField{"constellationName", c.ConstellationName, c.Names})
+	}
+	return w.writeTable(FileConstellations, records)
 }
 
 // WriteWormholeClasses writes wormhole class data to JSON.
 func (w *JSONWriter) WriteWormholeClasses(classes []models.WormholeClassLocation) error {
-	return w.writeJSON(FileWormholeClasses, classes)
+	return w.writeTable(FileWormholeClasses, classes)
+}
+
+// WriteSystemWormholeClasses writes the effective wormhole class of each system to JSON.
+func (w *JSONWriter) WriteSystemWormholeClasses(classes []models.SystemWormholeClass) error {
+	return w.writeTable(FileSystemWormholeClasses, classes)
 }
 
 // WriteTypes writes type data to JSON.
 func (w *JSONWriter) WriteTypes(types []models.InvType) error {
-	return w.writeJSON(FileShipTypes, types)
+	if !w.localized() {
+		return w.writeTable(FileShipTypes, types)
+	}
+	records := make([]localizedRecord, len(types))
+	for i := range types {
+		t := &types[i]
+		records[i] = w.localize(t,
+			localizedField{"typeName", t.TypeName, t.Names},
+			localizedField{"description", t.Description, t.Descriptions})
+	}
+	return w.writeTable(FileShipTypes, records)
 }
 
 // WriteGroups writes group data to JSON.
 func (w *JSONWriter) WriteGroups(groups []models.InvGroup) error {
-	return w.writeJSON(FileItemGroups, groups)
+	if !w.localized() {
+		return w.writeTable(FileItemGroups, groups)
+	}
+	records := make([]localizedRecord, len(groups))
+	for i := range groups {
+		g := &groups[i]
+		records[i] = w.localize(g, localizedField{"groupName", g.GroupName, g.Names})
+	}
+	return w.writeTable(FileItemGroups, records)
 }
 
 // WriteSystemJumps writes system jump data to JSON.
 func (w *JSONWriter) WriteSystemJumps(jumps []models.SystemJump) error {
-	return w.writeJSON(FileSystemJumps, jumps)
+	return w.writeTable(FileSystemJumps, jumps)
+}
+
+// WriteRegionJumps writes region connections to JSON.
+func (w *JSONWriter) WriteRegionJumps(jumps []models.RegionJump) error {
+	return w.writeTable(FileRegionJumps, jumps)
+}
+
+// WriteConstellationJumps writes constellation connections to JSON.
+func (w *JSONWriter) WriteConstellationJumps(jumps []models.ConstellationJump) error {
+	return w.writeTable(FileConstellationJumps, jumps)
+}
+
+// WriteStargates writes stargate data to JSON.
+func (w *JSONWriter) WriteStargates(gates []models.Stargate) error {
+	return w.writeTable(FileStargates, gates)
+}
+
+// WriteSystemTopology writes per-system topology metrics to JSON, plus the
+// report when given.
+func (w *JSONWriter) WriteSystemTopology(rows []models.SystemTopology, report *models.TopologyReport) error {
+	if err := w.writeTable(FileSystemTopology, rows); err != nil || report == nil {
+		return err
+	}
+	return w.writeJSON(FileTopologyReport, report)
+}
+
+// WriteStations writes NPC station data to JSON.
+func (w *JSONWriter) WriteStations(stations []models.StaStation) error {
+	return w.writeTable(FileStations, stations)
+}
+
+// WriteStationServices writes station service data to JSON.
+func (w *JSONWriter) WriteStationServices(services []models.StaService) error {
+	return w.writeTable(FileStationServices, services)
+}
+
+// WriteOperationServices writes station operation service links to JSON.
+func (w *JSONWriter) WriteOperationServices(links []models.StaOperationService) error {
+	return w.writeTable(FileOperationServices, links)
+}
+
+// WriteFactions writes faction data to JSON.
+func (w *JSONWriter) WriteFactions(factions []models.ChrFaction) error {
+	if !w.localized() {
+		return w.writeTable(FileFactions, factions)
+	}
+	records := make([]localizedRecord, len(factions))
+	for i := range factions {
+		f := &factions[i]
+		records[i] = w.localize(f, localizedField{"factionName", f.FactionName, f.Names})
+	}
+	return w.writeTable(FileFactions, records)
+}
+
+// WriteRaces writes race data to JSON.
+func (w *JSONWriter) WriteRaces(races []models.ChrRace) error {
+	if !w.localized() {
+		return w.writeTable(FileRaces, races)
+	}
+	records := make([]localizedRecord, len(races))
+	for i := range races {
+		r := &races[i]
+		records[i] = w.localize(r, localizedField{"raceName", r.RaceName, r.Names})
+	}
+	return w.writeTable(FileRaces, records)
+}
+
+// WriteCorporations writes NPC corporation data to JSON.
+func (w *JSONWriter) WriteCorporations(corporations []models.CrpNPCCorporation) error {
+	if !w.localized() {
+		return w.writeTable(FileCorporations, corporations)
+	}
+	records := make([]localizedRecord, len(corporations))
+	for i := range corporations {
+		c := &corporations[i]
+		records[i] = w.localize(c, localizedField{"corporationName", c.CorporationName, c.Names})
+	}
+	return w.writeTable(FileCorporations, records)
+}
+
+// WriteMarketGroups writes market group data to JSON, plus the nested tree when given.
+func (w *JSONWriter) WriteMarketGroups(groups []models.InvMarketGroup, tree []models.MarketGroupNode) error {
+	var err error
+	if !w.localized() {
+		err = w.writeTable(FileMarketGroups, groups)
+	} else {
+		records := make([]localizedRecord, len(groups))
+		for i := range groups {
+			g := &groups[i]
+			records[i] = w.localize(g,
+				localizedField{"marketGroupName", g.MarketGroupName, g.Names},
+				localizedField{"description", g.Description, g.Descriptions})
+		}
+		err = w.writeTable(FileMarketGroups, records)
+	}
+	if err != nil || tree == nil {
+		return err
+	}
+	return w.writeJSON(FileMarketGroupTree, tree)
+}
+
+// WriteDogmaAttributes writes dogma attribute definitions to JSON.
+func (w *JSONWriter) WriteDogmaAttributes(attributes []models.DgmAttributeType) error {
+	return w.writeTable(FileDogmaAttributes, attributes)
+}
+
+// WriteDogmaEffects writes dogma effect definitions to JSON.
+func (w *JSONWriter) WriteDogmaEffects(effects []models.DgmEffect) error {
+	return w.writeTable(FileDogmaEffects, effects)
+}
+
+// WriteTypeAttributes writes per-type dogma attribute values to JSON.
+func (w *JSONWriter) WriteTypeAttributes(values []models.DgmTypeAttribute) error {
+	return w.writeTable(FileTypeAttributes, values)
+}
+
+// WriteTypeEffects writes per-type dogma effects to JSON.
+func (w *JSONWriter) WriteTypeEffects(effects []models.DgmTypeEffect) error {
+	return w.writeTable(FileTypeEffects, effects)
 }
 
 // CopyPassthroughFiles copies community-maintained JSON files from the source directory.
@@ -137,45 +357,71 @@ func (w *JSONWriter) CopyPassthroughFiles(sourceDir string) error {
 	}
 
 	if w.config.Verbose {
-		fmt.Printf("Copying passthrough files from: %s\n", sourceDir)
+		w.config.Logf("Copying passthrough files from: %s\n", sourceDir)
 	}
 
 	var copied, skipped int
 	for _, filename := range PassthroughFiles {
 		srcPath := filepath.Join(sourceDir, filename)
-		dstPath := filepath.Join(w.outputDir, filename)
+		dstPath := filepath.Join(w.outputDir, filename+w.config.Compression.Extension())
 
 		// Check if source file exists
 		if _, err := os.Stat(srcPath); os.IsNotExist(err) {
 			if w.config.Verbose {
-				fmt.Printf("  Skipping %s (not found)\n", filename)
+				w.config.Logf("  Skipping %s (not found)\n", filename)
 			}
 			skipped++
 			continue
 		}
 
-		if err := copyFile(srcPath, dstPath); err != nil {
+		if err := copyFile(srcPath, dstPath, w.config.Compression); err != nil {
 			return fmt.Errorf("failed to copy %s: %w", filename, err)
 		}
 
 		if w.config.Verbose {
-			fmt.Printf("  Copied %s\n", filename)
+			w.config.Logf("  Copied %s\n", filename)
 		}
 		copied++
 	}
 
 	if w.config.Verbose {
-		fmt.Printf("Passthrough complete: %d copied, %d skipped\n", copied, skipped)
+		w.config.Logf("Passthrough complete: %d copied, %d skipped\n", copied, skipped)
 	}
 
 	return nil
 }
 
-// writeJSON marshals data to JSON and writes it to a file.
+// writeTable writes the records of a table as NDJSON, or as JSON laid out
+// as configured.
+func (w *JSONWriter) writeTable(filename string, records interface{}) error {
+	if w.ndjson {
+		return w.writeNDJSON(ndjsonFile(filename), records)
+	}
+	if w.config.JSONLayout == config.JSONLayoutKeyed {
+		keyed, err := keyedRecords(filename, records)
+		if err != nil {
+			return fmt.Errorf("failed to key %s: %w", filename, err)
+		}
+		records = keyed
+	}
+	if w.tableNames {
+		filename = regionFile(jsonTableFiles, filename)
+	}
+	return w.writeJSON(filename, records)
+}
+
+// writeJSON marshals data to JSON and writes it to a file, adding the
+// compression extension to filename. During WriteBundle the data is added
+// to the bundle instead.
 func (w *JSONWriter) writeJSON(filename string, data interface{}) error {
+	if w.bundle != nil {
+		return w.bundle.add(filename, data)
+	}
+
+	filename += w.config.Compression.Extension()
 	path := filepath.Join(w.outputDir, filename)
 
-	file, err := os.Create(path)
+	file, err := CreateFile(path, 0644, w.config.Compression)
 	if err != nil {
 		return fmt.Errorf("failed to create file %s: %w", path, err)
 	}
@@ -189,16 +435,19 @@ func (w *JSONWriter) writeJSON(filename string, data interface{}) error {
 	if err := encoder.Encode(data); err != nil {
 		return fmt.Errorf("failed to encode JSON to %s: %w", path, err)
 	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", path, err)
+	}
 
 	if w.config.Verbose {
-		fmt.Printf("  Wrote %s\n", filename)
+		w.config.Logf("  Wrote %s\n", filename)
 	}
 
 	return nil
 }
 
-// copyFile copies a single file from src to dst.
-func copyFile(src, dst string) error {
+// copyFile copies a single file from src to dst, compressing it as configured.
+func copyFile(src, dst string, compression config.Compression) error {
 	srcFile, err := os.Open(src)
 	if err != nil {
 		return err
@@ -211,12 +460,14 @@ func copyFile(src, dst string) error {
 		return err
 	}
 
-	dstFile, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, srcInfo.Mode())
+	dstFile, err := CreateFile(dst, srcInfo.Mode(), compression)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = dstFile.Close() }()
 
-	_, err = io.Copy(dstFile, srcFile)
-	return err
+	if _, err := io.Copy(dstFile, srcFile); err != nil {
+		return err
+	}
+	return dstFile.Close()
 }
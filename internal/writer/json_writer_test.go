@@ -198,3 +198,132 @@ func TestJSONWriter_PrettyPrint(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONWriter_Languages(t *testing.T) {
+	regions := []models.Region{
+		{RegionID: 10000002, RegionName: "The Forge", Names: models.LocalizedText{"en": "The Forge", "de": "Die Schmiede"}},
+	}
+
+	t.Run("flat", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		w := New(&config.Config{OutputDir: tmpDir, Languages: []string{"de", "fr"}})
+		if err := w.WriteRegions(regions); err != nil {
+			t.Fatalf("WriteRegions failed: %v", err)
+		}
+
+		var parsed []map[string]interface{}
+		readJSON(t, filepath.Join(tmpDir, FileRegions), &parsed)
+
+		if parsed[0]["regionName"] != "The Forge" {
+			t.Errorf("Expected English regionName, got %v", parsed[0]["regionName"])
+		}
+		if parsed[0]["regionName_de"] != "Die Schmiede" {
+			t.Errorf("Expected German regionName_de, got %v", parsed[0]["regionName_de"])
+		}
+		if parsed[0]["regionName_fr"] != "The Forge" {
+			t.Errorf("Expected English fallback for regionName_fr, got %v", parsed[0]["regionName_fr"])
+		}
+		if parsed[0]["regionID"] != float64(10000002) {
+			t.Errorf("Expected regionID to be kept, got %v", parsed[0]["regionID"])
+		}
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		w := New(&config.Config{OutputDir: tmpDir, Languages: []string{"de"}, NestedNames: true})
+		if err := w.WriteRegions(regions); err != nil {
+			t.Fatalf("WriteRegions failed: %v", err)
+		}
+
+		var parsed []struct {
+			RegionID   int64             `json:"regionID"`
+			RegionName map[string]string `json:"regionName"`
+		}
+		readJSON(t, filepath.Join(tmpDir, FileRegions), &parsed)
+
+		if parsed[0].RegionName["en"] != "The Forge" || parsed[0].RegionName["de"] != "Die Schmiede" {
+			t.Errorf("Unexpected nested regionName: %v", parsed[0].RegionName)
+		}
+		if parsed[0].RegionID != 10000002 {
+			t.Errorf("Expected regionID 10000002, got %d", parsed[0].RegionID)
+		}
+	})
+
+	t.Run("nested with quotes in name", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		w := New(&config.Config{OutputDir: tmpDir, NestedNames: true})
+		types := []models.InvType{
+			{TypeID: 1, TypeName: `"Quoted" <Name>`, Description: `typeName":"x`},
+		}
+		if err := w.WriteTypes(types); err != nil {
+			t.Fatalf("WriteTypes failed: %v", err)
+		}
+
+		var parsed []struct {
+			TypeName    map[string]string `json:"typeName"`
+			Description map[string]string `json:"description"`
+		}
+		readJSON(t, filepath.Join(tmpDir, FileShipTypes), &parsed)
+
+		if parsed[0].TypeName["en"] != `"Quoted" <Name>` {
+			t.Errorf("Unexpected typeName: %v", parsed[0].TypeName)
+		}
+		if parsed[0].Description["en"] != `typeName":"x` {
+			t.Errorf("Unexpected description: %v", parsed[0].Description)
+		}
+	})
+}
+
+func TestLocalizedRecord_MarshalJSON(t *testing.T) {
+	factionID := int64(500001)
+	security := 0.9459
+	system := models.SolarSystem{
+		RegionID: 10000002, SolarSystemID: 30000142, SolarSystemName: "Jita",
+		FactionID: &factionID, DisplaySecurity: &security, SecurityClass: "B",
+		Names: models.LocalizedText{"de": "Jita DE"},
+	}
+
+	// Without translations the record encodes exactly as encoding/json does
+	plain, err := json.Marshal(localizedRecord{record: &system})
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	want, _ := json.Marshal(system)
+	if string(plain) != string(want) {
+		t.Errorf("unexpected encoding:\n got %s\nwant %s", plain, want)
+	}
+
+	// A description holding the same text as the name is left alone
+	typ := models.InvType{TypeID: 587, TypeName: "Rifter", Description: "Rifter", Names: models.LocalizedText{"de": "Rifter DE"}}
+	nested, err := json.Marshal(localizedRecord{
+		record:    &typ,
+		fields:    []localizedField{{"typeName", typ.TypeName, typ.Names}},
+		languages: []string{"de"},
+		nested:    true,
+	})
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	var parsed struct {
+		TypeName    map[string]string `json:"typeName"`
+		Description string            `json:"description"`
+	}
+	if err := json.Unmarshal(nested, &parsed); err != nil {
+		t.Fatalf("failed to decode %s: %v", nested, err)
+	}
+	if parsed.TypeName["de"] != "Rifter DE" || parsed.Description != "Rifter" {
+		t.Errorf("unexpected nested record: %s", nested)
+	}
+}
+
+// readJSON reads and decodes a JSON file into target.
+func readJSON(t *testing.T, path string, target interface{}) {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	if err := json.Unmarshal(content, target); err != nil {
+		t.Fatalf("failed to decode %s: %v", path, err)
+	}
+}
@@ -0,0 +1,112 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// keyColumns names the unique ID field of each JSON table written as an
+// object by the keyed layout. Link tables without a single-column key, such
+// as jumps and per-type dogma values, stay arrays.
+var keyColumns = map[string]string{
+	FileSolarSystems:          "SolarSystemID",
+	FileRegions:               "RegionID",
+	FileConstellations:        "ConstellationID",
+	FileWormholeClasses:       "LocationID",
+	FileSystemWormholeClasses: "SolarSystemID",
+	FileShipTypes:             "TypeID",
+	FileItemGroups:            "GroupID",
+	FileStargates:             "StargateID",
+	FileSystemTopology:        "SolarSystemID",
+	FileStations:              "StationID",
+	FileStationServices:       "ServiceID",
+	FileFactions:              "FactionID",
+	FileRaces:                 "RaceID",
+	FileCorporations:          "CorporationID",
+	FileMarketGroups:          "MarketGroupID",
+	FileDogmaAttributes:       "AttributeID",
+	FileDogmaEffects:          "EffectID",
+}
+
+// jsonObject is a JSON object that keeps its members in insertion order,
+// where a map would be encoded with sorted keys.
+type jsonObject struct {
+	keys   []string
+	values []interface{}
+}
+
+// add appends a member to the object.
+func (o *jsonObject) add(key string, value interface{}) {
+	o.keys = append(o.keys, key)
+	o.values = append(o.values, value)
+}
+
+// set replaces the value of an existing member, reporting whether it exists.
+func (o *jsonObject) set(key string, value interface{}) bool {
+	for i, k := range o.keys {
+		if k == key {
+			o.values[i] = value
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON encodes the members in order.
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, fmt.Errorf("member %s: %w", key, err)
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// keyedRecords returns the records of a JSON table as an object mapping the
+// table's ID column to each record, in record order. Tables without an ID
+// column are returned unchanged.
+func keyedRecords(filename string, records interface{}) (interface{}, error) {
+	column, ok := keyColumns[filename]
+	if !ok {
+		return records, nil
+	}
+
+	rows := reflect.ValueOf(records)
+	object := &jsonObject{
+		keys:   make([]string, 0, rows.Len()),
+		values: make([]interface{}, 0, rows.Len()),
+	}
+	seen := make(map[int64]bool, rows.Len())
+	for i := range rows.Len() {
+		record := rows.Index(i).Addr().Interface()
+		value := rows.Index(i)
+		if localized, ok := record.(*localizedRecord); ok {
+			value = reflect.Indirect(reflect.ValueOf(localized.record))
+		}
+
+		id := value.FieldByName(column).Int()
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate %s %d", column, id)
+		}
+		seen[id] = true
+		object.add(strconv.FormatInt(id, 10), record)
+	}
+	return object, nil
+}
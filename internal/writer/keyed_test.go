@@ -0,0 +1,61 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+func TestJSONWriter_KeyedLayout(t *testing.T) {
+	tmpDir := t.TempDir()
+	w := New(&config.Config{OutputDir: tmpDir, JSONLayout: config.JSONLayoutKeyed, Languages: []string{"de"}})
+
+	regions := []models.Region{
+		{RegionID: 10000002, RegionName: "The Forge", Names: models.LocalizedText{"de": "Die Schmiede"}},
+		{RegionID: 10000001, RegionName: "Derelik"},
+	}
+	if err := w.WriteRegions(regions); err != nil {
+		t.Fatalf("WriteRegions failed: %v", err)
+	}
+	jumps := []models.SystemJump{{FromSolarSystemID: 30000142, ToSolarSystemID: 30000144}}
+	if err := w.WriteSystemJumps(jumps); err != nil {
+		t.Fatalf("WriteSystemJumps failed: %v", err)
+	}
+
+	var parsed map[string]map[string]interface{}
+	readJSON(t, filepath.Join(tmpDir, FileRegions), &parsed)
+	if len(parsed) != 2 {
+		t.Fatalf("expected 2 regions, got %d", len(parsed))
+	}
+	forge := parsed["10000002"]
+	if forge["regionName"] != "The Forge" || forge["regionName_de"] != "Die Schmiede" {
+		t.Errorf("unexpected record for 10000002: %v", forge)
+	}
+
+	// Keys keep the record order rather than being sorted
+	content, err := os.ReadFile(filepath.Join(tmpDir, FileRegions))
+	if err != nil {
+		t.Fatalf("failed to read regions: %v", err)
+	}
+	if strings.Index(string(content), `"10000002"`) > strings.Index(string(content), `"10000001"`) {
+		t.Errorf("expected keys in record order:\n%s", content)
+	}
+
+	// Tables without an ID column stay arrays
+	var parsedJumps []models.SystemJump
+	readJSON(t, filepath.Join(tmpDir, FileSystemJumps), &parsedJumps)
+	if len(parsedJumps) != 1 {
+		t.Errorf("expected 1 jump, got %d", len(parsedJumps))
+	}
+}
+
+func TestKeyedRecords_Duplicate(t *testing.T) {
+	types := []models.InvType{{TypeID: 587}, {TypeID: 587}}
+	if _, err := keyedRecords(FileShipTypes, types); err == nil {
+		t.Error("expected an error for a duplicate typeID")
+	}
+}
@@ -0,0 +1,109 @@
+package writer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// localizedField describes a translated JSON field of a record.
+type localizedField struct {
+	key     string
+	english string
+	text    models.LocalizedText
+}
+
+// localizedRecord wraps a record so its JSON encoding carries translations,
+// either as extra "<key>_<lang>" fields or, when nested, by replacing the
+// field value with a {lang: text} object.
+type localizedRecord struct {
+	record    interface{}
+	fields    []localizedField
+	languages []string
+	nested    bool
+}
+
+// MarshalJSON encodes the wrapped record with its translations.
+func (r localizedRecord) MarshalJSON() ([]byte, error) {
+	object, err := recordObject(r.record)
+	if err != nil {
+		return nil, err
+	}
+
+	extra := models.ExtraLanguages(r.languages)
+	for _, field := range r.fields {
+		if r.nested {
+			names := map[string]string{models.DefaultLanguage: field.english}
+			for _, lang := range extra {
+				names[lang] = field.text.Get(lang, field.english)
+			}
+			if !object.set(field.key, names) {
+				return nil, fmt.Errorf("field %q not found in record", field.key)
+			}
+			continue
+		}
+		for _, lang := range extra {
+			object.add(field.key+"_"+lang, field.text.Get(lang, field.english))
+		}
+	}
+	return object.MarshalJSON()
+}
+
+// recordObject returns the members encoding/json would write for a struct
+// or pointer to struct, in field order. Fields tagged "-" are skipped and
+// empty omitempty fields are left out.
+func recordObject(record interface{}) (*jsonObject, error) {
+	value := reflect.Indirect(reflect.ValueOf(record))
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("record is a %s, not a struct", value.Kind())
+	}
+
+	object := &jsonObject{}
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && options == "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if options == "omitempty" && emptyValue(value.Field(i)) {
+			continue
+		}
+		object.add(name, value.Field(i).Interface())
+	}
+	return object, nil
+}
+
+// emptyValue reports whether omitempty leaves v out.
+func emptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	default:
+		return v.IsZero()
+	}
+}
+
+// localized reports whether JSON records need translation handling.
+func (w *JSONWriter) localized() bool {
+	return w.config.NestedNames || len(models.ExtraLanguages(w.config.Languages)) > 0
+}
+
+// localize wraps a record with its translated fields.
+func (w *JSONWriter) localize(record interface{}, fields ...localizedField) localizedRecord {
+	return localizedRecord{
+		record:    record,
+		fields:    fields,
+		languages: w.config.Languages,
+		nested:    w.config.NestedNames,
+	}
+}
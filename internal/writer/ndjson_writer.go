@@ -0,0 +1,66 @@
+package writer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+)
+
+// NewNDJSONWriter creates a JSONWriter that writes each table as
+// newline-delimited JSON (one record per line). Side files such as the
+// market group tree are still written as plain JSON documents.
+func NewNDJSONWriter(cfg *config.Config) *JSONWriter {
+	w := New(cfg)
+	w.ndjson = true
+	return w
+}
+
+// ndjsonFile returns the NDJSON file name for a JSON file name.
+func ndjsonFile(jsonFile string) string {
+	return strings.TrimSuffix(jsonFile, ".json") + ".ndjson"
+}
+
+// writeNDJSON streams the elements of the records slice to a file, one JSON
+// value per line, without building the whole array in memory.
+func (w *JSONWriter) writeNDJSON(filename string, records interface{}) error {
+	rows := reflect.ValueOf(records)
+	if rows.Kind() != reflect.Slice {
+		return fmt.Errorf("cannot write %T as NDJSON: not a slice", records)
+	}
+
+	filename += w.config.Compression.Extension()
+	path := filepath.Join(w.outputDir, filename)
+
+	file, err := CreateFile(path, 0644, w.config.Compression)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+	buf := bufio.NewWriter(file)
+
+	// Encode adds the newline after each record
+	encoder := json.NewEncoder(buf)
+	for i := range rows.Len() {
+		if err := encoder.Encode(rows.Index(i).Addr().Interface()); err != nil {
+			return fmt.Errorf("failed to encode record %d to %s: %w", i, path, err)
+		}
+	}
+
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", path, err)
+	}
+
+	if w.config.Verbose {
+		w.config.Logf("  Wrote %s (%d records)\n", filename, rows.Len())
+	}
+
+	return nil
+}
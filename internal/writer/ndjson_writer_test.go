@@ -0,0 +1,123 @@
+package writer
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// readNDJSON decodes each line of an NDJSON file into a map.
+func readNDJSON(t *testing.T, r io.Reader) []map[string]interface{} {
+	t.Helper()
+	var records []map[string]interface{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var record map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("line %d is not a JSON object: %v", len(records)+1, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("failed to read NDJSON: %v", err)
+	}
+	return records
+}
+
+func ndjsonTestData() *models.ConvertedData {
+	return &models.ConvertedData{
+		Universe: &models.UniverseData{
+			Regions: []models.Region{
+				{RegionID: 10000002, RegionName: "The Forge"},
+				{RegionID: 10000043, RegionName: "Domain"},
+			},
+		},
+		InvTypes: []models.InvType{
+			{TypeID: 587, GroupID: 25, TypeName: "Rifter"},
+			{TypeID: 588, GroupID: 29, TypeName: "Reaper", Names: models.LocalizedText{"de": "Reaper DE"}},
+		},
+		MarketGroupTree: []models.MarketGroupNode{{MarketGroupID: 4, MarketGroupName: "Ships"}},
+	}
+}
+
+func TestNDJSONWriter_WriteAll(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:   tmpDir,
+		PrettyPrint: true,
+		Tables:      []string{config.TableRegions, config.TableTypes, config.TableMarketGroups},
+		Languages:   []string{"de"},
+	}
+
+	if err := NewNDJSONWriter(cfg).WriteAll(ndjsonTestData()); err != nil {
+		t.Fatalf("WriteAll failed: %v", err)
+	}
+
+	f, err := os.Open(filepath.Join(tmpDir, "invTypes.ndjson"))
+	if err != nil {
+		t.Fatalf("invTypes.ndjson was not created: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	// One compact record per line, pretty printing does not apply
+	types := readNDJSON(t, f)
+	if len(types) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(types))
+	}
+	if types[1]["typeName"] != "Reaper" || types[1]["typeName_de"] != "Reaper DE" {
+		t.Errorf("unexpected record: %v", types[1])
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, FileShipTypes)); !os.IsNotExist(err) {
+		t.Errorf("%s should not be written in NDJSON format", FileShipTypes)
+	}
+
+	// Side files stay plain JSON documents
+	content, err := os.ReadFile(filepath.Join(tmpDir, FileMarketGroupTree))
+	if err != nil {
+		t.Fatalf("market group tree was not written: %v", err)
+	}
+	var tree []map[string]interface{}
+	if err := json.Unmarshal(content, &tree); err != nil || len(tree) != 1 {
+		t.Errorf("expected a JSON array with one root group, got %s (%v)", content, err)
+	}
+}
+
+func TestNDJSONWriter_Gzip(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:   tmpDir,
+		Compression: config.CompressionGzip,
+		Tables:      []string{config.TableRegions},
+	}
+
+	if err := NewNDJSONWriter(cfg).WriteAll(ndjsonTestData()); err != nil {
+		t.Fatalf("WriteAll failed: %v", err)
+	}
+
+	name := OutputFile(config.FormatNDJSON, cfg.Compression, config.TableRegions)
+	if name != "mapRegions.ndjson.gz" {
+		t.Errorf("unexpected file name %s", name)
+	}
+	f, err := os.Open(filepath.Join(tmpDir, name))
+	if err != nil {
+		t.Fatalf("%s was not created: %v", name, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("not a gzip file: %v", err)
+	}
+	regions := readNDJSON(t, gz)
+	if len(regions) != 2 || regions[0]["regionName"] != "The Forge" {
+		t.Errorf("unexpected regions: %v", regions)
+	}
+}
@@ -0,0 +1,194 @@
+package writer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/xitongsys/parquet-go-source/writerfile"
+	"github.com/xitongsys/parquet-go/parquet"
+	pqwriter "github.com/xitongsys/parquet-go/writer"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// ParquetWriter handles writing converted data to Apache Parquet files.
+// Each table becomes one file whose typed schema is derived from the models
+// struct, with nullable pointer fields as optional columns holding real nulls.
+// Translated names are not written.
+type ParquetWriter struct {
+	config    *config.Config
+	outputDir string
+}
+
+// NewParquetWriter creates a new ParquetWriter with the given configuration.
+func NewParquetWriter(cfg *config.Config) *ParquetWriter {
+	return &ParquetWriter{
+		config:    cfg,
+		outputDir: cfg.OutputDir,
+	}
+}
+
+// parquetFile returns the Parquet file name for a JSON file name.
+func parquetFile(jsonFile string) string {
+	return strings.TrimSuffix(jsonFile, ".json") + ".parquet"
+}
+
+// WriteAll writes all converted data to Parquet files.
+func (w *ParquetWriter) WriteAll(data *models.ConvertedData) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(w.outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if w.config.Verbose {
+		w.config.Logf("Writing Parquet files to: %s\n", w.outputDir)
+	}
+
+	// Write all enabled data files
+	for _, table := range tableRecords(data) {
+		if !w.config.TableEnabled(table.name) {
+			continue
+		}
+		if err := w.writeParquet(OutputFile(config.FormatParquet, w.config.Compression, table.name), table.records); err != nil {
+			return fmt.Errorf("failed to write %s: %w", table.label, err)
+		}
+	}
+
+	return writeJSONSideFiles(w.config, data)
+}
+
+// CopyPassthroughFiles copies community-maintained JSON files from the source directory.
+// For Parquet output, we still want to copy these JSON files as they're used by Wanderer.
+func (w *ParquetWriter) CopyPassthroughFiles(sourceDir string) error {
+	return New(w.config).CopyPassthroughFiles(sourceDir)
+}
+
+// parquetCodecs maps the configured compression to the Parquet page codec.
+// Uncompressed output still uses Snappy, the customary Parquet default.
+var parquetCodecs = map[config.Compression]parquet.CompressionCodec{
+	config.CompressionGzip: parquet.CompressionCodec_GZIP,
+	config.CompressionZstd: parquet.CompressionCodec_ZSTD,
+}
+
+// writeParquet writes the elements of the records slice to a Parquet file.
+func (w *ParquetWriter) writeParquet(filename string, records interface{}) error {
+	rows := reflect.ValueOf(records)
+	if rows.Kind() != reflect.Slice {
+		return fmt.Errorf("cannot write %T as Parquet: not a slice", records)
+	}
+
+	schema, err := parquetSchema(rows.Type().Elem(), omittedColumns(w.config, rows.Type().Elem()))
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(w.outputDir, filename)
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	pw, err := pqwriter.NewParquetWriter(writerfile.NewWriterFile(file), schema, int64(max(w.config.Workers, 1)))
+	if err != nil {
+		return fmt.Errorf("failed to start Parquet writer for %s: %w", path, err)
+	}
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+	if codec, ok := parquetCodecs[w.config.Compression]; ok {
+		pw.CompressionType = codec
+	}
+
+	for i := range rows.Len() {
+		if err := pw.Write(rows.Index(i).Addr().Interface()); err != nil {
+			return fmt.Errorf("failed to write row %d to %s: %w", i, path, err)
+		}
+	}
+	if err := pw.WriteStop(); err != nil {
+		return fmt.Errorf("failed to finish %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", path, err)
+	}
+
+	if w.config.Verbose {
+		w.config.Logf("  Wrote %s (%d rows)\n", filename, rows.Len())
+	}
+
+	return nil
+}
+
+// parquetSchemaItem is one node of a parquet-go JSON schema.
+type parquetSchemaItem struct {
+	Tag    string               `json:"Tag"`
+	Fields []*parquetSchemaItem `json:"Fields,omitempty"`
+}
+
+// parquetSchema derives a parquet-go JSON schema from the JSON-encoded fields
+// of a models struct type, leaving out the columns named in skip. Columns are
+// named as in JSON output and map back to the Go fields for marshalling.
+func parquetSchema(t reflect.Type, skip []string) (string, error) {
+	root := &parquetSchemaItem{Tag: "name=parquet_go_root, repetitiontype=REQUIRED"}
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" || slices.Contains(skip, name) {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		column, err := parquetColumn(name, field.Name, field.Type)
+		if err != nil {
+			return "", fmt.Errorf("unsupported Parquet column %s.%s: %w", t.Name(), field.Name, err)
+		}
+		root.Fields = append(root.Fields, column)
+	}
+
+	schema, err := json.Marshal(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode Parquet schema: %w", err)
+	}
+	return string(schema), nil
+}
+
+// parquetColumn returns the schema node for a Go field type. Pointers become
+// optional columns, so nil values are written as nulls, and []int64 a list.
+func parquetColumn(name, inName string, t reflect.Type) (*parquetSchemaItem, error) {
+	repetition := "REQUIRED"
+	if t.Kind() == reflect.Pointer {
+		repetition = "OPTIONAL"
+		t = t.Elem()
+	}
+
+	var columnType string
+	switch t.Kind() {
+	case reflect.Int64, reflect.Int:
+		columnType = "type=INT64"
+	case reflect.Float64:
+		columnType = "type=DOUBLE"
+	case reflect.Bool:
+		columnType = "type=BOOLEAN"
+	case reflect.String:
+		columnType = "type=BYTE_ARRAY, convertedtype=UTF8"
+	case reflect.Slice:
+		if repetition != "REQUIRED" || t.Elem().Kind() != reflect.Int64 {
+			return nil, fmt.Errorf("type %s", t)
+		}
+		return &parquetSchemaItem{
+			Tag:    fmt.Sprintf("name=%s, inname=%s, type=LIST, repetitiontype=REQUIRED", name, inName),
+			Fields: []*parquetSchemaItem{{Tag: "name=element, type=INT64, repetitiontype=REQUIRED"}},
+		}, nil
+	default:
+		return nil, fmt.Errorf("type %s", t)
+	}
+	return &parquetSchemaItem{
+		Tag: fmt.Sprintf("name=%s, inname=%s, %s, repetitiontype=%s", name, inName, columnType, repetition),
+	}, nil
+}
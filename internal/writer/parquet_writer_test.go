@@ -0,0 +1,139 @@
+package writer
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// parquetTypeRow reads back a subset of the invTypes columns.
+type parquetTypeRow struct {
+	TypeID        int64   `parquet:"name=typeID, type=INT64"`
+	TypeName      string  `parquet:"name=typeName, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Mass          float64 `parquet:"name=mass, type=DOUBLE"`
+	Published     bool    `parquet:"name=published, type=BOOLEAN"`
+	MarketGroupID *int64  `parquet:"name=marketGroupID, type=INT64, repetitiontype=OPTIONAL"`
+}
+
+// readParquetTypes reads all rows of an invTypes Parquet file.
+func readParquetTypes(t *testing.T, path string) ([]parquetTypeRow, *parquet.FileMetaData) {
+	t.Helper()
+	pf, err := local.NewLocalFileReader(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer func() { _ = pf.Close() }()
+
+	pr, err := reader.NewParquetReader(pf, new(parquetTypeRow), 1)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	defer pr.ReadStop()
+
+	rows := make([]parquetTypeRow, pr.GetNumRows())
+	if err := pr.Read(&rows); err != nil {
+		t.Fatalf("failed to read rows: %v", err)
+	}
+	return rows, pr.Footer
+}
+
+func TestParquetWriter_WriteAll(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:   tmpDir,
+		Compression: config.CompressionZstd,
+		Tables:      []string{config.TableTypes, config.TableRegionJumps},
+	}
+
+	marketGroupID := int64(61)
+	data := &models.ConvertedData{
+		Universe: &models.UniverseData{},
+		InvTypes: []models.InvType{
+			{TypeID: 587, TypeName: "Rifter", Mass: 1350000, Published: true, MarketGroupID: &marketGroupID},
+			{TypeID: 670, TypeName: "Capsule", Mass: 32000},
+		},
+		RegionJumps: []models.RegionJump{
+			{FromRegionID: 10000002, ToRegionID: 10000033, FromSolarSystemIDs: []int64{30000142}, ToSolarSystemIDs: []int64{30002813}},
+		},
+	}
+
+	w, err := NewWriter(&config.Config{OutputDir: tmpDir, OutputFormat: config.FormatParquet})
+	if err != nil {
+		t.Fatalf("NewWriter failed: %v", err)
+	}
+	if _, ok := w.(*ParquetWriter); !ok {
+		t.Fatalf("expected *ParquetWriter, got %T", w)
+	}
+
+	if err := NewParquetWriter(cfg).WriteAll(data); err != nil {
+		t.Fatalf("WriteAll failed: %v", err)
+	}
+
+	// Parquet compresses pages internally, so the name has no compression extension
+	name := OutputFile(config.FormatParquet, cfg.Compression, config.TableTypes)
+	if name != "invTypes.parquet" {
+		t.Errorf("unexpected file name %s", name)
+	}
+
+	rows, footer := readParquetTypes(t, filepath.Join(tmpDir, name))
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[0].TypeName != "Rifter" || rows[0].Mass != 1350000 || !rows[0].Published ||
+		rows[0].MarketGroupID == nil || *rows[0].MarketGroupID != 61 {
+		t.Errorf("unexpected first row: %+v", rows[0])
+	}
+	// A nil pointer is a real null, not "None"
+	if rows[1].MarketGroupID != nil {
+		t.Errorf("expected null marketGroupID, got %d", *rows[1].MarketGroupID)
+	}
+
+	codec := footer.RowGroups[0].Columns[0].MetaData.Codec
+	if codec != parquet.CompressionCodec_ZSTD {
+		t.Errorf("expected zstd pages, got %s", codec)
+	}
+	// Translations are not columns
+	for _, element := range footer.Schema {
+		if strings.Contains(element.Name, "Names") {
+			t.Errorf("unexpected column %s", element.Name)
+		}
+	}
+
+	if _, err := local.NewLocalFileReader(filepath.Join(tmpDir, "mapRegionJumps.parquet")); err != nil {
+		t.Errorf("region jumps were not written: %v", err)
+	}
+}
+
+func TestParquetSchema(t *testing.T) {
+	schema, err := parquetSchema(reflect.TypeOf(models.SolarSystem{}), models.SecurityColumns)
+	if err != nil {
+		t.Fatalf("parquetSchema failed: %v", err)
+	}
+	for _, want := range []string{
+		`name=solarSystemID, inname=SolarSystemID, type=INT64, repetitiontype=REQUIRED`,
+		`name=factionID, inname=FactionID, type=INT64, repetitiontype=OPTIONAL`,
+		`name=solarSystemName, inname=SolarSystemName, type=BYTE_ARRAY, convertedtype=UTF8`,
+		`name=projectedX, inname=ProjectedX, type=DOUBLE, repetitiontype=OPTIONAL`,
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("schema missing %q:\n%s", want, schema)
+		}
+	}
+	for _, unwanted := range []string{"displaySecurity", "Names", "GateFlags"} {
+		if strings.Contains(schema, unwanted) {
+			t.Errorf("schema should not contain %s:\n%s", unwanted, schema)
+		}
+	}
+
+	if _, err := parquetSchema(reflect.TypeOf(struct{ M map[string]int }{}), nil); err == nil {
+		t.Error("expected an error for a map column")
+	}
+}
@@ -0,0 +1,138 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// RegionsDir is the directory, below the output directory, holding one
+// directory per region when splitting by region.
+const RegionsDir = "regions"
+
+// FileRegionIndex lists the region directories. It is JSON in every format.
+const FileRegionIndex = "index.json"
+
+// regionTableWriter writes the tables that are split by region.
+type regionTableWriter interface {
+	WriteSolarSystems(systems []models.SolarSystem) error
+	WriteConstellations(constellations []models.Constellation) error
+	WriteSystemJumps(jumps []models.SystemJump) error
+	WriteWormholeClasses(classes []models.WormholeClassLocation) error
+}
+
+// regionIndexEntry describes one region directory in FileRegionIndex.
+type regionIndexEntry struct {
+	RegionID   int64  `json:"regionID"`
+	RegionName string `json:"regionName"`
+	// Files lists the region's files relative to RegionsDir.
+	Files           []string `json:"files"`
+	SolarSystems    int      `json:"solarSystems"`
+	Constellations  int      `json:"constellations"`
+	Jumps           int      `json:"jumps"`
+	WormholeClasses int      `json:"wormholeClasses"`
+}
+
+// regionFile returns the name of a table file in a region directory: the
+// table name with the extension of its full file, e.g. systems.csv.
+func regionFile(tableFiles map[string]string, filename string) string {
+	for table, name := range tableFiles {
+		if name == filename {
+			return table + filepath.Ext(filename)
+		}
+	}
+	return filename
+}
+
+// writeRegionSplit writes the systems, constellations, jumps and wormhole
+// classes of each region to RegionsDir/<regionID>/ with the writers returned
+// by newWriter, followed by FileRegionIndex. Jumps are those leaving the
+// region's systems, so gates to other regions are included; wormhole classes
+// are those set on the region, its constellations or its systems. ext is the
+// extension of the table files before compression.
+func writeRegionSplit(cfg *config.Config, data *models.ConvertedData, ext string, newWriter func(cfg *config.Config) regionTableWriter) error {
+	index := models.NewDataset(data)
+	universe := data.Universe
+
+	regionOf := make(map[int64]int64, len(universe.Regions)+len(universe.Constellations)+len(universe.SolarSystems))
+	for _, r := range universe.Regions {
+		regionOf[r.RegionID] = r.RegionID
+	}
+	for _, c := range universe.Constellations {
+		regionOf[c.ConstellationID] = c.RegionID
+	}
+	for _, s := range universe.SolarSystems {
+		regionOf[s.SolarSystemID] = s.RegionID
+	}
+	classesByRegion := make(map[int64][]models.WormholeClassLocation)
+	for _, wc := range data.WormholeClasses {
+		if regionID, ok := regionOf[wc.LocationID]; ok {
+			classesByRegion[regionID] = append(classesByRegion[regionID], wc)
+		}
+	}
+
+	entries := make([]regionIndexEntry, 0, len(universe.Regions))
+	for _, region := range universe.Regions {
+		dir := strconv.FormatInt(region.RegionID, 10)
+
+		// Region files are written quietly and summarised once below
+		regionCfg := *cfg
+		regionCfg.OutputDir = filepath.Join(cfg.OutputDir, RegionsDir, dir)
+		regionCfg.Verbose = false
+		if err := os.MkdirAll(regionCfg.OutputDir, 0755); err != nil {
+			return fmt.Errorf("failed to create region directory: %w", err)
+		}
+		w := newWriter(&regionCfg)
+
+		systems := slices.Collect(index.SystemsInRegion(region.RegionID))
+		constellations := slices.Collect(index.ConstellationsInRegion(region.RegionID))
+		jumps := slices.Collect(index.JumpsFromRegion(region.RegionID))
+		classes := classesByRegion[region.RegionID]
+
+		entry := regionIndexEntry{
+			RegionID:        region.RegionID,
+			RegionName:      region.RegionName,
+			Files:           []string{},
+			SolarSystems:    len(systems),
+			Constellations:  len(constellations),
+			Jumps:           len(jumps),
+			WormholeClasses: len(classes),
+		}
+		tables := []struct {
+			name  string
+			write func() error
+		}{
+			{config.TableSystems, func() error { return w.WriteSolarSystems(systems) }},
+			{config.TableConstellations, func() error { return w.WriteConstellations(constellations) }},
+			{config.TableJumps, func() error { return w.WriteSystemJumps(jumps) }},
+			{config.TableWormholeClasses, func() error { return w.WriteWormholeClasses(classes) }},
+		}
+		for _, table := range tables {
+			if !cfg.TableEnabled(table.name) {
+				continue
+			}
+			if err := table.write(); err != nil {
+				return fmt.Errorf("failed to write %s of region %d: %w", table.name, region.RegionID, err)
+			}
+			entry.Files = append(entry.Files, dir+"/"+table.name+ext+cfg.Compression.Extension())
+		}
+		entries = append(entries, entry)
+	}
+
+	indexCfg := *cfg
+	indexCfg.OutputDir = filepath.Join(cfg.OutputDir, RegionsDir)
+	indexCfg.Verbose = false
+	if err := New(&indexCfg).writeJSON(FileRegionIndex, entries); err != nil {
+		return fmt.Errorf("failed to write region index: %w", err)
+	}
+
+	if cfg.Verbose {
+		cfg.Logf("  Wrote %s/ (%d regions)\n", RegionsDir, len(entries))
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package writer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// splitTestData has two regions joined by a gate and wormhole classes set on
+// a region, a constellation and a system.
+func splitTestData() *models.ConvertedData {
+	return &models.ConvertedData{
+		Universe: &models.UniverseData{
+			Regions: []models.Region{
+				{RegionID: 10000002, RegionName: "The Forge"},
+				{RegionID: 10000033, RegionName: "The Citadel"},
+			},
+			Constellations: []models.Constellation{
+				{RegionID: 10000002, ConstellationID: 20000020, ConstellationName: "Kimotoro"},
+				{RegionID: 10000033, ConstellationID: 20000410, ConstellationName: "Tasalen"},
+			},
+			SolarSystems: []models.SolarSystem{
+				{RegionID: 10000002, ConstellationID: 20000020, SolarSystemID: 30000142, SolarSystemName: "Jita"},
+				{RegionID: 10000002, ConstellationID: 20000020, SolarSystemID: 30000144, SolarSystemName: "Perimeter"},
+				{RegionID: 10000033, ConstellationID: 20000410, SolarSystemID: 30002813, SolarSystemName: "Tasabeshi"},
+			},
+		},
+		SystemJumps: []models.SystemJump{
+			{FromRegionID: 10000002, FromSolarSystemID: 30000142, ToSolarSystemID: 30000144, ToRegionID: 10000002},
+			{FromRegionID: 10000002, FromSolarSystemID: 30000144, ToSolarSystemID: 30000142, ToRegionID: 10000002},
+			{FromRegionID: 10000002, FromSolarSystemID: 30000144, ToSolarSystemID: 30002813, ToRegionID: 10000033},
+			{FromRegionID: 10000033, FromSolarSystemID: 30002813, ToSolarSystemID: 30000144, ToRegionID: 10000002},
+		},
+		WormholeClasses: []models.WormholeClassLocation{
+			{LocationID: 10000002, WormholeClassID: 7},
+			{LocationID: 20000410, WormholeClassID: 8},
+			{LocationID: 30000142, WormholeClassID: 7},
+		},
+	}
+}
+
+func TestJSONWriter_SplitByRegion(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{OutputDir: tmpDir, SplitBy: config.SplitByRegion}
+
+	if err := New(cfg).WriteAll(splitTestData()); err != nil {
+		t.Fatalf("WriteAll failed: %v", err)
+	}
+
+	// The full tables are still written
+	if _, err := os.Stat(filepath.Join(tmpDir, FileSolarSystems)); err != nil {
+		t.Errorf("full systems table missing: %v", err)
+	}
+
+	forge := filepath.Join(tmpDir, RegionsDir, "10000002")
+	var systems []models.SolarSystem
+	readJSON(t, filepath.Join(forge, "systems.json"), &systems)
+	if len(systems) != 2 || systems[0].SolarSystemName != "Jita" {
+		t.Errorf("unexpected systems: %+v", systems)
+	}
+
+	var constellations []models.Constellation
+	readJSON(t, filepath.Join(forge, "constellations.json"), &constellations)
+	if len(constellations) != 1 || constellations[0].ConstellationID != 20000020 {
+		t.Errorf("unexpected constellations: %+v", constellations)
+	}
+
+	// Outbound gates to other regions are included, inbound ones are not
+	var jumps []models.SystemJump
+	readJSON(t, filepath.Join(forge, "jumps.json"), &jumps)
+	if len(jumps) != 3 || jumps[2].ToRegionID != 10000033 {
+		t.Errorf("unexpected jumps: %+v", jumps)
+	}
+
+	var classes []models.WormholeClassLocation
+	readJSON(t, filepath.Join(forge, "wormholeClasses.json"), &classes)
+	if len(classes) != 2 || classes[0].LocationID != 10000002 || classes[1].LocationID != 30000142 {
+		t.Errorf("unexpected wormhole classes: %+v", classes)
+	}
+	readJSON(t, filepath.Join(tmpDir, RegionsDir, "10000033", "wormholeClasses.json"), &classes)
+	if len(classes) != 1 || classes[0].LocationID != 20000410 {
+		t.Errorf("unexpected Citadel wormhole classes: %+v", classes)
+	}
+
+	var index []regionIndexEntry
+	readJSON(t, filepath.Join(tmpDir, RegionsDir, FileRegionIndex), &index)
+	if len(index) != 2 {
+		t.Fatalf("expected 2 index entries, got %d", len(index))
+	}
+	want := regionIndexEntry{
+		RegionID:   10000002,
+		RegionName: "The Forge",
+		Files: []string{
+			"10000002/systems.json", "10000002/constellations.json",
+			"10000002/jumps.json", "10000002/wormholeClasses.json",
+		},
+		SolarSystems: 2, Constellations: 1, Jumps: 3, WormholeClasses: 2,
+	}
+	if !slices.Equal(index[0].Files, want.Files) || index[0].RegionName != want.RegionName ||
+		index[0].Jumps != want.Jumps || index[0].WormholeClasses != want.WormholeClasses {
+		t.Errorf("unexpected index entry %+v, want %+v", index[0], want)
+	}
+}
+
+func TestCSVWriter_SplitByRegion(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := &config.Config{
+		OutputDir:   tmpDir,
+		SplitBy:     config.SplitByRegion,
+		Compression: config.CompressionGzip,
+		Tables:      []string{config.TableSystems, config.TableJumps},
+	}
+
+	if err := NewCSVWriter(cfg).WriteAll(splitTestData()); err != nil {
+		t.Fatalf("WriteAll failed: %v", err)
+	}
+
+	citadel := filepath.Join(tmpDir, RegionsDir, "10000033")
+	content := string(readCompressed(t, filepath.Join(citadel, "jumps.csv.gz"), cfg.Compression))
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "fromRegionID,") || !strings.Contains(lines[1], "30002813,30000144") {
+		t.Errorf("unexpected jumps.csv:\n%s", content)
+	}
+
+	// Disabled tables are not split
+	if _, err := os.Stat(filepath.Join(citadel, "constellations.csv.gz")); !os.IsNotExist(err) {
+		t.Errorf("expected no constellations file, got %v", err)
+	}
+
+	var index []regionIndexEntry
+	if err := json.Unmarshal(readCompressed(t, filepath.Join(tmpDir, RegionsDir, FileRegionIndex+".gz"), cfg.Compression), &index); err != nil {
+		t.Fatalf("failed to decode index: %v", err)
+	}
+	if len(index) != 2 || !slices.Equal(index[1].Files, []string{"10000033/systems.csv.gz", "10000033/jumps.csv.gz"}) {
+		t.Errorf("unexpected index: %+v", index)
+	}
+}
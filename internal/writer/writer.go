@@ -3,6 +3,7 @@ package writer
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/guarzo/wanderer-sde/internal/config"
 	"github.com/guarzo/wanderer-sde/internal/models"
@@ -24,35 +25,174 @@ func NewWriter(cfg *config.Config) (Writer, error) {
 		return NewCSVWriter(cfg), nil
 	case config.FormatJSON:
 		return New(cfg), nil
+	case config.FormatNDJSON:
+		return NewNDJSONWriter(cfg), nil
+	case config.FormatParquet:
+		return NewParquetWriter(cfg), nil
+	case config.FormatETF:
+		return NewETFWriter(cfg), nil
 	default:
 		return nil, fmt.Errorf("unsupported output format: %s", cfg.OutputFormat)
 	}
 }
 
-// GetOutputFiles returns the list of output file names based on format.
-func GetOutputFiles(format config.OutputFormat) []string {
+// tableData holds the records of one output table.
+type tableData struct {
+	name    string
+	label   string
+	records interface{}
+}
+
+// tableRecords lists every table with its record slice, in output order, for
+// writers that encode all tables the same way.
+func tableRecords(data *models.ConvertedData) []tableData {
+	return []tableData{
+		{config.TableSystems, "solar systems", data.Universe.SolarSystems},
+		{config.TableRegions, "regions", data.Universe.Regions},
+		{config.TableConstellations, "constellations", data.Universe.Constellations},
+		{config.TableWormholeClasses, "wormhole classes", data.WormholeClasses},
+		{config.TableSystemWormholeClasses, "system wormhole classes", data.SystemWormholeClasses},
+		{config.TableTypes, "types", data.InvTypes},
+		{config.TableGroups, "groups", data.InvGroups},
+		{config.TableJumps, "system jumps", data.SystemJumps},
+		{config.TableRegionJumps, "region jumps", data.RegionJumps},
+		{config.TableConstellationJumps, "constellation jumps", data.ConstellationJumps},
+		{config.TableStargates, "stargates", data.Stargates},
+		{config.TableSystemTopology, "system topology", data.SystemTopology},
+		{config.TableStations, "stations", data.Stations},
+		{config.TableStationServices, "station services", data.StationServices},
+		{config.TableOperationServices, "operation services", data.OperationServices},
+		{config.TableFactions, "factions", data.Factions},
+		{config.TableRaces, "races", data.Races},
+		{config.TableCorporations, "corporations", data.Corporations},
+		{config.TableMarketGroups, "market groups", data.MarketGroups},
+		{config.TableDogmaAttributes, "dogma attributes", data.DogmaAttributes},
+		{config.TableDogmaEffects, "dogma effects", data.DogmaEffects},
+		{config.TableTypeAttributes, "type attributes", data.TypeAttributes},
+		{config.TableTypeEffects, "type effects", data.TypeEffects},
+	}
+}
+
+// omittedColumns returns the optional solar system columns that are not enabled,
+// for writers that derive their columns from the models struct, so the output
+// matches the CSV columns.
+func omittedColumns(cfg *config.Config, t reflect.Type) []string {
+	if t != reflect.TypeOf(models.SolarSystem{}) {
+		return nil
+	}
+	var omit []string
+	if !cfg.SecurityColumns {
+		omit = append(omit, models.SecurityColumns...)
+	}
+	if !cfg.ProjectionColumns {
+		omit = append(omit, models.ProjectionColumns...)
+	}
+	return omit
+}
+
+// writeJSONSideFiles writes the topology report and market group tree as JSON,
+// for formats that only hold flat tables.
+func writeJSONSideFiles(cfg *config.Config, data *models.ConvertedData) error {
+	if data.TopologyReport != nil && cfg.TableEnabled(config.TableSystemTopology) {
+		if err := New(cfg).writeJSON(FileTopologyReport, data.TopologyReport); err != nil {
+			return fmt.Errorf("failed to write topology report: %w", err)
+		}
+	}
+	if data.MarketGroupTree != nil && cfg.TableEnabled(config.TableMarketGroups) {
+		if err := New(cfg).writeJSON(FileMarketGroupTree, data.MarketGroupTree); err != nil {
+			return fmt.Errorf("failed to write market group tree: %w", err)
+		}
+	}
+	return nil
+}
+
+// csvTableFiles maps each output table to its CSV file name.
+var csvTableFiles = map[string]string{
+	config.TableSystems:               CSVFileSolarSystems,
+	config.TableRegions:               CSVFileRegions,
+	config.TableConstellations:        CSVFileConstellations,
+	config.TableWormholeClasses:       CSVFileWormholeClasses,
+	config.TableSystemWormholeClasses: CSVFileSystemWormholeClasses,
+	config.TableTypes:                 CSVFileTypes,
+	config.TableGroups:                CSVFileGroups,
+	config.TableJumps:                 CSVFileSystemJumps,
+	config.TableRegionJumps:           CSVFileRegionJumps,
+	config.TableConstellationJumps:    CSVFileConstellationJumps,
+	config.TableStargates:             CSVFileStargates,
+	config.TableSystemTopology:        CSVFileSystemTopology,
+	config.TableStations:              CSVFileStations,
+	config.TableStationServices:       CSVFileStationServices,
+	config.TableOperationServices:     CSVFileOperationServices,
+	config.TableFactions:              CSVFileFactions,
+	config.TableRaces:                 CSVFileRaces,
+	config.TableCorporations:          CSVFileCorporations,
+	config.TableMarketGroups:          CSVFileMarketGroups,
+	config.TableDogmaAttributes:       CSVFileDogmaAttributes,
+	config.TableDogmaEffects:          CSVFileDogmaEffects,
+	config.TableTypeAttributes:        CSVFileTypeAttributes,
+	config.TableTypeEffects:           CSVFileTypeEffects,
+}
+
+// jsonTableFiles maps each output table to its JSON file name.
+var jsonTableFiles = map[string]string{
+	config.TableSystems:               FileSolarSystems,
+	config.TableRegions:               FileRegions,
+	config.TableConstellations:        FileConstellations,
+	config.TableWormholeClasses:       FileWormholeClasses,
+	config.TableSystemWormholeClasses: FileSystemWormholeClasses,
+	config.TableTypes:                 FileShipTypes,
+	config.TableGroups:                FileItemGroups,
+	config.TableJumps:                 FileSystemJumps,
+	config.TableRegionJumps:           FileRegionJumps,
+	config.TableConstellationJumps:    FileConstellationJumps,
+	config.TableStargates:             FileStargates,
+	config.TableSystemTopology:        FileSystemTopology,
+	config.TableStations:              FileStations,
+	config.TableStationServices:       FileStationServices,
+	config.TableOperationServices:     FileOperationServices,
+	config.TableFactions:              FileFactions,
+	config.TableRaces:                 FileRaces,
+	config.TableCorporations:          FileCorporations,
+	config.TableMarketGroups:          FileMarketGroups,
+	config.TableDogmaAttributes:       FileDogmaAttributes,
+	config.TableDogmaEffects:          FileDogmaEffects,
+	config.TableTypeAttributes:        FileTypeAttributes,
+	config.TableTypeEffects:           FileTypeEffects,
+}
+
+// OutputFile returns the file name written for table in the given format,
+// with the extension of the given compression. Parquet files are compressed
+// internally and keep their .parquet extension.
+func OutputFile(format config.OutputFormat, compression config.Compression, table string) string {
+	var name string
 	switch format {
 	case config.FormatCSV:
-		return []string{
-			CSVFileSolarSystems,
-			CSVFileRegions,
-			CSVFileConstellations,
-			CSVFileWormholeClasses,
-			CSVFileTypes,
-			CSVFileGroups,
-			CSVFileSystemJumps,
-		}
+		name = csvTableFiles[table]
 	case config.FormatJSON:
-		return []string{
-			FileSolarSystems,
-			FileRegions,
-			FileConstellations,
-			FileWormholeClasses,
-			FileShipTypes,
-			FileItemGroups,
-			FileSystemJumps,
-		}
+		name = jsonTableFiles[table]
+	case config.FormatNDJSON:
+		name = ndjsonFile(jsonTableFiles[table])
+	case config.FormatParquet:
+		return parquetFile(jsonTableFiles[table])
+	case config.FormatETF:
+		name = etfFile(jsonTableFiles[table])
+	default:
+		return ""
+	}
+	return name + compression.Extension()
+}
+
+// GetOutputFiles returns the list of output file names based on format and
+// compression, in the order of config.AllTables.
+func GetOutputFiles(format config.OutputFormat, compression config.Compression) []string {
+	switch format {
+	case config.FormatCSV, config.FormatJSON, config.FormatNDJSON, config.FormatParquet, config.FormatETF:
 	default:
 		return nil
 	}
+	files := make([]string, len(config.AllTables))
+	for i, table := range config.AllTables {
+		files[i] = OutputFile(format, compression, table)
+	}
+	return files
 }
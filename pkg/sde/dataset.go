@@ -0,0 +1,286 @@
+package sde
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// Dataset is a loaded and converted SDE. It is read-only and safe for
+// concurrent use.
+type Dataset struct {
+	// SDEPath is the SDE directory or archive the dataset was loaded from.
+	SDEPath string
+
+	// Version describes the downloaded SDE build, or is nil when the SDE was
+	// read from disk or its version could not be checked.
+	Version *VersionInfo
+
+	// Validation holds the row counts and findings of validating the dataset.
+	Validation *ValidationResult
+
+	index *models.Dataset
+}
+
+// newDataset wraps converted data, indexing each table.
+func newDataset(sdePath string, version *VersionInfo, data *ConvertedData, validation *ValidationResult) *Dataset {
+	return &Dataset{
+		SDEPath:    sdePath,
+		Version:    version,
+		Validation: validation,
+		index:      models.NewDataset(data),
+	}
+}
+
+// Data returns every loaded table. Callers must not modify it.
+func (d *Dataset) Data() *ConvertedData {
+	return d.index.Data()
+}
+
+// universe returns the map tables, which are empty when none were loaded.
+func (d *Dataset) universe() ([]SolarSystem, []Region, []Constellation) {
+	u := d.index.Data().Universe
+	if u == nil {
+		return nil, nil, nil
+	}
+	return u.SolarSystems, u.Regions, u.Constellations
+}
+
+// SolarSystem returns the solar system with the given ID.
+func (d *Dataset) SolarSystem(id int64) (SolarSystem, bool) {
+	return d.index.SolarSystem(id)
+}
+
+// SolarSystemByName returns the solar system with the given English name, ignoring case.
+func (d *Dataset) SolarSystemByName(name string) (SolarSystem, bool) {
+	return d.index.SolarSystemByName(name)
+}
+
+// SystemsInRegion iterates over the solar systems of a region in ID order.
+func (d *Dataset) SystemsInRegion(regionID int64) iter.Seq[SolarSystem] {
+	return d.index.SystemsInRegion(regionID)
+}
+
+// SystemsInConstellation iterates over the solar systems of a constellation in ID order.
+func (d *Dataset) SystemsInConstellation(constellationID int64) iter.Seq[SolarSystem] {
+	return d.index.SystemsInConstellation(constellationID)
+}
+
+// Neighbours returns the sorted IDs of the systems a system has stargates to.
+// It is empty unless the jumps table was loaded.
+func (d *Dataset) Neighbours(systemID int64) []int64 {
+	return d.index.Neighbours(systemID)
+}
+
+// WormholeClass returns the wormhole class of a solar system, inheriting the
+// constellation's and then the region's class when the system has none of its own.
+// It needs the wormholeClasses table.
+func (d *Dataset) WormholeClass(systemID int64) (int64, bool) {
+	return d.index.WormholeClass(systemID)
+}
+
+// Region returns the region with the given ID.
+func (d *Dataset) Region(id int64) (Region, bool) {
+	return d.index.Region(id)
+}
+
+// RegionByName returns the region with the given English name, ignoring case.
+func (d *Dataset) RegionByName(name string) (Region, bool) {
+	return d.index.RegionByName(name)
+}
+
+// Constellation returns the constellation with the given ID.
+func (d *Dataset) Constellation(id int64) (Constellation, bool) {
+	return d.index.Constellation(id)
+}
+
+// ConstellationByName returns the constellation with the given English name, ignoring case.
+func (d *Dataset) ConstellationByName(name string) (Constellation, bool) {
+	return d.index.ConstellationByName(name)
+}
+
+// Type returns the item type with the given ID.
+func (d *Dataset) Type(id int64) (InvType, bool) {
+	return d.index.Type(id)
+}
+
+// TypeByName returns the item type with the given English name, ignoring case.
+func (d *Dataset) TypeByName(name string) (InvType, bool) {
+	return d.index.TypeByName(name)
+}
+
+// TypesInGroup iterates over the item types of a group in ID order.
+func (d *Dataset) TypesInGroup(groupID int64) iter.Seq[InvType] {
+	return d.index.TypesInGroup(groupID)
+}
+
+// TypesInCategory iterates over the item types of a category in ID order.
+// It needs the groups table to map groups to categories.
+func (d *Dataset) TypesInCategory(categoryID int64) iter.Seq[InvType] {
+	return d.index.TypesInCategory(categoryID)
+}
+
+// Group returns the item group with the given ID.
+func (d *Dataset) Group(id int64) (InvGroup, bool) {
+	return d.index.Group(id)
+}
+
+// GroupByName returns the item group with the given English name, ignoring case.
+func (d *Dataset) GroupByName(name string) (InvGroup, bool) {
+	return d.index.GroupByName(name)
+}
+
+// Station returns the NPC station with the given ID.
+func (d *Dataset) Station(id int64) (StaStation, bool) {
+	return d.index.Station(id)
+}
+
+// StationByName returns the NPC station with the given English name, ignoring case.
+func (d *Dataset) StationByName(name string) (StaStation, bool) {
+	return d.index.StationByName(name)
+}
+
+// Faction returns the faction with the given ID.
+func (d *Dataset) Faction(id int64) (ChrFaction, bool) {
+	return d.index.Faction(id)
+}
+
+// FactionByName returns the faction with the given English name, ignoring case.
+func (d *Dataset) FactionByName(name string) (ChrFaction, bool) {
+	return d.index.FactionByName(name)
+}
+
+// Corporation returns the NPC corporation with the given ID.
+func (d *Dataset) Corporation(id int64) (CrpNPCCorporation, bool) {
+	return d.index.Corporation(id)
+}
+
+// CorporationByName returns the NPC corporation with the given English name, ignoring case.
+func (d *Dataset) CorporationByName(name string) (CrpNPCCorporation, bool) {
+	return d.index.CorporationByName(name)
+}
+
+// MarketGroup returns the market group with the given ID.
+func (d *Dataset) MarketGroup(id int64) (InvMarketGroup, bool) {
+	return d.index.MarketGroup(id)
+}
+
+// SolarSystems iterates over solar systems in ID order.
+func (d *Dataset) SolarSystems() iter.Seq[SolarSystem] {
+	systems, _, _ := d.universe()
+	return slices.Values(systems)
+}
+
+// Regions iterates over regions in ID order.
+func (d *Dataset) Regions() iter.Seq[Region] {
+	_, regions, _ := d.universe()
+	return slices.Values(regions)
+}
+
+// Constellations iterates over constellations in ID order.
+func (d *Dataset) Constellations() iter.Seq[Constellation] {
+	_, _, constellations := d.universe()
+	return slices.Values(constellations)
+}
+
+// Types iterates over item types in ID order.
+func (d *Dataset) Types() iter.Seq[InvType] {
+	return slices.Values(d.index.Data().InvTypes)
+}
+
+// Groups iterates over item groups in ID order.
+func (d *Dataset) Groups() iter.Seq[InvGroup] {
+	return slices.Values(d.index.Data().InvGroups)
+}
+
+// Jumps iterates over stargate jumps, each direction separately.
+func (d *Dataset) Jumps() iter.Seq[SystemJump] {
+	return slices.Values(d.index.Data().SystemJumps)
+}
+
+// RegionJumps iterates over region-to-region gate connections, each direction
+// separately, by source then destination region.
+func (d *Dataset) RegionJumps() iter.Seq[RegionJump] {
+	return slices.Values(d.index.Data().RegionJumps)
+}
+
+// ConstellationJumps iterates over constellation-to-constellation gate
+// connections, each direction separately, by source then destination constellation.
+func (d *Dataset) ConstellationJumps() iter.Seq[ConstellationJump] {
+	return slices.Values(d.index.Data().ConstellationJumps)
+}
+
+// Stargates iterates over stargates in ID order.
+func (d *Dataset) Stargates() iter.Seq[Stargate] {
+	return slices.Values(d.index.Data().Stargates)
+}
+
+// SystemTopology iterates over the gate network metrics of each solar system
+// in ID order.
+func (d *Dataset) SystemTopology() iter.Seq[SystemTopology] {
+	return slices.Values(d.index.Data().SystemTopology)
+}
+
+// TopologyReport returns the gate network summary, or nil when the
+// systemTopology table was not loaded.
+func (d *Dataset) TopologyReport() *TopologyReport {
+	return d.index.Data().TopologyReport
+}
+
+// WormholeClasses iterates over wormhole class assignments of regions,
+// constellations and solar systems.
+func (d *Dataset) WormholeClasses() iter.Seq[WormholeClassLocation] {
+	return slices.Values(d.index.Data().WormholeClasses)
+}
+
+// SystemWormholeClasses iterates over the effective wormhole class of each
+// solar system that has one, in system ID order.
+func (d *Dataset) SystemWormholeClasses() iter.Seq[SystemWormholeClass] {
+	return slices.Values(d.index.Data().SystemWormholeClasses)
+}
+
+// Stations iterates over NPC stations in ID order.
+func (d *Dataset) Stations() iter.Seq[StaStation] {
+	return slices.Values(d.index.Data().Stations)
+}
+
+// Factions iterates over factions in ID order.
+func (d *Dataset) Factions() iter.Seq[ChrFaction] {
+	return slices.Values(d.index.Data().Factions)
+}
+
+// Races iterates over races in ID order.
+func (d *Dataset) Races() iter.Seq[ChrRace] {
+	return slices.Values(d.index.Data().Races)
+}
+
+// Corporations iterates over NPC corporations in ID order.
+func (d *Dataset) Corporations() iter.Seq[CrpNPCCorporation] {
+	return slices.Values(d.index.Data().Corporations)
+}
+
+// MarketGroups iterates over market groups in ID order.
+func (d *Dataset) MarketGroups() iter.Seq[InvMarketGroup] {
+	return slices.Values(d.index.Data().MarketGroups)
+}
+
+// DogmaAttributes iterates over dogma attribute definitions in ID order.
+func (d *Dataset) DogmaAttributes() iter.Seq[DgmAttributeType] {
+	return slices.Values(d.index.Data().DogmaAttributes)
+}
+
+// DogmaEffects iterates over dogma effect definitions in ID order.
+func (d *Dataset) DogmaEffects() iter.Seq[DgmEffect] {
+	return slices.Values(d.index.Data().DogmaEffects)
+}
+
+// TypeAttributes iterates over per-type attribute values, by type then attribute.
+func (d *Dataset) TypeAttributes() iter.Seq[DgmTypeAttribute] {
+	return slices.Values(d.index.Data().TypeAttributes)
+}
+
+// TypeEffects iterates over per-type effects, by type then effect.
+func (d *Dataset) TypeEffects() iter.Seq[DgmTypeEffect] {
+	return slices.Values(d.index.Data().TypeEffects)
+}
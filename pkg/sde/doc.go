@@ -0,0 +1,29 @@
+// Package sde loads EVE Online's Static Data Export into typed, Fuzzwork-shaped
+// records. It is the supported Go API of the converter; the sdeconvert CLI is a
+// thin layer that calls Load and writes the resulting Dataset to disk.
+//
+// A typical program loads a local SDE directory and queries it:
+//
+//	ds, err := sde.Load(ctx, sde.Dir("./sde"), sde.WithTables(sde.TableSystems, sde.TableJumps))
+//	if err != nil {
+//		return err
+//	}
+//	jita, ok := ds.SolarSystemByName("Jita")
+//	for jump := range ds.Jumps() {
+//		...
+//	}
+//
+// # Compatibility
+//
+// This package follows semantic versioning. Within a major version:
+//
+//   - Exported identifiers are not removed, renamed or changed in an incompatible way.
+//   - New functions, methods, options, table constants and struct fields may be added.
+//     Construct records with keyed fields, not positional literals.
+//   - Record fields keep their meaning and their JSON names. Their order follows the
+//     Fuzzwork column order of each table and may grow at the end.
+//   - Row order returned by iterators (sorted by ID) is stable.
+//
+// Validation warnings and log text are informational and may change at any time.
+// Everything under internal/ is outside this promise.
+package sde
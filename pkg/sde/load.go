@@ -0,0 +1,262 @@
+package sde
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/downloader"
+	"github.com/guarzo/wanderer-sde/internal/parser"
+	"github.com/guarzo/wanderer-sde/internal/transformer"
+)
+
+// Source describes where Load reads the SDE from.
+type Source struct {
+	// Path is an SDE directory or ZIP archive. When Download is set it is the
+	// directory the download is extracted to, defaulting to CacheDir/sde.
+	Path string
+
+	// Download fetches the latest SDE from CCP unless the copy at Path is
+	// already the latest build.
+	Download bool
+
+	// CacheDir is where the build number of the downloaded SDE is recorded.
+	// It is required when Download is set.
+	CacheDir string
+
+	// URL overrides the SDE download URL.
+	URL string
+
+	// VersionURL overrides the URL used to look up the latest build number.
+	VersionURL string
+}
+
+// Dir returns a Source reading an existing SDE directory or ZIP archive.
+func Dir(path string) Source {
+	return Source{Path: path}
+}
+
+// Latest returns a Source that downloads the latest SDE into cacheDir/sde,
+// reusing the existing copy when it is up to date.
+func Latest(cacheDir string) Source {
+	return Source{Download: true, CacheDir: cacheDir}
+}
+
+// ValidationError is returned by Load when the loaded data fails validation.
+type ValidationError struct {
+	Result *ValidationResult
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("validation failed with %d errors", len(e.Result.Errors))
+}
+
+// Load reads, converts and validates the SDE described by source.
+// It returns a *ValidationError when the converted data fails validation.
+func Load(ctx context.Context, source Source, opts ...Option) (*Dataset, error) {
+	o := newOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	cfg := o.config
+	cfg.Log = o.log
+	if source.URL != "" {
+		cfg.SDEUrl = source.URL
+	}
+	if source.VersionURL != "" {
+		cfg.VersionURL = source.VersionURL
+	}
+	if err := cfg.ValidateData(); err != nil {
+		return nil, err
+	}
+
+	sdePath, versionInfo, err := prepare(ctx, cfg, source, o.log)
+	if err != nil {
+		return nil, err
+	}
+
+	// ZIP archives are extracted to a temporary directory for the duration of the parse
+	if strings.EqualFold(filepath.Ext(sdePath), ".zip") {
+		extracted, err := os.MkdirTemp("", "sde-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create extraction directory: %w", err)
+		}
+		defer func() { _ = os.RemoveAll(extracted) }()
+
+		if _, err := downloader.New(cfg).Extract(sdePath, extracted); err != nil {
+			return nil, fmt.Errorf("failed to extract SDE: %w", err)
+		}
+		sdePath = extracted
+	}
+
+	if err := downloader.New(cfg).Validate(sdePath); err != nil {
+		return nil, fmt.Errorf("SDE validation failed: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(o.log, "Using SDE at: %s\n", displayPath(source, sdePath))
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	parseResult, err := parser.New(cfg, sdePath).ParseAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SDE: %w", err)
+	}
+	logParseCounts(o.log, parseResult)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	t := transformer.New(cfg)
+	data, err := t.Transform(parseResult)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform data: %w", err)
+	}
+
+	validation := t.Validate(data)
+	ds := newDataset(displayPath(source, sdePath), versionInfo, data, validation)
+	if !validation.IsValid() {
+		return ds, &ValidationError{Result: validation}
+	}
+	return ds, nil
+}
+
+// displayPath returns the SDE path to report: the archive rather than its
+// temporary extraction directory.
+func displayPath(source Source, sdePath string) string {
+	if !source.Download && source.Path != "" {
+		return source.Path
+	}
+	return sdePath
+}
+
+// prepare returns the SDE path to read, downloading the SDE first when the
+// source asks for it. versionInfo is nil when the SDE was not downloaded or
+// its version could not be checked.
+func prepare(ctx context.Context, cfg *config.Config, source Source, log io.Writer) (string, *VersionInfo, error) {
+	if !source.Download {
+		if source.Path == "" {
+			return "", nil, fmt.Errorf("no SDE path available")
+		}
+		return source.Path, nil, nil
+	}
+	if source.CacheDir == "" {
+		return "", nil, fmt.Errorf("a cache directory is required to download the SDE")
+	}
+
+	sdePath := source.Path
+	if sdePath == "" {
+		sdePath = filepath.Join(source.CacheDir, "sde")
+	}
+
+	vc := downloader.NewVersionChecker(cfg)
+
+	needsUpdate, versionInfo, err := vc.NeedsUpdate(ctx, source.CacheDir)
+	if err != nil {
+		_, _ = fmt.Fprintf(log, "Warning: could not check SDE version: %v\n", err)
+		needsUpdate = true // Proceed with download anyway
+	}
+
+	// Also check if the SDE directory exists
+	if _, err := os.Stat(sdePath); os.IsNotExist(err) {
+		needsUpdate = true
+	}
+
+	if !needsUpdate {
+		_, _ = fmt.Fprintln(log, "SDE is up to date, using cached version")
+		return sdePath, versionInfo, nil
+	}
+
+	_, _ = fmt.Fprintln(log, "Downloading latest SDE...")
+
+	// Download to a temp location first
+	downloadedPath, err := downloader.New(cfg).DownloadAndExtract(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to download SDE: %w", err)
+	}
+
+	// Remove old SDE directory if it exists
+	if err := os.RemoveAll(sdePath); err != nil && !os.IsNotExist(err) {
+		_, _ = fmt.Fprintf(log, "Warning: could not remove old SDE: %v\n", err)
+	}
+
+	// Move downloaded SDE to the persistent location
+	if err := os.Rename(downloadedPath, sdePath); err != nil {
+		// If rename fails (cross-device), fall back to copy
+		if err := copyDir(downloadedPath, sdePath); err != nil {
+			return "", nil, fmt.Errorf("failed to move SDE to %s: %w", sdePath, err)
+		}
+		_ = os.RemoveAll(downloadedPath)
+	}
+
+	_, _ = fmt.Fprintf(log, "SDE downloaded and extracted to: %s\n", sdePath)
+
+	// Store the version
+	if versionInfo != nil {
+		if err := vc.StoreVersion(source.CacheDir, versionInfo.BuildNumber); err != nil {
+			_, _ = fmt.Fprintf(log, "Warning: could not store version: %v\n", err)
+		}
+	}
+
+	return sdePath, versionInfo, nil
+}
+
+// logParseCounts writes the number of parsed SDE records.
+func logParseCounts(log io.Writer, result *parser.ParseResult) {
+	_, _ = fmt.Fprintf(log, "\nParsing complete:\n")
+	_, _ = fmt.Fprintf(log, "  Regions:         %d\n", len(result.Regions))
+	_, _ = fmt.Fprintf(log, "  Constellations:  %d\n", len(result.Constellations))
+	_, _ = fmt.Fprintf(log, "  Solar Systems:   %d\n", len(result.SolarSystems))
+	_, _ = fmt.Fprintf(log, "  Types:           %d\n", len(result.Types))
+	_, _ = fmt.Fprintf(log, "  Groups:          %d\n", len(result.Groups))
+	_, _ = fmt.Fprintf(log, "  Categories:      %d\n", len(result.Categories))
+	_, _ = fmt.Fprintf(log, "  Wormhole Classes: %d\n", len(result.WormholeClasses))
+	_, _ = fmt.Fprintf(log, "  System Jumps:    %d\n", len(result.SystemJumps))
+}
+
+// copyDir recursively copies a directory tree.
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Calculate destination path
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		dstPath := filepath.Join(dst, relPath)
+
+		if info.IsDir() {
+			return os.MkdirAll(dstPath, info.Mode())
+		}
+
+		return copyFile(path, dstPath)
+	})
+}
+
+// copyFile copies a single file.
+func copyFile(src, dst string) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = srcFile.Close() }()
+
+	dstFile, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dstFile.Close() }()
+
+	_, err = io.Copy(dstFile, srcFile)
+	return err
+}
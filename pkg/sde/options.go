@@ -0,0 +1,73 @@
+package sde
+
+import (
+	"io"
+
+	"github.com/guarzo/wanderer-sde/internal/config"
+)
+
+// Option configures Load.
+type Option func(*options)
+
+// options holds the settings built up by Options.
+type options struct {
+	config *config.Config
+	log    io.Writer
+}
+
+// newOptions returns the defaults: every table, default thresholds and no log output.
+func newOptions() *options {
+	return &options{config: config.NewConfig(), log: io.Discard}
+}
+
+// WithTables limits loading to the named tables. Tables that are not needed
+// are not parsed, so selecting only map tables skips the large types file.
+func WithTables(tables ...string) Option {
+	return func(o *options) { o.config.Tables = tables }
+}
+
+// WithoutTables skips the named tables.
+func WithoutTables(tables ...string) Option {
+	return func(o *options) { o.config.ExcludeTables = tables }
+}
+
+// WithDogmaTypes limits dgmTypeAttributes and dgmTypeEffects to the types
+// selected by the given filters (TypeFilterPublished, TypeFilterShips).
+func WithDogmaTypes(filters ...string) Option {
+	return func(o *options) { o.config.DogmaTypes = filters }
+}
+
+// WithMarketGroupTree also builds the nested market group tree
+// (ConvertedData.MarketGroupTree).
+func WithMarketGroupTree(enabled bool) Option {
+	return func(o *options) { o.config.MarketGroupTree = enabled }
+}
+
+// WithSecurityColumns also sets the derived DisplaySecurity, SecurityBand and
+// WormholeSpace fields of each solar system.
+func WithSecurityColumns(enabled bool) Option {
+	return func(o *options) { o.config.SecurityColumns = enabled }
+}
+
+// WithProjectionColumns also sets the projected 2D map position (ProjectedX,
+// ProjectedY) of each solar system.
+func WithProjectionColumns(enabled bool) Option {
+	return func(o *options) { o.config.ProjectionColumns = enabled }
+}
+
+// WithThresholds sets the minimum row counts used when validating the dataset.
+func WithThresholds(thresholds Thresholds) Option {
+	return func(o *options) { o.config.Thresholds = thresholds }
+}
+
+// WithLog writes progress messages to w. By default nothing is written.
+func WithLog(w io.Writer) Option {
+	return func(o *options) { o.log = w }
+}
+
+// WithVerbose enables detailed progress messages from each pipeline step.
+// Verbose messages are written to the WithLog writer, so they are discarded
+// unless one is set.
+func WithVerbose(enabled bool) Option {
+	return func(o *options) { o.config.Verbose = enabled }
+}
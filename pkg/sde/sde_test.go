@@ -0,0 +1,278 @@
+package sde
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testSDEFiles is a minimal SDE with two connected systems in one constellation.
+var testSDEFiles = map[string]string{
+	"mapRegions.yaml": `10000002:
+  name:
+    en: "The Forge"
+`,
+	"mapConstellations.yaml": `20000020:
+  regionID: 10000002
+  name:
+    en: "Kimotoro"
+`,
+	"mapSolarSystems.yaml": `30000142:
+  constellationID: 20000020
+  regionID: 10000002
+  name:
+    en: "Jita"
+  securityStatus: 0.9459
+  starID: 40000006
+30000144:
+  constellationID: 20000020
+  regionID: 10000002
+  name:
+    en: "Perimeter"
+  securityStatus: 0.94
+`,
+	"mapStars.yaml": `40000006:
+  solarSystemID: 30000142
+  typeID: 3796
+  radius: 123456789.0
+`,
+	"mapStargates.yaml": `50000001:
+  solarSystemID: 30000142
+  destination:
+    solarSystemID: 30000144
+    stargateID: 50000002
+  typeID: 16
+50000002:
+  solarSystemID: 30000144
+  destination:
+    solarSystemID: 30000142
+    stargateID: 50000001
+  typeID: 16
+`,
+	"types.yaml": `587:
+  groupID: 25
+  name:
+    en: "Rifter"
+  published: true
+`,
+	"groups.yaml": `25:
+  categoryID: 6
+  name:
+    en: "Frigate"
+  published: true
+`,
+	"categories.yaml": `6:
+  name:
+    en: "Ship"
+  published: true
+`,
+}
+
+// writeTestSDE writes testSDEFiles to a temporary directory and returns it.
+func writeTestSDE(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range testSDEFiles {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+// lowThresholds keeps validation quiet for the small test SDE.
+var lowThresholds = WithThresholds(Thresholds{
+	MinSolarSystems: 1, MinRegions: 1, MinConstellations: 1, MinTypes: 1, MinSystemJumps: 1, MinWormholeClasses: 1,
+})
+
+func TestLoad_VerboseLog(t *testing.T) {
+	dir := writeTestSDE(t)
+
+	// Swap standard output for a pipe to catch any direct writes
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	var log bytes.Buffer
+	_, loadErr := Load(context.Background(), Dir(dir), lowThresholds, WithVerbose(true), WithLog(&log))
+	_, _ = Load(context.Background(), Dir(dir), lowThresholds, WithVerbose(true))
+
+	os.Stdout = stdout
+	_ = w.Close()
+	written, _ := io.ReadAll(r)
+
+	if loadErr != nil {
+		t.Fatalf("Load() error = %v", loadErr)
+	}
+	if !strings.Contains(log.String(), "Parsing SDE files...") || !strings.Contains(log.String(), "Transforming SDE data...") {
+		t.Errorf("verbose messages missing from log:\n%s", log.String())
+	}
+	if len(written) != 0 {
+		t.Errorf("expected nothing on standard output, got:\n%s", written)
+	}
+}
+
+func TestLoad_Dir(t *testing.T) {
+	dir := writeTestSDE(t)
+
+	ds, err := Load(context.Background(), Dir(dir), lowThresholds)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if ds.SDEPath != dir {
+		t.Errorf("SDEPath = %q, want %q", ds.SDEPath, dir)
+	}
+	if ds.Version != nil {
+		t.Errorf("Version = %+v, want nil for a local SDE", ds.Version)
+	}
+	if ds.Validation.SolarSystems != 2 {
+		t.Errorf("Validation.SolarSystems = %d, want 2", ds.Validation.SolarSystems)
+	}
+
+	jita, ok := ds.SolarSystem(30000142)
+	if !ok || jita.SolarSystemName != "Jita" {
+		t.Errorf("SolarSystem(30000142) = %q, %v; want Jita", jita.SolarSystemName, ok)
+	}
+	if s, ok := ds.SolarSystemByName("perimeter"); !ok || s.SolarSystemID != 30000144 {
+		t.Errorf("SolarSystemByName(perimeter) = %d, %v; want 30000144", s.SolarSystemID, ok)
+	}
+	if got := ds.Neighbours(30000142); !slices.Equal(got, []int64{30000144}) {
+		t.Errorf("Neighbours(30000142) = %v, want [30000144]", got)
+	}
+	if n := len(slices.Collect(ds.SystemsInRegion(10000002))); n != 2 {
+		t.Errorf("SystemsInRegion(10000002) returned %d systems, want 2", n)
+	}
+	if n := len(slices.Collect(ds.TypesInCategory(6))); n != 1 {
+		t.Errorf("TypesInCategory(6) returned %d types, want 1", n)
+	}
+	if _, ok := ds.SolarSystem(1); ok {
+		t.Error("SolarSystem(1) found, want missing")
+	}
+	if r, ok := ds.RegionByName("The Forge"); !ok || r.RegionID != 10000002 {
+		t.Errorf("RegionByName(The Forge) = %d, %v; want 10000002", r.RegionID, ok)
+	}
+	if c, ok := ds.Constellation(20000020); !ok || c.ConstellationName != "Kimotoro" {
+		t.Errorf("Constellation(20000020) = %q, %v; want Kimotoro", c.ConstellationName, ok)
+	}
+	if typ, ok := ds.TypeByName("Rifter"); !ok || typ.TypeID != 587 {
+		t.Errorf("TypeByName(Rifter) = %d, %v; want 587", typ.TypeID, ok)
+	}
+	if g, ok := ds.Group(25); !ok || g.GroupName != "Frigate" {
+		t.Errorf("Group(25) = %q, %v; want Frigate", g.GroupName, ok)
+	}
+
+	var names []string
+	for s := range ds.SolarSystems() {
+		names = append(names, s.SolarSystemName)
+	}
+	if !slices.Equal(names, []string{"Jita", "Perimeter"}) {
+		t.Errorf("SolarSystems() = %v, want [Jita Perimeter]", names)
+	}
+	if jumps := slices.Collect(ds.Jumps()); len(jumps) != 2 {
+		t.Errorf("Jumps() returned %d jumps, want 2", len(jumps))
+	}
+}
+
+func TestLoad_Zip(t *testing.T) {
+	zipPath := filepath.Join(t.TempDir(), "sde.zip")
+	f, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatalf("failed to create zip: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	for name, content := range testSDEFiles {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("failed to add %s: %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip: %v", err)
+	}
+	_ = f.Close()
+
+	ds, err := Load(context.Background(), Dir(zipPath), lowThresholds)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if ds.SDEPath != zipPath {
+		t.Errorf("SDEPath = %q, want %q", ds.SDEPath, zipPath)
+	}
+	if _, ok := ds.SolarSystemByName("Jita"); !ok {
+		t.Error("SolarSystemByName(Jita) not found")
+	}
+}
+
+func TestLoad_WithTables(t *testing.T) {
+	dir := writeTestSDE(t)
+	// A broken types.yaml only loads if the types table is skipped
+	if err := os.WriteFile(filepath.Join(dir, "types.yaml"), []byte("not: [valid"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ds, err := Load(context.Background(), Dir(dir), lowThresholds, WithTables(TableSystems, TableJumps))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if n := len(slices.Collect(ds.Types())); n != 0 {
+		t.Errorf("Types() returned %d types, want 0", n)
+	}
+	if _, ok := ds.SolarSystem(30000142); !ok {
+		t.Error("SolarSystem(30000142) not found")
+	}
+}
+
+func TestLoad_ValidationError(t *testing.T) {
+	dir := writeTestSDE(t)
+	if err := os.WriteFile(filepath.Join(dir, "mapSolarSystems.yaml"), []byte("{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ds, err := Load(context.Background(), Dir(dir), lowThresholds)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Load() error = %v, want *ValidationError", err)
+	}
+	if ds == nil || ds.Validation != validationErr.Result {
+		t.Error("Load() should return the dataset together with its validation result")
+	}
+	if !slices.Contains(validationErr.Result.Errors, "No solar systems found") {
+		t.Errorf("Errors = %v, want 'No solar systems found'", validationErr.Result.Errors)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir := writeTestSDE(t)
+
+	if _, err := Load(context.Background(), Dir(dir), WithTables("bogus")); err == nil {
+		t.Error("Load() with an unknown table should fail")
+	}
+	if _, err := Load(context.Background(), Dir(t.TempDir())); err == nil {
+		t.Error("Load() of an empty directory should fail")
+	}
+	if _, err := Load(context.Background(), Source{}); err == nil {
+		t.Error("Load() without a path should fail")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Load(ctx, Dir(dir)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Load() with a cancelled context error = %v, want context.Canceled", err)
+	}
+}
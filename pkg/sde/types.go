@@ -0,0 +1,139 @@
+package sde
+
+import (
+	"github.com/guarzo/wanderer-sde/internal/config"
+	"github.com/guarzo/wanderer-sde/internal/downloader"
+	"github.com/guarzo/wanderer-sde/internal/models"
+)
+
+// Record types. Each is one row of the output table of the same name.
+type (
+	// SolarSystem is a row of mapSolarSystems.
+	SolarSystem = models.SolarSystem
+	// GateFlags are the solar system flags derived from its stargates.
+	GateFlags = models.GateFlags
+	// Region is a row of mapRegions.
+	Region = models.Region
+	// Constellation is a row of mapConstellations.
+	Constellation = models.Constellation
+	// WormholeClassLocation is a row of mapLocationWormholeClasses.
+	WormholeClassLocation = models.WormholeClassLocation
+	// SystemWormholeClass is a row of mapSolarSystemWormholeClasses.
+	SystemWormholeClass = models.SystemWormholeClass
+	// InvType is a row of invTypes.
+	InvType = models.InvType
+	// InvGroup is a row of invGroups.
+	InvGroup = models.InvGroup
+	// SystemJump is a row of mapSolarSystemJumps.
+	SystemJump = models.SystemJump
+	// RegionJump is a row of mapRegionJumps.
+	RegionJump = models.RegionJump
+	// ConstellationJump is a row of mapConstellationJumps.
+	ConstellationJump = models.ConstellationJump
+	// Stargate is a row of mapStargates.
+	Stargate = models.Stargate
+	// SystemTopology is a row of mapSystemTopology.
+	SystemTopology = models.SystemTopology
+	// TopologyReport summarises the stargate network.
+	TopologyReport = models.TopologyReport
+	// TopologyBridge is a gate connection listed in the topology report.
+	TopologyBridge = models.TopologyBridge
+	// TopologyPipe is a pipe listed in the topology report.
+	TopologyPipe = models.TopologyPipe
+	// TopologyRank is a system ranked by betweenness in the topology report.
+	TopologyRank = models.TopologyRank
+	// StaStation is a row of staStations.
+	StaStation = models.StaStation
+	// StaService is a row of staServices.
+	StaService = models.StaService
+	// StaOperationService is a row of staOperationServices.
+	StaOperationService = models.StaOperationService
+	// ChrFaction is a row of chrFactions.
+	ChrFaction = models.ChrFaction
+	// ChrRace is a row of chrRaces.
+	ChrRace = models.ChrRace
+	// CrpNPCCorporation is a row of crpNPCCorporations.
+	CrpNPCCorporation = models.CrpNPCCorporation
+	// InvMarketGroup is a row of invMarketGroups.
+	InvMarketGroup = models.InvMarketGroup
+	// MarketGroupNode is a node of the nested market group tree.
+	MarketGroupNode = models.MarketGroupNode
+	// DgmAttributeType is a row of dgmAttributeTypes.
+	DgmAttributeType = models.DgmAttributeType
+	// DgmEffect is a row of dgmEffects.
+	DgmEffect = models.DgmEffect
+	// DgmTypeAttribute is a row of dgmTypeAttributes.
+	DgmTypeAttribute = models.DgmTypeAttribute
+	// DgmTypeEffect is a row of dgmTypeEffects.
+	DgmTypeEffect = models.DgmTypeEffect
+	// LocalizedText holds translations keyed by SDE language code.
+	LocalizedText = models.LocalizedText
+)
+
+// ConvertedData holds every loaded table, as written by the CLI.
+type ConvertedData = models.ConvertedData
+
+// ValidationResult holds the row counts, warnings and errors found when
+// validating a loaded dataset.
+type ValidationResult = models.ValidationResult
+
+// VersionInfo describes a downloaded SDE build.
+type VersionInfo = downloader.VersionInfo
+
+// Thresholds holds the minimum expected row counts used by validation.
+// Counts below a threshold produce a warning.
+type Thresholds = config.ValidationThresholds
+
+// DefaultThresholds returns thresholds based on the known EVE universe size.
+func DefaultThresholds() Thresholds {
+	return config.DefaultThresholds()
+}
+
+// Table names accepted by WithTables and WithoutTables.
+const (
+	TableSystems               = config.TableSystems
+	TableRegions               = config.TableRegions
+	TableConstellations        = config.TableConstellations
+	TableWormholeClasses       = config.TableWormholeClasses
+	TableSystemWormholeClasses = config.TableSystemWormholeClasses
+	TableTypes                 = config.TableTypes
+	TableGroups                = config.TableGroups
+	TableJumps                 = config.TableJumps
+	TableRegionJumps           = config.TableRegionJumps
+	TableConstellationJumps    = config.TableConstellationJumps
+	TableStargates             = config.TableStargates
+	TableSystemTopology        = config.TableSystemTopology
+	TableStations              = config.TableStations
+	TableStationServices       = config.TableStationServices
+	TableOperationServices     = config.TableOperationServices
+	TableFactions              = config.TableFactions
+	TableRaces                 = config.TableRaces
+	TableCorporations          = config.TableCorporations
+	TableMarketGroups          = config.TableMarketGroups
+	TableDogmaAttributes       = config.TableDogmaAttributes
+	TableDogmaEffects          = config.TableDogmaEffects
+	TableTypeAttributes        = config.TableTypeAttributes
+	TableTypeEffects           = config.TableTypeEffects
+)
+
+// Type filters accepted by WithDogmaTypes.
+const (
+	TypeFilterPublished = config.TypeFilterPublished
+	TypeFilterShips     = config.TypeFilterShips
+)
+
+// Security bands set in SolarSystem.SecurityBand by WithSecurityColumns.
+const (
+	SecurityBandHighsec  = models.SecurityBandHighsec
+	SecurityBandLowsec   = models.SecurityBandLowsec
+	SecurityBandNullsec  = models.SecurityBandNullsec
+	SecurityBandWormhole = models.SecurityBandWormhole
+	SecurityBandPochven  = models.SecurityBandPochven
+	SecurityBandAbyssal  = models.SecurityBandAbyssal
+	SecurityBandZarzakh  = models.SecurityBandZarzakh
+)
+
+// Tables returns every table name in output order.
+func Tables() []string {
+	return append([]string(nil), config.AllTables...)
+}
@@ -2,6 +2,9 @@
 package yaml
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -45,3 +48,76 @@ func ParseFileMap[K comparable, V any](path string) (map[K]V, error) {
 	}
 	return result, nil
 }
+
+// StreamFileMap reads a YAML file whose top level is a map and calls fn for each
+// entry in file order, decoding one entry at a time. Unlike ParseFileMap it never
+// holds the whole file in memory, which matters for large files like typeDogma.yaml.
+//
+// Entries are split on top-level keys, i.e. lines that start in the first column.
+// This holds for all SDE files, whose values are always nested block mappings.
+func StreamFileMap[K comparable, V any](path string, fn func(key K, value V) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return StreamMap(f, fn)
+}
+
+// StreamMap decodes a top-level YAML map from r one entry at a time. See StreamFileMap.
+func StreamMap[K comparable, V any](r io.Reader, fn func(key K, value V) error) error {
+	reader := bufio.NewReaderSize(r, 64*1024)
+	var entry bytes.Buffer
+	var lineNo, entryLine int
+
+	flush := func() error {
+		if entry.Len() == 0 {
+			return nil
+		}
+		var decoded map[K]V
+		if err := yaml.Unmarshal(entry.Bytes(), &decoded); err != nil {
+			return fmt.Errorf("failed to decode YAML entry at line %d: %w", entryLine, err)
+		}
+		entry.Reset()
+		for key, value := range decoded {
+			if err := fn(key, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			lineNo++
+			if startsEntry(line) {
+				if err := flush(); err != nil {
+					return err
+				}
+				entryLine = lineNo
+			}
+			if !bytes.Equal(bytes.TrimSpace(line), []byte("---")) {
+				entry.Write(line)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read YAML: %w", err)
+		}
+	}
+
+	return flush()
+}
+
+// startsEntry reports whether line begins a new top-level map entry.
+func startsEntry(line []byte) bool {
+	switch line[0] {
+	case ' ', '\t', '\n', '\r', '#', '-':
+		return false
+	}
+	return true
+}
@@ -1,6 +1,7 @@
 package yaml
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -286,3 +287,66 @@ func TestParseFileMap_NestedStructs(t *testing.T) {
 		t.Errorf("Expected stats value 100, got %d", entry.Stats.Value)
 	}
 }
+
+func TestStreamMap(t *testing.T) {
+	yamlContent := `# leading comment
+---
+1:
+  name: "first"
+  values: [1, 2]
+
+2:
+  name: "second"
+  values:
+    - 3
+`
+	type item struct {
+		Name   string  `yaml:"name"`
+		Values []int64 `yaml:"values"`
+	}
+
+	var keys []int64
+	var names []string
+	err := StreamMap(strings.NewReader(yamlContent), func(key int64, value item) error {
+		keys = append(keys, key)
+		names = append(names, value.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamMap failed: %v", err)
+	}
+
+	if len(keys) != 2 || keys[0] != 1 || keys[1] != 2 {
+		t.Errorf("Expected keys [1 2] in file order, got %v", keys)
+	}
+	if names[0] != "first" || names[1] != "second" {
+		t.Errorf("Unexpected names: %v", names)
+	}
+}
+
+func TestStreamMap_CallbackError(t *testing.T) {
+	yamlContent := "1:\n  name: a\n2:\n  name: b\n"
+	stop := errors.New("stop")
+
+	var calls int
+	err := StreamMap(strings.NewReader(yamlContent), func(key int64, value map[string]string) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("Expected callback error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected streaming to stop after 1 call, got %d", calls)
+	}
+}
+
+func TestStreamMap_MalformedEntry(t *testing.T) {
+	yamlContent := "1:\n  name: a\n2:\n  name: [unclosed\n"
+	err := StreamMap(strings.NewReader(yamlContent), func(key int64, value map[string]interface{}) error {
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("Expected decode error mentioning line 3, got %v", err)
+	}
+}